@@ -0,0 +1,213 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultCacheMaxBytes is the size of the response bodies an APIClient keeps
+// when ClientConfig.CacheMaxBytes is not set.
+const DefaultCacheMaxBytes = 8 << 20
+
+// responseCache keeps the bodies of GET responses carrying an ETag so that
+// they can be revalidated with If-None-Match and reused when the service
+// answers 304 Not Modified. Only JSON responses are kept, and the least
+// recently used ones are evicted once the bodies exceed the size limit.
+type responseCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// cachedResponse is the last successful response to a GET request.
+type cachedResponse struct {
+	key    string
+	url    string
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// newResponseCache creates an empty response cache keeping at most maxBytes
+// of response bodies.
+func newResponseCache(maxBytes int64) *responseCache {
+	if maxBytes <= 0 {
+		maxBytes = DefaultCacheMaxBytes
+	}
+	return &responseCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// cacheURL returns the URL of a resource as kept in the cache.
+func cacheURL(url string) string {
+	return strings.TrimSuffix(url, "/")
+}
+
+// cacheKey returns the key of a request in the cache. The custom headers are
+// part of it since they may change the representation the service returns,
+// as Accept does.
+func cacheKey(url string, customHeaders map[string]string) string {
+	headers := make([]string, 0, len(customHeaders))
+	for k, v := range customHeaders {
+		headers = append(headers, http.CanonicalHeaderKey(k)+": "+v)
+	}
+	sort.Strings(headers)
+	return cacheURL(url) + "\n" + strings.Join(headers, "\n")
+}
+
+// lookup returns the cached response to revalidate for a request, or nil if
+// there is none. Requests already carrying their own conditions are left
+// alone.
+func (cache *responseCache) lookup(method, url string, customHeaders map[string]string) *cachedResponse {
+	if cache == nil || method != http.MethodGet {
+		return nil
+	}
+	for k := range customHeaders {
+		if strings.EqualFold(k, "If-None-Match") || strings.EqualFold(k, "If-Match") {
+			return nil
+		}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	element, ok := cache.entries[cacheKey(url, customHeaders)]
+	if !ok {
+		return nil
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*cachedResponse)
+}
+
+// store records the JSON response to a successful GET request, returning a
+// response the caller can still read. Bodies larger than the cache are not
+// read ahead, so that large or streamed responses reach the caller as they
+// come. Other requests drop the cached responses of their URL since they may
+// have changed the resource.
+func (cache *responseCache) store(method, url string, customHeaders map[string]string, resp *http.Response) (*http.Response, error) {
+	if cache == nil {
+		return resp, nil
+	}
+
+	if method != http.MethodGet {
+		cache.invalidate(url)
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("Etag")
+	if resp.StatusCode != http.StatusOK || etag == "" || !isJSON(resp.Header) ||
+		resp.ContentLength > cache.maxBytes {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, cache.maxBytes+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > cache.maxBytes {
+		// Too large to be kept, hand the rest of the body over unread
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cache.add(&cachedResponse{
+		key:    cacheKey(url, customHeaders),
+		url:    cacheURL(url),
+		etag:   etag,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+
+	return resp, nil
+}
+
+// add keeps a response, evicting the least recently used ones to stay within
+// the size limit.
+func (cache *responseCache) add(cached *cachedResponse) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, ok := cache.entries[cached.key]; ok {
+		cache.remove(element)
+	}
+	cache.entries[cached.key] = cache.order.PushFront(cached)
+	cache.size += int64(len(cached.body))
+
+	for cache.size > cache.maxBytes {
+		cache.remove(cache.order.Back())
+	}
+}
+
+// invalidate drops the cached responses of a URL, whatever the headers of
+// the requests.
+func (cache *responseCache) invalidate(url string) {
+	url = cacheURL(url)
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for element := cache.order.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*cachedResponse).url == url {
+			cache.remove(element)
+		}
+		element = next
+	}
+}
+
+// remove drops a cached response. The cache lock must be held.
+func (cache *responseCache) remove(element *list.Element) {
+	cached := cache.order.Remove(element).(*cachedResponse)
+	delete(cache.entries, cached.key)
+	cache.size -= int64(len(cached.body))
+}
+
+// isJSON reports whether the Content-Type of a response is JSON.
+func isJSON(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == applicationJSON
+}
+
+// readCloser reads from a reader and closes a closer, such as a body
+// partially read ahead.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// response builds a response from the cached one.
+func (cached *cachedResponse) response() *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+	}
+}
+
+// withHeader returns a copy of the custom headers with the given header set.
+func withHeader(customHeaders map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(customHeaders)+1)
+	for k, v := range customHeaders {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
@@ -0,0 +1,244 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// TestCacheResponses tests revalidating cached responses with If-None-Match.
+func TestCacheResponses(t *testing.T) {
+	var mu sync.Mutex
+	etag := `W/"1"`
+	body := `{"Id": "1", "AssetTag": "first"}`
+	var conditions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == common.DefaultServiceRoot {
+			w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPatch {
+			etag = `W/"2"`
+			body = `{"Id": "1", "AssetTag": "second"}`
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		conditions = append(conditions, r.Header.Get("If-None-Match"))
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body)) //nolint
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), CacheResponses: true})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	get := func() string {
+		resp, err := client.Get("/redfish/v1/Systems/1")
+		if err != nil {
+			t.Fatalf("Error getting resource: %s", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Unexpected status code: %d", resp.StatusCode)
+		}
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Error reading body: %s", err)
+		}
+		return string(b)
+	}
+
+	if result := get(); result != `{"Id": "1", "AssetTag": "first"}` {
+		t.Errorf("Unexpected first body: %s", result)
+	}
+	if result := get(); result != `{"Id": "1", "AssetTag": "first"}` {
+		t.Errorf("Unexpected cached body: %s", result)
+	}
+
+	resp, err := client.Patch("/redfish/v1/Systems/1", map[string]string{"AssetTag": "second"})
+	if err != nil {
+		t.Fatalf("Error patching resource: %s", err)
+	}
+	resp.Body.Close()
+
+	if result := get(); result != `{"Id": "1", "AssetTag": "second"}` {
+		t.Errorf("Unexpected body after update: %s", result)
+	}
+
+	expected := []string{"", `W/"1"`, ""}
+	if len(conditions) != len(expected) {
+		t.Fatalf("Expected %d GET requests, got %d", len(expected), len(conditions))
+	}
+	for i := range expected {
+		if conditions[i] != expected[i] {
+			t.Errorf("Request %d: expected If-None-Match %q, got %q", i+1, expected[i], conditions[i])
+		}
+	}
+}
+
+// TestCacheResponsesDisabled tests that responses are not cached by default.
+func TestCacheResponsesDisabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("Unexpected If-None-Match header: %s", r.Header.Get("If-None-Match"))
+		}
+		w.Header().Set("ETag", `W/"1"`)
+		w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(common.DefaultServiceRoot)
+		if err != nil {
+			t.Fatalf("Error getting resource: %s", err)
+		}
+		resp.Body.Close()
+	}
+}
+
+// TestCacheResponsesKeys tests that responses are only reused for requests
+// with the same headers, and that only JSON responses are cached.
+func TestCacheResponsesKeys(t *testing.T) {
+	var mu sync.Mutex
+	conditions := make(map[string][]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == common.DefaultServiceRoot {
+			w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+			return
+		}
+
+		mu.Lock()
+		key := r.URL.Path + " " + r.Header.Get("Accept")
+		conditions[key] = append(conditions[key], r.Header.Get("If-None-Match"))
+		mu.Unlock()
+
+		w.Header().Set("ETag", `W/"1"`)
+		if r.Header.Get("If-None-Match") == `W/"1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.URL.Path == "/redfish/v1/EventService/SSE" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("data: {}\n\n")) //nolint
+			return
+		}
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+		w.Write([]byte(`{"Id": "1"}`)) //nolint
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), CacheResponses: true})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	get := func(uri, accept string) {
+		var headers map[string]string
+		if accept != "" {
+			headers = map[string]string{"Accept": accept}
+		}
+		resp, err := client.GetWithHeaders(uri, headers)
+		if err != nil {
+			t.Fatalf("Error getting resource: %s", err)
+		}
+		defer resp.Body.Close()
+		if _, err := io.ReadAll(resp.Body); err != nil {
+			t.Fatalf("Error reading body: %s", err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		get("/redfish/v1/Systems/1", "")
+		get("/redfish/v1/Systems/1", "application/yaml")
+		get("/redfish/v1/EventService/SSE", "")
+	}
+
+	expected := map[string][]string{
+		"/redfish/v1/Systems/1 application/json":        {"", `W/"1"`},
+		"/redfish/v1/Systems/1 application/yaml":        {"", ""},
+		"/redfish/v1/EventService/SSE application/json": {"", ""},
+	}
+	for key, want := range expected {
+		got := conditions[key]
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: expected If-None-Match %q, got %q", key, want, got)
+		}
+	}
+}
+
+// TestResponseCacheEviction tests that the cache drops the least recently
+// used responses to stay within its size limit.
+func TestResponseCacheEviction(t *testing.T) {
+	cache := newResponseCache(10)
+
+	store := func(uri, body string) {
+		resp := &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Etag": []string{`W/"1"`}, "Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: -1,
+		}
+		resp, err := cache.store(http.MethodGet, uri, nil, resp)
+		if err != nil {
+			t.Fatalf("Error storing response: %s", err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		if err != nil || string(b) != body {
+			t.Errorf("Unexpected body returned for %s: %q, %v", uri, b, err)
+		}
+	}
+	cached := func(uri string) bool {
+		return cache.lookup(http.MethodGet, uri, nil) != nil
+	}
+
+	store("/a", "aaaa")
+	store("/b", "bbbb")
+	if !cached("/a") {
+		t.Error("Expected /a to be cached")
+	}
+	store("/c", "cccc")
+
+	if !cached("/a") || cached("/b") || !cached("/c") {
+		t.Errorf("Expected the least recently used /b to be evicted: %v %v %v",
+			cached("/a"), cached("/b"), cached("/c"))
+	}
+
+	store("/large", "0123456789a")
+	if cached("/large") {
+		t.Error("Expected a response larger than the cache not to be kept")
+	}
+	if cache.size != 8 {
+		t.Errorf("Unexpected cache size: %d", cache.size)
+	}
+
+	_, _ = cache.store(http.MethodPatch, "/a/", nil, &http.Response{Body: io.NopCloser(&bytes.Buffer{})})
+	if cached("/a") {
+		t.Error("Expected /a to be dropped after an update")
+	}
+}
@@ -0,0 +1,406 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// redacted replaces credentials in cassettes.
+const redacted = "REDACTED"
+
+// DefaultMaxRecordedBodyBytes is the size above which a Recorder leaves
+// bodies out of the cassette when its MaxBodyBytes is not set.
+const DefaultMaxRecordedBodyBytes = 4 << 20
+
+// Interaction is a request and its response, as recorded in a cassette.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	RecordedBody
+}
+
+// RecordedResponse is a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	RecordedBody
+}
+
+// RecordedBody is the body of a recorded request or response. Bodies that
+// are not valid UTF-8 are recorded in base64. Bodies too large to be
+// recorded are left out and marked as omitted.
+type RecordedBody struct {
+	Body        string `json:"body,omitempty"`
+	BodyBase64  string `json:"bodyBase64,omitempty"`
+	BodyOmitted bool   `json:"bodyOmitted,omitempty"`
+}
+
+// newRecordedBody records a body.
+func newRecordedBody(b []byte) RecordedBody {
+	if utf8.Valid(b) {
+		return RecordedBody{Body: string(b)}
+	}
+	return RecordedBody{BodyBase64: base64.StdEncoding.EncodeToString(b)}
+}
+
+// Bytes returns the recorded body.
+func (body *RecordedBody) Bytes() ([]byte, error) {
+	if body.BodyBase64 != "" {
+		return base64.StdEncoding.DecodeString(body.BodyBase64)
+	}
+	return []byte(body.Body), nil
+}
+
+// Recorder is an http.RoundTripper recording the requests sent through it
+// and their responses to a cassette, which can be replayed with a Replayer.
+// The cassette holds one JSON encoded Interaction per line, written once the
+// response body was read to the end or closed: responses whose body is left
+// open, which also leaks their connection, are missing from the cassette.
+// Credentials in headers and Password properties are redacted.
+//
+// Bodies are recorded as they are streamed, without being read ahead. Bodies
+// larger than MaxBodyBytes, such as firmware images or long event streams,
+// are left out of the cassette.
+type Recorder struct {
+	// MaxBodyBytes is the size above which request and response bodies are
+	// not recorded. Defaults to DefaultMaxRecordedBodyBytes.
+	MaxBodyBytes int64
+
+	transport http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+	// err is the first error writing the cassette.
+	err error
+}
+
+// NewRecorder creates a Recorder writing the cassette to w and sending the
+// requests through transport, or http.DefaultTransport if it is nil.
+func NewRecorder(w io.Writer, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport, w: w}
+}
+
+// Err returns the first error that occurred writing the cassette.
+func (recorder *Recorder) Err() error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return recorder.err
+}
+
+// RoundTrip sends a request and records it along with its response.
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: redactHeader(req.Header),
+		},
+	}
+
+	// The request body is recorded as the transport sends it
+	requestBody := recorder.newBodyBuffer()
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = readCloser{io.TeeReader(req.Body, requestBody), req.Body}
+	}
+
+	resp, err := recorder.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.Response.StatusCode = resp.StatusCode
+	interaction.Response.Header = redactHeader(resp.Header)
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		buf:        recorder.newBodyBuffer(),
+		done: func(b *bodyBuffer) {
+			interaction.Request.RecordedBody = requestBody.recorded(true)
+			interaction.Response.RecordedBody = b.recorded(false)
+			recorder.write(interaction)
+		},
+	}
+
+	return resp, nil
+}
+
+// newBodyBuffer creates a buffer recording a body up to the size limit.
+func (recorder *Recorder) newBodyBuffer() *bodyBuffer {
+	maxBytes := recorder.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxRecordedBodyBytes
+	}
+	return &bodyBuffer{maxBytes: maxBytes}
+}
+
+// write appends an interaction to the cassette.
+func (recorder *Recorder) write(interaction *Interaction) {
+	b, err := json.Marshal(interaction)
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if err == nil {
+		_, err = recorder.w.Write(append(b, '\n'))
+	}
+	if err != nil && recorder.err == nil {
+		recorder.err = err
+	}
+}
+
+// bodyBuffer keeps what is written to it until it exceeds its size limit,
+// after which the body is omitted. It is safe for concurrent use, since
+// transports may still be sending the request body when the response
+// arrives.
+type bodyBuffer struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	maxBytes int64
+	omitted  bool
+}
+
+// Write records p unless the body grew too large.
+func (b *bodyBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.omitted {
+		return len(p), nil
+	}
+	if int64(b.buf.Len()+len(p)) > b.maxBytes {
+		b.omitted = true
+		b.buf = bytes.Buffer{}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// recorded returns the recorded body, with its Password properties redacted
+// if redact is set.
+func (b *bodyBuffer) recorded(redact bool) RecordedBody {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.omitted {
+		return RecordedBody{BodyOmitted: true}
+	}
+	body := b.buf.Bytes()
+	if redact {
+		body = redactBody(body)
+	}
+	return newRecordedBody(body)
+}
+
+// recordingBody keeps what is read from a response body, passing it on once
+// the body is read or closed.
+type recordingBody struct {
+	io.ReadCloser
+	buf  *bodyBuffer
+	once sync.Once
+	done func(b *bodyBuffer)
+}
+
+// Read reads from the body, recording what was read.
+func (body *recordingBody) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+	_, _ = body.buf.Write(p[:n])
+	if err == io.EOF {
+		body.once.Do(func() { body.done(body.buf) })
+	}
+	return n, err
+}
+
+// Close closes the body, recording what was read so far.
+func (body *recordingBody) Close() error {
+	body.once.Do(func() { body.done(body.buf) })
+	return body.ReadCloser.Close()
+}
+
+// redactedHeaders lists the headers holding credentials.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Auth-Token"}
+
+// redactHeader returns a copy of a header with credentials redacted.
+func redactHeader(header http.Header) http.Header {
+	result := header.Clone()
+	for _, key := range redactedHeaders {
+		if result.Get(key) != "" {
+			result.Set(key, redacted)
+		}
+	}
+	return result
+}
+
+// redactBody returns a JSON body with its Password properties redacted.
+// Other bodies are returned as is.
+func redactBody(body []byte) []byte {
+	var value interface{}
+	if len(body) == 0 || json.Unmarshal(body, &value) != nil || !redactValue(value) {
+		return body
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// redactValue redacts the Password properties of a JSON value, reporting
+// whether there were any.
+func redactValue(value interface{}) bool {
+	found := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if strings.HasSuffix(key, "Password") {
+				if _, ok := item.(string); ok {
+					v[key] = redacted
+					found = true
+				}
+				continue
+			}
+			found = redactValue(item) || found
+		}
+	case []interface{}:
+		for _, item := range v {
+			found = redactValue(item) || found
+		}
+	}
+	return found
+}
+
+// Replayer is an http.RoundTripper answering requests with the responses of
+// a cassette written by a Recorder. Requests are matched with the recorded
+// ones by method, URL path and query, and body, JSON bodies being compared
+// by value. Interactions whose request body was omitted match any body, and
+// those whose response body was omitted answer with an empty body. Each
+// recorded interaction answers one request, in the recorded order. Requests
+// without a matching interaction fail.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewReplayer creates a Replayer for the cassette read from r.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	replayer := &Replayer{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("cassette line %d: %w", line, err)
+		}
+		replayer.interactions = append(replayer.interactions, &interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	replayer.used = make([]bool, len(replayer.interactions))
+	return replayer, nil
+}
+
+// RoundTrip answers a request with the first unused matching interaction.
+func (replayer *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	body = redactBody(body)
+
+	replayer.mu.Lock()
+	defer replayer.mu.Unlock()
+
+	for i, interaction := range replayer.interactions {
+		if replayer.used[i] || !interaction.matches(req, body) {
+			continue
+		}
+
+		responseBody, err := interaction.Response.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		replayer.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(responseBody)),
+			ContentLength: int64(len(responseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction left for %s %s", req.Method, req.URL.RequestURI())
+}
+
+// Unused returns the recorded interactions that were not replayed, to check
+// that a test made all the requests expected.
+func (replayer *Replayer) Unused() []Interaction {
+	replayer.mu.Lock()
+	defer replayer.mu.Unlock()
+
+	var result []Interaction
+	for i, interaction := range replayer.interactions {
+		if !replayer.used[i] {
+			result = append(result, *interaction)
+		}
+	}
+	return result
+}
+
+// matches checks whether a request matches the recorded one.
+func (interaction *Interaction) matches(req *http.Request, body []byte) bool {
+	if interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.RequestURI() {
+		return false
+	}
+	if interaction.Request.BodyOmitted {
+		return true
+	}
+
+	recorded, err := interaction.Request.Bytes()
+	if err != nil {
+		return false
+	}
+	if bytes.Equal(recorded, body) {
+		return true
+	}
+
+	var recordedValue, value interface{}
+	if json.Unmarshal(recorded, &recordedValue) != nil || json.Unmarshal(body, &value) != nil {
+		return false
+	}
+	return reflect.DeepEqual(recordedValue, value)
+}
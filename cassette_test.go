@@ -0,0 +1,242 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/redfishtest"
+)
+
+// cassetteSession runs the requests recorded and replayed by the tests.
+func cassetteSession(t *testing.T, config ClientConfig) {
+	config.Username = "admin"
+	config.Password = "secret"
+	client, err := Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	systems, err := client.Service.Systems()
+	if err != nil || len(systems) != 1 {
+		t.Fatalf("Expected 1 system, got %d: %v", len(systems), err)
+	}
+	if systems[0].SerialNumber != "ABC123" {
+		t.Errorf("Unexpected serial number: %s", systems[0].SerialNumber)
+	}
+
+	resp, err := client.Patch("/redfish/v1/Systems/1", map[string]interface{}{"AssetTag": "Rack 12"})
+	if err != nil {
+		t.Fatalf("Error updating system: %s", err)
+	}
+	resp.Body.Close()
+
+	client.Logout()
+}
+
+// TestRecordReplay tests replaying the requests recorded from a service.
+func TestRecordReplay(t *testing.T) {
+	server := newCrawlServer(t)
+	defer server.Close()
+	server.SetCredentials("admin", "secret")
+
+	var cassette bytes.Buffer
+	cassetteSession(t, ClientConfig{
+		Endpoint:     server.URL,
+		HTTPClient:   server.Client(),
+		RecordWriter: &cassette,
+	})
+
+	recorded := cassette.String()
+	if strings.Count(recorded, "\n") != 6 {
+		t.Errorf("Expected 6 interactions, got:\n%s", recorded)
+	}
+	if strings.Contains(recorded, "secret") {
+		t.Errorf("Expected the password to be redacted:\n%s", recorded)
+	}
+	resource, _ := server.Resource("/redfish/v1/SessionService/Sessions")
+	for _, member := range resource["Members"].([]interface{}) {
+		t.Errorf("Expected the session to be deleted: %v", member)
+	}
+
+	replayer, err := NewReplayer(strings.NewReader(recorded))
+	if err != nil {
+		t.Fatalf("Error loading cassette: %s", err)
+	}
+	cassetteSession(t, ClientConfig{
+		Endpoint:   "http://replay.invalid",
+		HTTPClient: &http.Client{Transport: replayer},
+	})
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("Expected all interactions to be replayed, got %d unused", len(unused))
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// TestRecordWriterError tests that errors writing the cassette are reported
+// through the Recorder of the client.
+func TestRecordWriterError(t *testing.T) {
+	server := newCrawlServer(t)
+	defer server.Close()
+
+	client, err := Connect(ClientConfig{
+		Endpoint:     server.URL,
+		HTTPClient:   server.Client(),
+		RecordWriter: failingWriter{},
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	if client.Recorder() == nil {
+		t.Fatal("Expected the client to have a Recorder")
+	}
+	if err := client.Recorder().Err(); err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected the write error to be reported, got: %v", err)
+	}
+
+	client, err = Connect(ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	if client.Recorder() != nil {
+		t.Error("Expected no Recorder when not recording")
+	}
+}
+
+// TestReplayUnmatched tests that requests not recorded fail.
+func TestReplayUnmatched(t *testing.T) {
+	server, err := redfishtest.NewServer(nil)
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+
+	var cassette bytes.Buffer
+	client, err := Connect(ClientConfig{
+		Endpoint:     server.URL,
+		HTTPClient:   server.Client(),
+		RecordWriter: &cassette,
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	resp, err := client.Post("/redfish/v1/SessionService/Sessions", map[string]interface{}{"UserName": "admin"})
+	if err != nil {
+		t.Fatalf("Error posting: %s", err)
+	}
+	resp.Body.Close()
+
+	replayer, err := NewReplayer(&cassette)
+	if err != nil {
+		t.Fatalf("Error loading cassette: %s", err)
+	}
+	client, err = Connect(ClientConfig{
+		Endpoint:   "http://replay.invalid",
+		HTTPClient: &http.Client{Transport: replayer},
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = client.Post("/redfish/v1/SessionService/Sessions", map[string]interface{}{"UserName": "operator"})
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction left for POST /redfish/v1/SessionService/Sessions") {
+		t.Errorf("Expected a request with another body to fail, got: %v", err)
+	}
+	resp, err = client.Post("/redfish/v1/SessionService/Sessions", map[string]interface{}{"UserName": "admin"})
+	if err != nil {
+		t.Fatalf("Error replaying request: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Auth-Token") != redacted {
+		t.Errorf("Unexpected replayed response: %d %v", resp.StatusCode, resp.Header)
+	}
+	if _, err = client.Get("/redfish/v1"); err == nil {
+		t.Error("Expected a request replayed twice to fail")
+	}
+}
+
+// TestRecordLargeBodies tests that bodies larger than the limit are streamed
+// and left out of the cassette.
+func TestRecordLargeBodies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.Write(bytes.Repeat([]byte("x"), len(b))) //nolint
+	}))
+	defer ts.Close()
+
+	var cassette bytes.Buffer
+	recorder := NewRecorder(&cassette, ts.Client().Transport)
+	recorder.MaxBodyBytes = 16
+	client := &http.Client{Transport: recorder}
+
+	post := func(client *http.Client, body string) string {
+		resp, err := client.Post(ts.URL+"/redfish/v1/UpdateService/upload", "application/octet-stream", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Error posting: %s", err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Error reading response: %s", err)
+		}
+		return string(b)
+	}
+
+	large := strings.Repeat("firmware", 8)
+	if result := post(client, large); result != strings.Repeat("x", len(large)) {
+		t.Errorf("Unexpected response to the large request: %q", result)
+	}
+	if result := post(client, "small"); result != "xxxxx" {
+		t.Errorf("Unexpected response to the small request: %q", result)
+	}
+
+	var interactions []Interaction
+	decoder := json.NewDecoder(bytes.NewReader(cassette.Bytes()))
+	for decoder.More() {
+		var interaction Interaction
+		if err := decoder.Decode(&interaction); err != nil {
+			t.Fatalf("Error decoding cassette: %s", err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if len(interactions) != 2 {
+		t.Fatalf("Expected 2 interactions, got %d", len(interactions))
+	}
+	if !interactions[0].Request.BodyOmitted || interactions[0].Request.Body != "" ||
+		!interactions[0].Response.BodyOmitted || interactions[0].Response.Body != "" {
+		t.Errorf("Expected the large bodies to be omitted: %#v", interactions[0])
+	}
+	if interactions[1].Request.BodyOmitted || interactions[1].Request.Body != "small" ||
+		interactions[1].Response.Body != "xxxxx" {
+		t.Errorf("Expected the small bodies to be recorded: %#v", interactions[1])
+	}
+
+	// Interactions with an omitted request body match any body
+	replayer, err := NewReplayer(&cassette)
+	if err != nil {
+		t.Fatalf("Error loading cassette: %s", err)
+	}
+	replay := &http.Client{Transport: replayer}
+	if result := post(replay, "another image"); result != "" {
+		t.Errorf("Expected an empty replayed response, got: %q", result)
+	}
+	if result := post(replay, "small"); result != "xxxxx" {
+		t.Errorf("Unexpected replayed response: %q", result)
+	}
+}
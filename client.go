@@ -16,12 +16,9 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/textproto"
-	"os"
-	"path/filepath"
 	"strconv"
-	"sync"
-
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bcohee/gofish/common"
@@ -31,6 +28,14 @@ import (
 const userAgent = "gofish/1.0"
 const applicationJSON = "application/json"
 
+// DefaultMaxConcurrentRequests is the number of requests an APIClient runs at
+// the same time when ClientConfig.MaxConcurrentRequests is not set.
+const DefaultMaxConcurrentRequests = 3
+
+// maxDrainBytes is how much of an unread response body is discarded on close
+// so the connection can be reused.
+const maxDrainBytes = 64 << 10
+
 // APIClient represents a connection to a Redfish/Swordfish enabled service
 // or device.
 type APIClient struct {
@@ -49,8 +54,23 @@ type APIClient struct {
 	// Auth information saved for later to be able to log out
 	auth *redfish.AuthToken
 
-	// mu used to lock requests
-	mu *sync.Mutex
+	// authMu protects auth, which may be replaced when re-authenticating
+	authMu *sync.RWMutex
+
+	// reAuthenticate controls whether expired sessions are recreated
+	reAuthenticate bool
+
+	// retryPolicy controls retries of requests failing with transient errors
+	retryPolicy *RetryPolicy
+
+	// limiter bounds the number of requests in flight at the same time
+	limiter chan struct{}
+
+	// cache holds the GET responses to revalidate, nil if caching is disabled
+	cache *responseCache
+
+	// recorder records the requests to ClientConfig.RecordWriter, if set
+	recorder *Recorder
 
 	// dumpWriter will receive HTTP dumps if non-nil.
 	dumpWriter io.Writer
@@ -93,6 +113,41 @@ type ClientConfig struct {
 
 	// BasicAuth tells the APIClient if basic auth should be used (true) or token based auth must be used (false)
 	BasicAuth bool
+
+	// ReAuthenticate tells the APIClient to create a new session with Username
+	// and Password and replay the request when the service rejects the
+	// current session token with 401 Unauthorized.
+	ReAuthenticate bool
+
+	// RetryPolicy is the optional policy used to retry requests that fail
+	// because of transient errors. Requests are not retried if it is nil.
+	RetryPolicy *RetryPolicy
+
+	// MaxConcurrentRequests limits how many requests the APIClient starts at
+	// the same time, including the fan-out used to fetch collections. A
+	// request counts until its response headers are received, reading the
+	// body is not limited. When no HTTPClient is given, the transport also
+	// opens at most this many connections to the service, so that response
+	// bodies left open, such as event streams, hold one each.
+	// Defaults to DefaultMaxConcurrentRequests.
+	MaxConcurrentRequests int
+
+	// CacheResponses tells the APIClient to keep the responses to GET
+	// requests carrying an ETag, and to send later GET requests for the same
+	// resources with If-None-Match. The cached response is reused when the
+	// service answers 304 Not Modified. Only JSON responses are cached.
+	CacheResponses bool
+
+	// CacheMaxBytes limits the size of the response bodies kept when
+	// CacheResponses is set, the least recently used ones being dropped
+	// first. Defaults to DefaultCacheMaxBytes.
+	CacheMaxBytes int64
+
+	// RecordWriter is an optional io.Writer receiving a cassette of the HTTP
+	// requests and responses, which can be replayed with a Replayer. See
+	// Recorder. Errors writing the cassette are reported by the Err method of
+	// the APIClient's Recorder.
+	RecordWriter io.Writer
 }
 
 // setupClientWithConfig setups the client using the client config
@@ -102,10 +157,17 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	}
 
 	client := &APIClient{
-		endpoint:   config.Endpoint,
-		dumpWriter: config.DumpWriter,
-		ctx:        ctx,
-		mu:         &sync.Mutex{},
+		endpoint:       config.Endpoint,
+		dumpWriter:     config.DumpWriter,
+		ctx:            ctx,
+		authMu:         &sync.RWMutex{},
+		reAuthenticate: config.ReAuthenticate,
+		retryPolicy:    config.RetryPolicy,
+		limiter:        newLimiter(config.MaxConcurrentRequests),
+	}
+
+	if config.CacheResponses {
+		client.cache = newResponseCache(config.CacheMaxBytes)
 	}
 
 	if config.TLSHandshakeTimeout == 0 {
@@ -113,23 +175,20 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	}
 
 	if config.HTTPClient == nil {
-		defaultTransport := http.DefaultTransport.(*http.Transport)
-		transport := &http.Transport{
-			Proxy:                 defaultTransport.Proxy,
-			DialContext:           defaultTransport.DialContext,
-			MaxIdleConns:          defaultTransport.MaxIdleConns,
-			IdleConnTimeout:       defaultTransport.IdleConnTimeout,
-			ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
-			TLSHandshakeTimeout:   time.Duration(config.TLSHandshakeTimeout) * time.Second,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: config.Insecure,
-			},
+		client.HTTPClient = &http.Client{
+			Transport: newTransport(config.TLSHandshakeTimeout, config.Insecure, cap(client.limiter)),
 		}
-		client.HTTPClient = &http.Client{Transport: transport}
 	} else {
 		client.HTTPClient = config.HTTPClient
 	}
 
+	if config.RecordWriter != nil {
+		httpClient := *client.HTTPClient
+		client.recorder = NewRecorder(config.RecordWriter, httpClient.Transport)
+		httpClient.Transport = client.recorder
+		client.HTTPClient = &httpClient
+	}
+
 	// Fetch the service root
 	client.Service, err = ServiceRoot(client)
 	if err != nil {
@@ -139,6 +198,34 @@ func setupClientWithConfig(ctx context.Context, config *ClientConfig) (c *APICli
 	return client, nil
 }
 
+// newLimiter creates the semaphore bounding concurrent requests.
+func newLimiter(maxConcurrentRequests int) chan struct{} {
+	if maxConcurrentRequests <= 0 {
+		maxConcurrentRequests = DefaultMaxConcurrentRequests
+	}
+	return make(chan struct{}, maxConcurrentRequests)
+}
+
+// newTransport creates the HTTP transport used when no HTTPClient is given.
+// It opens at most maxConnsPerHost connections to the service, and keeps them
+// all idle for reuse.
+func newTransport(tlsHandshakeTimeout int, insecure bool, maxConnsPerHost int) *http.Transport {
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	return &http.Transport{
+		Proxy:                 defaultTransport.Proxy,
+		DialContext:           defaultTransport.DialContext,
+		MaxIdleConns:          defaultTransport.MaxIdleConns,
+		MaxIdleConnsPerHost:   maxConnsPerHost,
+		MaxConnsPerHost:       maxConnsPerHost,
+		IdleConnTimeout:       defaultTransport.IdleConnTimeout,
+		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+		TLSHandshakeTimeout:   time.Duration(tlsHandshakeTimeout) * time.Second,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: insecure,
+		},
+	}
+}
+
 // setupClientWithEndpoint setups the client using only the endpoint
 func setupClientWithEndpoint(ctx context.Context, endpoint string) (c *APIClient, err error) {
 	if !strings.HasPrefix(endpoint, "http") {
@@ -148,9 +235,12 @@ func setupClientWithEndpoint(ctx context.Context, endpoint string) (c *APIClient
 	client := &APIClient{
 		endpoint: endpoint,
 		ctx:      ctx,
-		mu:       &sync.Mutex{},
+		authMu:   &sync.RWMutex{},
+		limiter:  newLimiter(0),
+	}
+	client.HTTPClient = &http.Client{
+		Transport: newTransport(10, false, cap(client.limiter)),
 	}
-	client.HTTPClient = &http.Client{}
 
 	// Fetch the service root
 	client.Service, err = ServiceRoot(client)
@@ -164,10 +254,15 @@ func setupClientWithEndpoint(ctx context.Context, endpoint string) (c *APIClient
 // setupClientAuth setups the authentication in the client using the client config
 func (c *APIClient) setupClientAuth(config *ClientConfig) error {
 	if config.Session != nil {
-		c.auth = &redfish.AuthToken{
+		auth := &redfish.AuthToken{
 			Session: config.Session.ID,
 			Token:   config.Session.Token,
 		}
+		if config.ReAuthenticate {
+			auth.Username = config.Username
+			auth.Password = config.Password
+		}
+		c.setAuth(auth)
 	} else if config.Username != "" {
 		var auth *redfish.AuthToken
 		if config.BasicAuth {
@@ -182,11 +277,75 @@ func (c *APIClient) setupClientAuth(config *ClientConfig) error {
 			if err != nil {
 				return err
 			}
+			if config.ReAuthenticate {
+				// Keep the credentials to be able to create a new session
+				auth.Username = config.Username
+				auth.Password = config.Password
+			}
 		}
 
+		c.setAuth(auth)
+	}
+
+	return nil
+}
+
+// currentAuth returns the authentication information currently in use.
+func (c *APIClient) currentAuth() *redfish.AuthToken {
+	if c.authMu == nil {
+		return c.auth
+	}
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.auth
+}
+
+// setAuth replaces the authentication information in use.
+func (c *APIClient) setAuth(auth *redfish.AuthToken) {
+	if c.authMu == nil {
 		c.auth = auth
+		return
+	}
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.auth = auth
+}
+
+// canReAuthenticate checks whether a request rejected with 401 using the
+// given auth may be retried with a new session.
+func (c *APIClient) canReAuthenticate(auth *redfish.AuthToken, url string) bool {
+	return c.reAuthenticate &&
+		c.Service != nil &&
+		auth != nil &&
+		auth.Session != "" &&
+		auth.Username != "" &&
+		url != c.Service.sessions
+}
+
+// renewSession creates a new session to replace the expired one. If another
+// request already replaced it in the meantime, the new session is reused.
+func (c *APIClient) renewSession(expired *redfish.AuthToken) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.auth != expired {
+		return nil
 	}
 
+	// The session request itself must not carry the expired token
+	anonymous := *c
+	anonymous.auth = nil
+	anonymous.authMu = nil
+	anonymous.reAuthenticate = false
+
+	auth, err := redfish.CreateSession(&anonymous, c.Service.sessions, expired.Username, expired.Password)
+	if err != nil {
+		return err
+	}
+	auth.Username = expired.Username
+	auth.Password = expired.Password
+	c.auth = auth
+
 	return nil
 }
 
@@ -226,6 +385,18 @@ func ConnectDefaultContext(ctx context.Context, endpoint string) (c *APIClient,
 	return client, err
 }
 
+// MaxConcurrentRequests returns how many requests the client runs at the same
+// time.
+func (c *APIClient) MaxConcurrentRequests() int {
+	return cap(c.limiter)
+}
+
+// Recorder returns the Recorder writing the cassette to
+// ClientConfig.RecordWriter, or nil if the client is not recording.
+func (c *APIClient) Recorder() *Recorder {
+	return c.recorder
+}
+
 // GetService returns the APIClient's service.
 func (c *APIClient) GetService() *Service {
 	return c.Service
@@ -233,12 +404,14 @@ func (c *APIClient) GetService() *Service {
 
 // CloneWithSession will create a new Client with a session instead of basic auth.
 func (c *APIClient) CloneWithSession() (*APIClient, error) {
-	if c.auth.Session != "" {
+	current := c.currentAuth()
+	if current.Session != "" {
 		return nil, fmt.Errorf("client already has a session")
 	}
 
 	newClient := *c
 	newClient.HTTPClient = c.HTTPClient
+	newClient.authMu = &sync.RWMutex{}
 	service, err := ServiceRoot(&newClient)
 	if err != nil {
 		return nil, err
@@ -246,11 +419,15 @@ func (c *APIClient) CloneWithSession() (*APIClient, error) {
 	newClient.Service = service
 
 	auth, err := newClient.Service.CreateSession(
-		newClient.auth.Username,
-		newClient.auth.Password)
+		current.Username,
+		current.Password)
 	if err != nil {
 		return nil, err
 	}
+	if c.reAuthenticate {
+		auth.Username = current.Username
+		auth.Password = current.Password
+	}
 	newClient.auth = auth
 
 	return &newClient, err
@@ -259,12 +436,13 @@ func (c *APIClient) CloneWithSession() (*APIClient, error) {
 // GetSession retrieves the session data from an initialized APIClient. An error
 // is returned if the client is not authenticated.
 func (c *APIClient) GetSession() (*Session, error) {
-	if c.auth == nil || c.auth.Session == "" {
+	auth := c.currentAuth()
+	if auth == nil || auth.Session == "" {
 		return nil, fmt.Errorf("client not authenticated")
 	}
 	return &Session{
-		ID:    c.auth.Session,
-		Token: c.auth.Token,
+		ID:    auth.Session,
+		Token: auth.Token,
 	}, nil
 }
 
@@ -283,6 +461,18 @@ func (c *APIClient) GetWithHeaders(url string, customHeaders map[string]string)
 	return c.runRequestWithHeaders(http.MethodGet, relativePath, nil, customHeaders)
 }
 
+// GetWithHeadersContext performs a GET request like GetWithHeaders, but tied
+// to ctx instead of the context of the client. It is used for requests that
+// outlive the caller, such as reopening an event stream.
+func (c *APIClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	relativePath := url
+	if relativePath == "" {
+		relativePath = common.DefaultServiceRoot
+	}
+
+	return c.runRawRequestContext(ctx, http.MethodGet, relativePath, nil, applicationJSON, customHeaders)
+}
+
 // Post performs a Post request against the Redfish service.
 func (c *APIClient) Post(url string, payload interface{}) (*http.Response, error) {
 	return c.PostWithHeaders(url, payload, nil)
@@ -364,29 +554,12 @@ func (c *APIClient) runRequestWithMultipartPayloadWithHeaders(method, url string
 		return nil, fmt.Errorf("unable to execute request, no target provided")
 	}
 
-	var payloadBuffer bytes.Buffer
-	var err error
-	payloadWriter := multipart.NewWriter(&payloadBuffer)
-	for key, reader := range payload {
-		var partWriter io.Writer
-		if file, ok := reader.(*os.File); ok {
-			// Add a file stream
-			if partWriter, err = payloadWriter.CreateFormFile(key, filepath.Base(file.Name())); err != nil {
-				return nil, err
-			}
-		} else {
-			// Add other fields
-			if partWriter, err = createFormField(key, payloadWriter); err != nil {
-				return nil, err
-			}
-		}
-		if _, err = io.Copy(partWriter, reader); err != nil {
-			return nil, err
-		}
+	body, err := newMultipartBody(payload)
+	if err != nil {
+		return nil, err
 	}
-	payloadWriter.Close()
 
-	return c.runRawRequestWithHeaders(method, url, bytes.NewReader(payloadBuffer.Bytes()), payloadWriter.FormDataContentType(), customHeaders)
+	return c.runRawRequestWithHeaders(method, url, body, body.contentType, customHeaders)
 }
 
 var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -416,16 +589,144 @@ func (c *APIClient) RunRawRequestWithHeaders(method, url string, payloadBuffer i
 
 // runRawRequestWithHeaders actually performs the REST calls but allowing custom headers
 func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	return c.runRawRequestContext(c.ctx, method, url, payloadBuffer, contentType, customHeaders)
+}
+
+// runRawRequestContext performs the REST calls, retries included, under ctx.
+func (c *APIClient) runRawRequestContext(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
 	if url == "" {
 		return nil, common.ConstructError(0, []byte("unable to execute request, no target provided"))
 	}
 
+	requestHeaders := customHeaders
+	cached := c.cache.lookup(method, url, customHeaders)
+	if cached != nil {
+		requestHeaders = withHeader(customHeaders, "If-None-Match", cached.etag)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.attemptRequest(ctx, method, url, payloadBuffer, contentType, requestHeaders)
+		if !c.retryPolicy.shouldRetry(ctx, attempt, method, resp, err) || !rewind(payloadBuffer) {
+			if err != nil {
+				return nil, err
+			}
+			if cached != nil && resp.StatusCode == http.StatusNotModified {
+				resp.Body.Close()
+				return cached.response(), nil
+			}
+			resp, err = c.checkResponse(method, url, resp)
+			if err != nil {
+				return nil, err
+			}
+			return c.cache.store(method, url, customHeaders, resp)
+		}
+
+		delay := c.retryPolicy.Backoff(attempt, resp)
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if c.retryPolicy.OnRetry != nil {
+			c.retryPolicy.OnRetry(attempt, method, url, statusCode, err, delay)
+		}
+
+		if err := c.retryPolicy.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// payloadLength returns how much of the payload is left to send, or -1 if it
+// cannot be known.
+func payloadLength(payloadBuffer io.ReadSeeker) int64 {
+	if sized, ok := payloadBuffer.(interface{ Size() int64 }); ok {
+		return sized.Size()
+	}
+
+	offset, err := payloadBuffer.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	end, err := payloadBuffer.Seek(0, io.SeekEnd)
+	if err != nil {
+		return -1
+	}
+	if _, err := payloadBuffer.Seek(offset, io.SeekStart); err != nil {
+		return -1
+	}
+	return end - offset
+}
+
+// rewind resets the payload so a request can be sent again, reporting whether
+// that was possible.
+func rewind(payloadBuffer io.ReadSeeker) bool {
+	if payloadBuffer == nil {
+		return true
+	}
+	_, err := payloadBuffer.Seek(0, io.SeekStart)
+	return err == nil
+}
+
+// attemptRequest sends the request once, renewing the session and replaying
+// the request if the service rejected an expired session.
+func (c *APIClient) attemptRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	auth := c.currentAuth()
+	resp, err := c.sendRequest(ctx, method, url, payloadBuffer, contentType, customHeaders, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && c.canReAuthenticate(auth, url) {
+		// The session most likely expired, get a new one and replay the request
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := c.renewSession(auth); err != nil {
+			return nil, err
+		}
+		if payloadBuffer != nil {
+			if _, err := payloadBuffer.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+		}
+
+		return c.sendRequest(ctx, method, url, payloadBuffer, contentType, customHeaders, c.currentAuth())
+	}
+
+	return resp, nil
+}
+
+// checkResponse turns responses with an unsuccessful status into errors.
+func (c *APIClient) checkResponse(method, url string, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 && resp.StatusCode != 204 {
+		payload, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, common.ConstructError(0, []byte(err.Error()))
+		}
+		defer resp.Body.Close()
+		return nil, common.ConstructRequestError(method, url, resp.StatusCode, payload)
+	}
+
+	return resp, nil
+}
+
+// sendRequest builds and sends a single HTTP request using the given auth.
+func (c *APIClient) sendRequest(ctx context.Context, method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string, auth *redfish.AuthToken) (*http.Response, error) {
 	endpoint := fmt.Sprintf("%s%s", c.endpoint, url)
-	req, err := http.NewRequestWithContext(c.ctx, method, endpoint, payloadBuffer)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, payloadBuffer)
 	if err != nil {
 		return nil, err
 	}
 
+	// Streamed payloads are sent with their length when it can be known
+	if payloadBuffer != nil && req.ContentLength == 0 {
+		if length := payloadLength(payloadBuffer); length > 0 {
+			req.ContentLength = length
+		}
+	}
+
 	// Add common headers
 	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("Accept", applicationJSON)
@@ -456,15 +757,14 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 	}
 
 	// Add auth info if authenticated
-	if c.auth != nil {
-		if c.auth.Token != "" {
-			req.Header.Set("X-Auth-Token", c.auth.Token)
-		} else if c.auth.BasicAuth && c.auth.Username != "" && c.auth.Password != "" {
-			encodedAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", c.auth.Username, c.auth.Password)))
+	if auth != nil {
+		if auth.Token != "" {
+			req.Header.Set("X-Auth-Token", auth.Token)
+		} else if auth.BasicAuth && auth.Username != "" && auth.Password != "" {
+			encodedAuth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v:%v", auth.Username, auth.Password)))
 			req.Header.Set("Authorization", fmt.Sprintf("Basic %v", encodedAuth))
 		}
 	}
-	req.Close = true
 
 	// Dump request if needed.
 	if c.dumpWriter != nil {
@@ -472,9 +772,8 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 			return nil, err
 		}
 	}
-	c.mu.Lock()
-	resp, err := c.HTTPClient.Do(req)
-	c.mu.Unlock()
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -487,16 +786,45 @@ func (c *APIClient) runRawRequestWithHeaders(method, url string, payloadBuffer i
 		}
 	}
 
-	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 && resp.StatusCode != 204 {
-		payload, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, common.ConstructError(0, []byte(err.Error()))
+	return resp, nil
+}
+
+// do sends the request once a slot is available under the concurrency limit.
+// Only the start of requests is limited: the slot is released as soon as the
+// response headers are received so that long running streams do not hold it.
+// The connections still held by open bodies are bounded by the transport.
+func (c *APIClient) do(req *http.Request) (*http.Response, error) {
+	if c.limiter != nil {
+		select {
+		case c.limiter <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
 		}
-		defer resp.Body.Close()
-		return nil, common.ConstructError(resp.StatusCode, payload)
+		defer func() { <-c.limiter }()
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
 
-	return resp, err
+	if resp.ContentLength >= 0 && resp.ContentLength <= maxDrainBytes {
+		resp.Body = &drainingBody{ReadCloser: resp.Body}
+	}
+
+	return resp, nil
+}
+
+// drainingBody discards what callers left unread before closing the body,
+// allowing the connection to be reused for the next request.
+type drainingBody struct {
+	io.ReadCloser
+}
+
+// Close drains and closes the body.
+func (b *drainingBody) Close() error {
+	_, _ = io.CopyN(io.Discard, b.ReadCloser, maxDrainBytes)
+	return b.ReadCloser.Close()
 }
 
 // dumpRequest writes outgoing client requests to dumpWriter
@@ -534,8 +862,9 @@ func (c *APIClient) dumpResponse(resp *http.Response) error {
 // Logout will delete any active session. Useful to defer logout when creating
 // a new connection.
 func (c *APIClient) Logout() {
-	if c.Service != nil && c.auth != nil {
-		_ = c.Service.DeleteSession(c.auth.Session)
+	auth := c.currentAuth()
+	if c.Service != nil && auth != nil {
+		_ = c.Service.DeleteSession(auth.Session)
 	}
 }
 
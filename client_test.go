@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sync"
@@ -74,6 +75,29 @@ func testError(code int, t *testing.T) {
 	}
 }
 
+// TestErrorRequest tests that errors can be inspected for their kind and the
+// request that failed.
+func TestErrorRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(expectErrorStatus)) //nolint
+	}))
+	defer ts.Close()
+
+	_, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("Expected a not found error, got: %v", err)
+	}
+
+	var errStruct *common.Error
+	if !errors.As(err, &errStruct) {
+		t.Fatalf("Expected a service error, got: %v", err)
+	}
+	if errStruct.Method != http.MethodGet || errStruct.URL != common.DefaultServiceRoot {
+		t.Errorf("Unexpected failed request: %s %s", errStruct.Method, errStruct.URL)
+	}
+}
+
 // TestError400 tests the parsing of error reply.
 func TestError400(t *testing.T) {
 	testError(400, t)
@@ -190,7 +214,7 @@ func TestConnectDefaultContextCancel(t *testing.T) {
 }
 
 func TestClientRunRawRequestNoURL(t *testing.T) {
-	client := APIClient{mu: &sync.Mutex{}}
+	client := APIClient{}
 
 	_, err := client.runRawRequest("", "", nil, "") //nolint:bodyclose
 	if err == nil {
@@ -201,3 +225,224 @@ func TestClientRunRawRequestNoURL(t *testing.T) {
 		t.Errorf("Unexpected error response: %s", err.Error())
 	}
 }
+
+// TestReAuthenticate tests replacing an expired session and replaying the
+// rejected requests.
+func TestReAuthenticate(t *testing.T) {
+	var mu sync.Mutex
+	sessionCount := 0
+	validToken := ""
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/redfish/v1/":
+			w.Write([]byte(`{"Links": {"Sessions": {"@odata.id": "/redfish/v1/SessionService/Sessions"}}}`)) //nolint
+		case r.URL.Path == "/redfish/v1/SessionService/Sessions" && r.Method == http.MethodPost:
+			if r.Header.Get("X-Auth-Token") != "" {
+				t.Errorf("Session request should not carry a token")
+			}
+			sessionCount++
+			validToken = fmt.Sprintf("token-%d", sessionCount)
+			w.Header().Set("X-Auth-Token", validToken)
+			w.Header().Set("Location", fmt.Sprintf("/redfish/v1/SessionService/Sessions/%d", sessionCount))
+			w.WriteHeader(http.StatusCreated)
+		case r.Header.Get("X-Auth-Token") != validToken:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			w.Write([]byte(`{"Id": "1"}`)) //nolint
+		}
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{
+		Endpoint:       ts.URL,
+		HTTPClient:     ts.Client(),
+		Username:       "admin",
+		Password:       "password",
+		ReAuthenticate: true,
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	// Expire the session on the service side
+	mu.Lock()
+	validToken = "expired"
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get("/redfish/v1/Systems/1")
+			if err != nil {
+				t.Errorf("Request should have been replayed: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if sessionCount != 2 {
+		t.Errorf("Expected exactly one new session, got %d sessions", sessionCount)
+	}
+
+	session, err := client.GetSession()
+	if err != nil {
+		t.Fatalf("Error getting session: %s", err)
+	}
+	if session.Token != "token-2" || session.ID != "/redfish/v1/SessionService/Sessions/2" {
+		t.Errorf("Unexpected session after renewal: %#v", session)
+	}
+}
+
+// TestReAuthenticateDisabled tests that 401 errors are returned as before when
+// re-authentication is not enabled.
+func TestReAuthenticateDisabled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/redfish/v1/":
+			w.Write([]byte(`{"Links": {"Sessions": {"@odata.id": "/redfish/v1/SessionService/Sessions"}}}`)) //nolint
+		case r.Method == http.MethodPost:
+			w.Header().Set("X-Auth-Token", "token")
+			w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/1")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Username:   "admin",
+		Password:   "password",
+	})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = client.Get("/redfish/v1/Systems/1") //nolint:bodyclose
+	if err == nil {
+		t.Fatal("Request should have failed")
+	}
+
+	if err.(*common.Error).HTTPReturnedStatusCode != http.StatusUnauthorized {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+// TestConcurrentRequests tests that requests run in parallel up to the
+// configured limit.
+func TestConcurrentRequests(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == common.DefaultServiceRoot {
+			w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{"Id": "1"}`)) //nolint
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), MaxConcurrentRequests: 2})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	if client.MaxConcurrentRequests() != 2 {
+		t.Errorf("Unexpected concurrency limit: %d", client.MaxConcurrentRequests())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get("/redfish/v1/Systems/1")
+			if err != nil {
+				t.Errorf("Error getting resource: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("Expected 2 requests in flight at most, got %d", peak)
+	}
+}
+
+// TestGetWithHeadersContext tests that a request can be cancelled through its
+// own context while the client context stays alive.
+func TestGetWithHeadersContext(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == common.DefaultServiceRoot {
+			w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = client.GetWithHeadersContext(ctx, "/redfish/v1/EventService/SSE", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to be cancelled, got: %v", err)
+	}
+
+	if _, err := client.Get(common.DefaultServiceRoot); err != nil {
+		t.Errorf("Client should still be usable: %s", err)
+	}
+}
+
+// TestNewTransport tests that the default transport opens no more connections
+// than the concurrency limit.
+func TestNewTransport(t *testing.T) {
+	transport := newTransport(10, false, 5)
+
+	if transport.MaxConnsPerHost != 5 {
+		t.Errorf("Unexpected MaxConnsPerHost: %d", transport.MaxConnsPerHost)
+	}
+
+	if transport.MaxIdleConnsPerHost != 5 {
+		t.Errorf("Unexpected MaxIdleConnsPerHost: %d", transport.MaxIdleConnsPerHost)
+	}
+
+	if transport.TLSHandshakeTimeout != 10*time.Second {
+		t.Errorf("Unexpected TLSHandshakeTimeout: %s", transport.TLSHandshakeTimeout)
+	}
+}
@@ -0,0 +1,121 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Command redfishdiff compares the resources of a Redfish service at two
+// points in time, for example before and after a firmware update. Each side
+// is either the URL of a live service or a directory written by gofish.Crawl
+// in the layout of the DMTF Redfish mockups:
+//
+//	redfishdiff -username admin -password secret before/ https://bmc-ip
+//
+// The exit status is 0 when there are no differences, 1 when there are and 2
+// on errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bcohee/gofish"
+	"github.com/bcohee/gofish/common"
+)
+
+func main() {
+	username := flag.String("username", "", "user name to connect to live services")
+	password := flag.String("password", "", "password to connect to live services")
+	insecure := flag.Bool("insecure", false, "do not check the certificates of live services")
+	exclude := flag.String("exclude", "", "comma separated patterns of resources not to compare")
+	ignore := flag.String("ignore", "", "comma separated patterns of properties not to compare, besides the volatile ones")
+	maxDepth := flag.Int("max-depth", 0, "how many links to follow from the service root, 0 for no limit")
+	jsonOutput := flag.Bool("json", false, "write the differences as JSON")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] OLD NEW\n\nOLD and NEW are service URLs or mockup directories.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config := gofish.ClientConfig{Username: *username, Password: *password, Insecure: *insecure}
+	options := gofish.CrawlOptions{Exclude: splitList(*exclude), MaxDepth: *maxDepth}
+
+	old, err := snapshot(flag.Arg(0), config, options)
+	if err != nil {
+		fail(err)
+	}
+	current, err := snapshot(flag.Arg(1), config, options)
+	if err != nil {
+		fail(err)
+	}
+
+	diff := gofish.Diff(old, current, gofish.DiffOptions{
+		IgnoreProperties: append(splitList(*ignore), gofish.DefaultVolatileProperties...),
+	})
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(diff)
+	} else {
+		err = diff.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fail(err)
+	}
+
+	if !diff.Empty() {
+		os.Exit(1)
+	}
+}
+
+// snapshot takes a snapshot of a live service or reads it from a directory.
+// Resources that cannot be read are reported, and flagged with "?" in the
+// differences instead of being compared.
+func snapshot(source string, config gofish.ClientConfig, options gofish.CrawlOptions) (*gofish.Snapshot, error) { //nolint:gocritic
+	var result *gofish.Snapshot
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		config.Endpoint = source
+		var c *gofish.APIClient
+		c, err = gofish.Connect(config)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Logout()
+		result, err = gofish.NewSnapshot(c, options)
+	} else {
+		result, err = gofish.LoadSnapshot(source, options)
+	}
+
+	var collectionError *common.CollectionError
+	if errors.As(err, &collectionError) {
+		for uri, failure := range collectionError.Failures {
+			fmt.Fprintf(os.Stderr, "%s: skipped %s: %s\n", source, uri, failure)
+		}
+		return result, nil
+	}
+	return result, err
+}
+
+// splitList splits a comma separated list.
+func splitList(list string) []string {
+	var result []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// fail reports an error and exits.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(2)
+}
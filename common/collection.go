@@ -6,7 +6,9 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -14,6 +16,9 @@ import (
 type Collection struct {
 	Name      string `json:"Name"`
 	ItemLinks []string
+	// NextLink is the URI of the next page of members when the service
+	// returns the collection in pages.
+	NextLink string `json:"Members@odata.nextLink"`
 }
 
 // UnmarshalJSON unmarshals a collection from the raw JSON.
@@ -44,8 +49,44 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// GetCollection retrieves a collection from the service.
+// GetCollection retrieves a collection from the service, following the next
+// links of paged collections. If c is a *QueryClient its query options are
+// applied; they are not if c only wraps one.
 func GetCollection(c Client, uri string) (*Collection, error) {
+	var result *Collection
+	pager := NewCollectionPager(c, uri)
+	for pager.Next() {
+		page := pager.Page()
+		if result == nil {
+			result = page
+			continue
+		}
+		result.ItemLinks = append(result.ItemLinks, page.ItemLinks...)
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return &Collection{}, nil
+	}
+
+	result.NextLink = ""
+	return result, nil
+}
+
+// GetCollectionPage retrieves a single page of a collection from the
+// service. The next page, if any, is referenced by the NextLink of the
+// result. If c is a *QueryClient its query options are applied; they are not
+// if c only wraps one.
+func GetCollectionPage(c Client, uri string) (*Collection, error) {
+	if qc, ok := c.(*QueryClient); ok {
+		return qc.getCollection(uri)
+	}
+	return getCollection(c, uri)
+}
+
+// getCollection retrieves a collection page without query options.
+func getCollection(c Client, uri string) (*Collection, error) {
 	resp, err := c.Get(uri)
 	if err != nil {
 		return nil, err
@@ -60,6 +101,97 @@ func GetCollection(c Client, uri string) (*Collection, error) {
 	return &result, nil
 }
 
+// getNextCollectionPage retrieves the page a next link refers to. Next links
+// already carry the query options of the first page.
+func getNextCollectionPage(c Client, uri string) (*Collection, error) {
+	if qc, ok := c.(*QueryClient); ok {
+		return qc.getCollectionPage(uri)
+	}
+	return getCollection(c, uri)
+}
+
+// CollectionPager walks the pages of a collection, only keeping one page in
+// memory at a time.
+type CollectionPager struct {
+	client Client
+	next   string
+	first  bool
+	seen   map[string]bool
+	page   *Collection
+	err    error
+}
+
+// NewCollectionPager creates a pager for the collection at uri. If c is a
+// *QueryClient its query options are applied to every page.
+func NewCollectionPager(c Client, uri string) *CollectionPager {
+	return &CollectionPager{
+		client: c,
+		next:   uri,
+		first:  true,
+		seen:   make(map[string]bool),
+	}
+}
+
+// Next retrieves the next page. It returns false when there are no more pages
+// or an error occurred.
+func (p *CollectionPager) Next() bool {
+	if p.err != nil || p.next == "" || p.seen[p.next] {
+		// A next link that was already visited would loop forever
+		return false
+	}
+	p.seen[p.next] = true
+
+	if p.first {
+		p.page, p.err = GetCollectionPage(p.client, p.next)
+		p.first = false
+	} else {
+		p.page, p.err = getNextCollectionPage(p.client, p.next)
+	}
+	if p.err != nil {
+		p.page = nil
+		return false
+	}
+
+	p.next = p.page.NextLink
+	return true
+}
+
+// Page returns the current page.
+func (p *CollectionPager) Page() *Collection {
+	return p.page
+}
+
+// Err returns the error that stopped the pager, if any.
+func (p *CollectionPager) Err() error {
+	return p.err
+}
+
+// WalkCollection calls fn with the links of every member of the collection at
+// uri, page by page, until fn returns an error.
+func WalkCollection(c Client, uri string, fn func(link string) error) error {
+	pager := NewCollectionPager(c, uri)
+	for pager.Next() {
+		for _, link := range pager.Page().ItemLinks {
+			if err := fn(link); err != nil {
+				return err
+			}
+		}
+	}
+	return pager.Err()
+}
+
+// defaultCollectionConcurrency is the number of members fetched at the same
+// time when the client does not report its own limit.
+const defaultCollectionConcurrency = 3
+
+// ConcurrencyLimiter is implemented by clients that limit how many requests
+// they run at the same time.
+type ConcurrencyLimiter interface {
+	// MaxConcurrentRequests returns how many requests the client runs at the
+	// same time.
+	MaxConcurrentRequests() int
+}
+
 // CollectionError is used for collecting errors when working with collections
 type CollectionError struct {
 	Failures map[string]error
@@ -73,6 +205,7 @@ func NewCollectionError() *CollectionError {
 	}
 }
 
+// Empty reports whether no failure was collected.
 func (cr *CollectionError) Empty() bool {
 	return len(cr.Failures) == 0
 }
@@ -101,21 +234,29 @@ func (cr *CollectionError) Error() string {
 }
 
 // CollectList will retrieve a collection of entities from the Redfish service.
+// If c is a *QueryClient the members expanded with a page are used by get, and
+// dropped once the page has been collected.
 func CollectList(get func(string), c Client, link string) error {
-	links, err := GetCollection(c, link)
-	if err != nil {
-		return err
+	pager := NewCollectionPager(c, link)
+	for pager.Next() {
+		links := pager.Page().ItemLinks
+		CollectCollection(get, c, links)
+		if qc, ok := c.(*QueryClient); ok {
+			qc.forgetMembers(links)
+		}
 	}
-
-	CollectCollection(get, c, links.ItemLinks)
-	return nil
+	return pager.Err()
 }
 
 // CollectCollection will retrieve a collection of entitied from the Redfish service
 // when you already have the set of individual links in the collection.
 func CollectCollection(get func(string), c Client, links []string) {
-	// Only allow three concurrent requests to avoid overwhelming the service
-	limiter := make(chan struct{}, 3)
+	// Limit concurrent requests to avoid overwhelming the service
+	concurrency := defaultCollectionConcurrency
+	if cl, ok := c.(ConcurrencyLimiter); ok && cl.MaxConcurrentRequests() > 0 {
+		concurrency = cl.MaxConcurrentRequests()
+	}
+	limiter := make(chan struct{}, concurrency)
 	var wg sync.WaitGroup
 
 	for _, itemLink := range links {
@@ -131,3 +272,54 @@ func CollectCollection(get func(string), c Client, links []string) {
 
 	wg.Wait()
 }
+
+// LinkError associates the failure to get an entity with its link.
+type LinkError struct {
+	// Link is the link of the entity that could not be retrieved.
+	Link string
+	// Err is the error returned when getting the entity.
+	Err error
+}
+
+func (e *LinkError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Link, e.Err)
+}
+
+// Unwrap returns the error returned when getting the entity.
+func (e *LinkError) Unwrap() error {
+	return e.Err
+}
+
+// Errors returns the collected failures, ordered by link.
+func (cr *CollectionError) Errors() []*LinkError {
+	result := make([]*LinkError, 0, len(cr.Failures))
+	for link, err := range cr.Failures {
+		result = append(result, &LinkError{Link: link, Err: err})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Link < result[j].Link
+	})
+	return result
+}
+
+// Is reports whether any of the collected failures matches the target, so
+// that errors.Is(err, ErrNotFound) tells whether some entities were missing.
+func (cr *CollectionError) Is(target error) bool {
+	for _, err := range cr.Failures {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first collected failure, in link order, that matches the
+// target, so that errors.As can extract a service error from the collection.
+func (cr *CollectionError) As(target interface{}) bool {
+	for _, linkErr := range cr.Errors() {
+		if errors.As(linkErr.Err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,9 +6,13 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 var collectionBody = strings.NewReader(
@@ -55,3 +59,102 @@ func TestCollection(t *testing.T) {
 		}
 	}
 }
+
+type limitedClient struct {
+	TestClient
+	limit int
+}
+
+func (c *limitedClient) MaxConcurrentRequests() int {
+	return c.limit
+}
+
+// TestCollectCollectionLimit tests that members are fetched with the client's
+// concurrency limit.
+func TestCollectCollectionLimit(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	get := func(link string) {
+		mu.Lock()
+		inFlight++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}
+
+	links := []string{"/1", "/2", "/3", "/4", "/5", "/6", "/7", "/8"}
+	CollectCollection(get, &limitedClient{limit: 5}, links)
+
+	if peak != 5 {
+		t.Errorf("Expected 5 concurrent requests, got %d", peak)
+	}
+}
+
+// TestGetCollectionNextLink tests following the pages of a collection.
+func TestGetCollectionNextLink(t *testing.T) {
+	page := func(member, next string) *http.Response {
+		body := fmt.Sprintf(`{"Members@odata.count": 3, "Members": [{"@odata.id": %q}]`, member)
+		if next != "" {
+			body += fmt.Sprintf(`, "Members@odata.nextLink": %q`, next)
+		}
+		return testResponse(http.StatusOK, body+"}", nil)
+	}
+
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				page("/redfish/v1/Systems/1", "/redfish/v1/Systems?$skip=1"),
+				page("/redfish/v1/Systems/2", "/redfish/v1/Systems?$skip=2"),
+				// A next link pointing back to a visited page must not loop
+				page("/redfish/v1/Systems/3", "/redfish/v1/Systems?$skip=1"),
+			},
+		},
+	}
+
+	result, err := GetCollection(testClient, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	if len(result.ItemLinks) != 3 || result.ItemLinks[2] != "/redfish/v1/Systems/3" {
+		t.Errorf("Unexpected members: %v", result.ItemLinks)
+	}
+
+	if len(testClient.CapturedCalls()) != 3 {
+		t.Errorf("Expected 3 requests, got: %#v", testClient.CapturedCalls())
+	}
+}
+
+// TestCollectionErrorFailures tests inspecting the failures of a
+// CollectionError.
+func TestCollectionErrorFailures(t *testing.T) {
+	collectionError := NewCollectionError()
+	collectionError.Failures["/redfish/v1/Systems/2"] = ConstructRequestError(http.MethodGet, "/redfish/v1/Systems/2", http.StatusNotFound, nil)
+	collectionError.Failures["/redfish/v1/Systems/1"] = fmt.Errorf("connection reset")
+
+	linkErrors := collectionError.Errors()
+	if len(linkErrors) != 2 || linkErrors[0].Link != "/redfish/v1/Systems/1" || linkErrors[1].Link != "/redfish/v1/Systems/2" {
+		t.Fatalf("Unexpected failures: %v", linkErrors)
+	}
+
+	if !errors.Is(linkErrors[1], ErrNotFound) {
+		t.Errorf("Failures should unwrap to their error: %v", linkErrors[1])
+	}
+
+	var err error = collectionError
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrConflict) {
+		t.Errorf("Unexpected error kinds for: %v", err)
+	}
+
+	var redfishError *Error
+	if !errors.As(err, &redfishError) || redfishError.URL != "/redfish/v1/Systems/2" {
+		t.Errorf("Service errors should be extracted from the failures: %v", redfishError)
+	}
+}
@@ -0,0 +1,97 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import "encoding/json"
+
+// CapabilityUseCase is the usage of a collection capability.
+type CapabilityUseCase string
+
+const (
+	// ComputerSystemCompositionCapabilityUseCase shall indicate the
+	// capability describes the POST request to compose a computer system
+	// from a specific set of resource blocks.
+	ComputerSystemCompositionCapabilityUseCase CapabilityUseCase = "ComputerSystemComposition"
+	// ComputerSystemConstrainedCompositionCapabilityUseCase shall indicate
+	// the capability describes the POST request to compose a computer system
+	// from a set of constraints, the service choosing the resource blocks.
+	ComputerSystemConstrainedCompositionCapabilityUseCase CapabilityUseCase = "ComputerSystemConstrainedComposition"
+	// VolumeCreationCapabilityUseCase shall indicate the capability
+	// describes the POST request to create a volume.
+	VolumeCreationCapabilityUseCase CapabilityUseCase = "VolumeCreation"
+	// ResourceBlockCompositionCapabilityUseCase shall indicate the
+	// capability describes the POST request to compose a resource block
+	// from a specific set of resource blocks.
+	ResourceBlockCompositionCapabilityUseCase CapabilityUseCase = "ResourceBlockComposition"
+	// ResourceBlockConstrainedCompositionCapabilityUseCase shall indicate
+	// the capability describes the POST request to compose a resource block
+	// from a set of constraints.
+	ResourceBlockConstrainedCompositionCapabilityUseCase CapabilityUseCase = "ResourceBlockConstrainedComposition"
+	// RegisterResourceBlockCapabilityUseCase shall indicate the capability
+	// describes the POST request to register a resource block.
+	RegisterResourceBlockCapabilityUseCase CapabilityUseCase = "RegisterResourceBlock"
+)
+
+// CollectionCapability shall describe a POST request that can be made to a
+// collection.
+type CollectionCapability struct {
+	// CapabilitiesObject shall contain a link to a resource describing the
+	// properties allowed in the POST request, and the ones that are required.
+	CapabilitiesObject string
+	// RelatedItems shall contain links to resources related to this
+	// capability, such as the zone or the storage the request applies to.
+	RelatedItems []string
+	// TargetCollection shall contain a link to the collection the POST
+	// request is made to.
+	TargetCollection string
+	// UseCase shall describe the usage of this capability.
+	UseCase CapabilityUseCase
+}
+
+// UnmarshalJSON unmarshals a CollectionCapability object from the raw JSON.
+func (capability *CollectionCapability) UnmarshalJSON(b []byte) error {
+	var t struct {
+		CapabilitiesObject Link
+		Links              struct {
+			RelatedItem      Links
+			TargetCollection Link
+		}
+		UseCase CapabilityUseCase
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	capability.CapabilitiesObject = t.CapabilitiesObject.String()
+	capability.RelatedItems = t.Links.RelatedItem.ToStrings()
+	capability.TargetCollection = t.Links.TargetCollection.String()
+	capability.UseCase = t.UseCase
+
+	return nil
+}
+
+// CollectionCapabilities shall describe the POST requests that can be made
+// to collections, as advertised by the @Redfish.CollectionCapabilities
+// annotation.
+type CollectionCapabilities struct {
+	// Capabilities shall contain the POST requests that can be made.
+	Capabilities []CollectionCapability
+	// MaxMembers shall contain the maximum number of members allowed in the
+	// collections.
+	MaxMembers int
+}
+
+// Capability returns the capability for a use case, or nil if the service
+// does not advertise one.
+func (capabilities *CollectionCapabilities) Capability(useCase CapabilityUseCase) *CollectionCapability {
+	for i := range capabilities.Capabilities {
+		if capabilities.Capabilities[i].UseCase == useCase {
+			return &capabilities.Capabilities[i]
+		}
+	}
+	return nil
+}
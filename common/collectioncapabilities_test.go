@@ -0,0 +1,70 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var collectionCapabilitiesBody = `{
+		"@odata.type": "#CollectionCapabilities.v1_4_0.CollectionCapabilities",
+		"MaxMembers": 8,
+		"Capabilities": [
+			{
+				"CapabilitiesObject": {
+					"@odata.id": "/redfish/v1/Systems/Capabilities"
+				},
+				"UseCase": "ComputerSystemComposition",
+				"Links": {
+					"TargetCollection": {
+						"@odata.id": "/redfish/v1/Systems"
+					},
+					"RelatedItem": [
+						{
+							"@odata.id": "/redfish/v1/CompositionService/ResourceZones/1"
+						}
+					]
+				}
+			}
+		]
+	}`
+
+// TestCollectionCapabilities tests the parsing of CollectionCapabilities
+// annotations.
+func TestCollectionCapabilities(t *testing.T) {
+	var result CollectionCapabilities
+	err := json.NewDecoder(strings.NewReader(collectionCapabilitiesBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.MaxMembers != 8 {
+		t.Errorf("Invalid MaxMembers: %d", result.MaxMembers)
+	}
+
+	capability := result.Capability(ComputerSystemCompositionCapabilityUseCase)
+	if capability == nil {
+		t.Fatal("Expected a composition capability")
+	}
+
+	if capability.CapabilitiesObject != "/redfish/v1/Systems/Capabilities" {
+		t.Errorf("Invalid CapabilitiesObject: %s", capability.CapabilitiesObject)
+	}
+
+	if capability.TargetCollection != "/redfish/v1/Systems" {
+		t.Errorf("Invalid TargetCollection: %s", capability.TargetCollection)
+	}
+
+	if capability.RelatedItems[0] != "/redfish/v1/CompositionService/ResourceZones/1" {
+		t.Errorf("Invalid RelatedItems: %v", capability.RelatedItems)
+	}
+
+	if result.Capability(VolumeCreationCapabilityUseCase) != nil {
+		t.Error("Unexpected volume creation capability")
+	}
+}
@@ -6,8 +6,13 @@ package common
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // Entity provides the common basis for all Redfish and Swordfish objects.
@@ -32,62 +37,250 @@ func (e *Entity) SetClient(c Client) {
 	e.Client = c
 }
 
-// Update commits changes to an entity.
+// Update commits changes to an entity. The changes are found by comparing
+// the original and current values of the entity, including the fields of
+// nested objects and arrays, and sent as a JSON merge patch holding only the
+// changed properties.
+//
+// allowedUpdates lists the properties that can be updated. Nested properties
+// are given as dotted paths, for example "Boot.BootSourceOverrideTarget",
+// and listing an object allows updating any of its properties. Array
+// indexes are not part of the paths: "IPv4StaticAddresses.Address" allows
+// updating the Address of any of the static addresses, as well as adding and
+// removing static addresses.
 func (e *Entity) Update(originalEntity, currentEntity reflect.Value, allowedUpdates []string) error {
+	diff := &updateDiff{}
 	payload := make(map[string]interface{})
+	diff.structFields("", originalEntity, currentEntity, payload)
 
-	for i := 0; i < originalEntity.NumField(); i++ {
-		if !originalEntity.Field(i).CanInterface() {
-			// Private field or something that we can't access
+	// See if we are attempting to update anything that is not allowed
+	sort.Strings(diff.paths)
+	for _, path := range diff.paths {
+		if !updateAllowed(path, allowedUpdates) {
+			return fmt.Errorf("%s field is read only", path)
+		}
+	}
+
+	// If there are any allowed updates, try to send updates to the system and
+	// return the result.
+	if len(payload) > 0 {
+		return e.Patch(e.ODataID, payload)
+	}
+
+	return nil
+}
+
+// DefaultUpdateAttempts is the number of attempts UpdateWithRetry makes when
+// no limit is given.
+const DefaultUpdateAttempts = 3
+
+// Updatable is a resource whose changes can be committed to the service, such
+// as a redfish.ComputerSystem.
+type Updatable interface {
+	// Update commits the changes made to the resource.
+	Update() error
+	// Reload reads the current state of the resource into the given
+	// resource, which is the resource itself.
+	Reload(payload interface{}) error
+}
+
+// UpdateWithRetry applies changes to a resource and commits them, retrying
+// if the update was rejected because the resource changed since it was read.
+// Before each retry the resource is read again and mutate is called again,
+// so mutate should set the wanted values rather than derive them from values
+// that may be out of date. An error returned by mutate aborts the update.
+//
+// Up to maxAttempts attempts are made, or DefaultUpdateAttempts if it is not
+// positive. The ErrPreconditionFailed error of the last attempt is returned
+// if they all fail.
+func UpdateWithRetry(resource Updatable, mutate func() error, maxAttempts int) error {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultUpdateAttempts
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := resource.Reload(resource); err != nil {
+				return err
+			}
+		}
+
+		if err := mutate(); err != nil {
+			return err
+		}
+
+		err = resource.Update()
+		if !errors.Is(err, ErrPreconditionFailed) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// updateAllowed checks whether the property at path, or the object holding
+// it, is in the list of allowed updates. Objects and arrays replaced as a
+// whole, such as array elements being added or removed, are allowed if some
+// of their properties are.
+func updateAllowed(path string, allowedUpdates []string) bool {
+	for _, name := range allowedUpdates {
+		if name == path || strings.HasPrefix(path, name+".") || strings.HasPrefix(name, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// updateDiff computes the changes made to an entity, following the Redfish
+// PATCH semantics.
+type updateDiff struct {
+	// paths holds the dotted paths of the changed properties.
+	paths []string
+}
+
+// jsonMarshalerType is used to compare types with their own JSON encoding
+// as a whole.
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// structFields adds the changed fields of a struct to the payload.
+func (d *updateDiff) structFields(path string, original, current reflect.Value, payload map[string]interface{}) {
+	for i := 0; i < original.NumField(); i++ {
+		field := original.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			// Embedded objects share the properties of the outer object
+			d.structFields(path, original.Field(i), current.Field(i), payload)
 			continue
 		}
-		fieldType := originalEntity.Type().Field(i).Type.Kind()
-		if fieldType == reflect.Struct || fieldType == reflect.Ptr || fieldType == reflect.Slice {
-			// TODO: Handle more complicated data types
+		if !original.Field(i).CanInterface() {
+			// Private field or something that we can't access
 			continue
 		}
-		fieldName := originalEntity.Type().Field(i).Name
-		jsonName := originalEntity.Type().Field(i).Tag.Get("json")
-		if jsonName != "" {
-			fieldName = jsonName
+
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+			name = tag
 		}
-		originalValue := originalEntity.Field(i).Interface()
-		currentValue := currentEntity.Field(i).Interface()
-		if originalValue == nil && currentValue == nil {
+		if name == "-" || strings.Contains(name, "@") {
+			// Not serialized, or an annotation that cannot be updated
 			continue
-		} else if originalValue == nil {
-			payload[fieldName] = currentValue
-		} else if reflect.TypeOf(originalValue).Kind() != reflect.Map {
-			if originalValue != currentValue {
-				payload[fieldName] = currentValue
-			}
-		} else if !reflect.DeepEqual(originalValue, currentValue) {
-			payload[fieldName] = currentValue
+		}
+
+		if value, changed := d.value(joinPath(path, name), original.Field(i), current.Field(i)); changed {
+			payload[name] = value
 		}
 	}
+}
 
-	// See if we are attempting to update anything that is not allowed
-	for field := range payload {
-		found := false
-		for _, name := range allowedUpdates {
-			if name == field {
-				found = true
-				break
-			}
+// value returns the patch for a changed value.
+func (d *updateDiff) value(path string, original, current reflect.Value) (interface{}, bool) {
+	if original.Type().Implements(jsonMarshalerType) || reflect.PtrTo(original.Type()).Implements(jsonMarshalerType) {
+		return d.leaf(path, original, current)
+	}
+
+	switch original.Kind() {
+	case reflect.Struct:
+		nested := make(map[string]interface{})
+		d.structFields(path, original, current, nested)
+		return nested, len(nested) > 0
+	case reflect.Ptr:
+		if original.IsNil() && current.IsNil() {
+			return nil, false
+		}
+		if current.IsNil() {
+			d.paths = append(d.paths, path)
+			return nil, true
 		}
+		if original.IsNil() {
+			return d.added(path, current.Elem()), true
+		}
+		return d.value(path, original.Elem(), current.Elem())
+	case reflect.Slice, reflect.Array:
+		return d.array(path, original, current)
+	default:
+		return d.leaf(path, original, current)
+	}
+}
 
-		if !found {
-			return fmt.Errorf("%s field is read only", field)
+// leaf returns the current value if it differs from the original one.
+func (d *updateDiff) leaf(path string, original, current reflect.Value) (interface{}, bool) {
+	if reflect.DeepEqual(original.Interface(), current.Interface()) {
+		return nil, false
+	}
+	d.paths = append(d.paths, path)
+	return current.Interface(), true
+}
+
+// array returns the patch for a changed array. Arrays of objects are patched
+// element by element: unchanged elements are left as empty objects, changed
+// ones hold their changed properties, removed ones are set to null and added
+// ones hold the properties that are set. Other arrays are replaced as a
+// whole.
+func (d *updateDiff) array(path string, original, current reflect.Value) (interface{}, bool) {
+	if reflect.DeepEqual(original.Interface(), current.Interface()) {
+		return nil, false
+	}
+
+	if !isObjectType(original.Type().Elem()) {
+		d.paths = append(d.paths, path)
+		if current.Kind() == reflect.Slice && current.IsNil() {
+			// Clearing the array rather than setting it to null
+			return reflect.MakeSlice(current.Type(), 0, 0).Interface(), true
 		}
+		return current.Interface(), true
 	}
 
-	// If there are any allowed updates, try to send updates to the system and
-	// return the result.
-	if len(payload) > 0 {
-		return e.Patch(e.ODataID, payload)
+	var result []interface{}
+	changed := false
+	for i := 0; i < current.Len() || i < original.Len(); i++ {
+		switch {
+		case i >= original.Len():
+			result = append(result, d.added(path, current.Index(i)))
+			changed = true
+		case i >= current.Len():
+			d.paths = append(d.paths, path)
+			result = append(result, nil)
+			changed = true
+		default:
+			value, elementChanged := d.value(path, original.Index(i), current.Index(i))
+			if !elementChanged {
+				value = map[string]interface{}{}
+			}
+			result = append(result, value)
+			changed = changed || elementChanged
+		}
 	}
 
-	return nil
+	return result, changed
+}
+
+// added returns the patch for a value that was not present before, holding
+// only the properties that are set.
+func (d *updateDiff) added(path string, current reflect.Value) interface{} {
+	value, changed := d.value(path, reflect.Zero(current.Type()), current)
+	if !changed || !isObjectType(current.Type()) {
+		d.paths = append(d.paths, path)
+		return current.Interface()
+	}
+	return value
+}
+
+// isObjectType checks whether values of a type are encoded as JSON objects
+// whose properties can be patched individually.
+func isObjectType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct &&
+		!t.Implements(jsonMarshalerType) && !reflect.PtrTo(t).Implements(jsonMarshalerType)
+}
+
+// joinPath appends a property name to a dotted path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
 }
 
 // Get performs a Get request against the Redfish service and save etag
@@ -98,18 +291,44 @@ func (e *Entity) Get(c Client, uri string, payload interface{}) error {
 	}
 	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(payload)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
 
-	if resp.Header["Etag"] != nil {
-		e.etag = resp.Header["Etag"][0]
+	err = json.Unmarshal(body, payload)
+	if err != nil {
+		return err
+	}
+
+	e.etag = resp.Header.Get("Etag")
+	if e.etag == "" {
+		// Fall back to the ETag the service put in the resource itself
+		var t struct {
+			ODataEtag string `json:"@odata.etag"`
+		}
+		if json.Unmarshal(body, &t) == nil {
+			e.etag = t.ODataEtag
+		}
 	}
 	e.SetClient(c)
 	return nil
 }
 
+// ETag returns the ETag of the resource as last read from the service, or an
+// empty string if the service did not provide one. It is sent with If-Match
+// when updating the resource so that the update fails with
+// ErrPreconditionFailed if the resource changed in the meantime.
+func (e *Entity) ETag() string {
+	return e.etag
+}
+
+// Reload reads the current state of the resource from the service into
+// payload, which is the resource embedding this entity.
+func (e *Entity) Reload(payload interface{}) error {
+	return e.Get(e.Client, e.ODataID, payload)
+}
+
 // Patch performs a Patch request against the Redfish service with etag
 func (e *Entity) Patch(uri string, payload interface{}) error {
 	header := make(map[string]string)
@@ -118,22 +337,34 @@ func (e *Entity) Patch(uri string, payload interface{}) error {
 	}
 
 	resp, err := e.Client.PatchWithHeaders(uri, payload, header)
+	if err != nil {
+		return err
+	}
+
+	if uri == e.ODataID && resp.Header.Get("Etag") != "" {
+		// Keep the ETag of the updated resource for further updates
+		e.etag = resp.Header.Get("Etag")
+	}
+	return resp.Body.Close()
+}
+
+// Post performs a Post request against the Redfish service with etag
+func (e *Entity) Post(uri string, payload interface{}) error {
+	resp, err := e.PostWithResponse(uri, payload)
 	if err == nil {
 		return resp.Body.Close()
 	}
 	return err
 }
 
-// Post performs a Post request against the Redfish service with etag
-func (e *Entity) Post(uri string, payload interface{}) error {
+// PostWithResponse performs a Post request against the Redfish service with
+// etag and returns the response. The caller is responsible for closing the
+// response body.
+func (e *Entity) PostWithResponse(uri string, payload interface{}) (*http.Response, error) {
 	header := make(map[string]string)
 	if e.etag != "" {
 		header["If-Match"] = e.etag
 	}
 
-	resp, err := e.Client.PostWithHeaders(uri, payload, header)
-	if err == nil {
-		return resp.Body.Close()
-	}
-	return err
+	return e.Client.PostWithHeaders(uri, payload, header)
 }
@@ -0,0 +1,362 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type updateTestAddress struct {
+	Address    string
+	Origin     string
+	SubnetMask string
+}
+
+type updateTestSettings struct {
+	Enabled bool
+	Mode    string `json:",omitempty"`
+}
+
+type updateTestEntity struct {
+	Entity
+	Description string
+	Settings    updateTestSettings
+	Optional    *updateTestSettings
+	Servers     []string
+	Addresses   []updateTestAddress
+	Count       int `json:"Members@odata.count"`
+	hidden      string
+}
+
+var updateTestAllowed = []string{
+	"Addresses.Address",
+	"Addresses.SubnetMask",
+	"Description",
+	"Optional",
+	"Servers",
+	"Settings.Mode",
+}
+
+func newUpdateTestEntity() *updateTestEntity {
+	return &updateTestEntity{
+		Entity:      Entity{ODataID: "/redfish/v1/Test/1", ID: "1", Name: "Test"},
+		Description: "Test",
+		Settings:    updateTestSettings{Enabled: true, Mode: "Auto"},
+		Servers:     []string{"192.168.1.1", "192.168.1.2"},
+		Addresses: []updateTestAddress{
+			{Address: "10.0.0.2", Origin: "Static", SubnetMask: "255.255.255.0"},
+			{Address: "10.0.1.2", Origin: "Static", SubnetMask: "255.255.255.0"},
+		},
+		Count: 2,
+	}
+}
+
+// TestEntityUpdate tests the payloads sent for changes to nested properties.
+func TestEntityUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(*updateTestEntity)
+		expected string
+	}{
+		{
+			"no change",
+			func(e *updateTestEntity) {},
+			"",
+		},
+		{
+			"private field",
+			func(e *updateTestEntity) { e.hidden = "changed" },
+			"",
+		},
+		{
+			"top level",
+			func(e *updateTestEntity) { e.Description = "Changed" },
+			"map[Description:Changed]",
+		},
+		{
+			"nested struct",
+			func(e *updateTestEntity) { e.Settings.Mode = "Manual" },
+			"map[Settings:map[Mode:Manual]]",
+		},
+		{
+			"pointer",
+			func(e *updateTestEntity) { e.Optional = &updateTestSettings{Enabled: true} },
+			"map[Optional:map[Enabled:true]]",
+		},
+		{
+			"primitive array",
+			func(e *updateTestEntity) { e.Servers = []string{"192.168.1.3"} },
+			"map[Servers:[192.168.1.3]]",
+		},
+		{
+			"cleared primitive array",
+			func(e *updateTestEntity) { e.Servers = nil },
+			"map[Servers:[]]",
+		},
+		{
+			"object array element",
+			func(e *updateTestEntity) { e.Addresses[1].Address = "10.0.1.3" },
+			"map[Addresses:[map[] map[Address:10.0.1.3]]]",
+		},
+		{
+			"object array removal",
+			func(e *updateTestEntity) { e.Addresses = e.Addresses[:1] },
+			"map[Addresses:[map[] <nil>]]",
+		},
+		{
+			"object array addition",
+			func(e *updateTestEntity) {
+				e.Addresses = append(e.Addresses, updateTestAddress{Address: "10.0.2.2", SubnetMask: "255.255.0.0"})
+			},
+			"map[Addresses:[map[] map[] map[Address:10.0.2.2 SubnetMask:255.255.0.0]]]",
+		},
+	}
+
+	for _, test := range tests {
+		original := newUpdateTestEntity()
+		current := newUpdateTestEntity()
+		testClient := &TestClient{}
+		current.SetClient(testClient)
+		test.change(current)
+
+		err := current.Update(reflect.ValueOf(original).Elem(), reflect.ValueOf(current).Elem(), updateTestAllowed)
+		if err != nil {
+			t.Errorf("%s: error making Update call: %s", test.name, err)
+			continue
+		}
+
+		calls := testClient.CapturedCalls()
+		if test.expected == "" {
+			if len(calls) != 0 {
+				t.Errorf("%s: unexpected update call: %s", test.name, calls[0].Payload)
+			}
+			continue
+		}
+
+		if len(calls) != 1 {
+			t.Errorf("%s: expected 1 update call, got %d", test.name, len(calls))
+			continue
+		}
+		if calls[0].Payload != test.expected {
+			t.Errorf("%s: unexpected update payload: %s", test.name, calls[0].Payload)
+		}
+	}
+}
+
+// TestEntityUpdateReadOnly tests rejecting changes to read only nested
+// properties.
+func TestEntityUpdateReadOnly(t *testing.T) {
+	tests := []struct {
+		change   func(*updateTestEntity)
+		expected string
+	}{
+		{
+			func(e *updateTestEntity) { e.Settings.Enabled = false },
+			"Settings.Enabled field is read only",
+		},
+		{
+			func(e *updateTestEntity) { e.Addresses[0].Origin = "DHCP" },
+			"Addresses.Origin field is read only",
+		},
+		{
+			func(e *updateTestEntity) {
+				e.Addresses = append(e.Addresses, updateTestAddress{Address: "10.0.2.2", Origin: "Static"})
+			},
+			"Addresses.Origin field is read only",
+		},
+		{
+			func(e *updateTestEntity) { e.Name = "Changed" },
+			"Name field is read only",
+		},
+	}
+
+	for _, test := range tests {
+		original := newUpdateTestEntity()
+		current := newUpdateTestEntity()
+		testClient := &TestClient{}
+		current.SetClient(testClient)
+		test.change(current)
+
+		err := current.Update(reflect.ValueOf(original).Elem(), reflect.ValueOf(current).Elem(), updateTestAllowed)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("Expected error %q, got: %v", test.expected, err)
+		}
+		if len(testClient.CapturedCalls()) != 0 {
+			t.Errorf("Unexpected update call for read only change: %s", testClient.CapturedCalls()[0].Payload)
+		}
+	}
+}
+
+type etagTestResource struct {
+	Entity
+	AssetTag string
+	rawData  []byte
+}
+
+func (r *etagTestResource) UnmarshalJSON(b []byte) error {
+	type temp etagTestResource
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*r = etagTestResource(t.temp)
+	r.rawData = b
+
+	return nil
+}
+
+func (r *etagTestResource) Update() error {
+	original := new(etagTestResource)
+	err := original.UnmarshalJSON(r.rawData)
+	if err != nil {
+		return err
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(r).Elem()
+
+	return r.Entity.Update(originalElement, currentElement, []string{"AssetTag"})
+}
+
+// TestEntityETag tests reading the ETag of a resource.
+func TestEntityETag(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Test/1", "@odata.etag": "W/\"body\""}`, http.Header{"Etag": []string{`W/"header"`}}),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Test/1", "@odata.etag": "W/\"body\""}`, nil),
+			},
+			http.MethodPatch: {
+				testResponse(http.StatusNoContent, "", http.Header{"Etag": []string{`W/"patched"`}}),
+			},
+		},
+	}
+
+	var resource etagTestResource
+	err := resource.Get(testClient, "/redfish/v1/Test/1", &resource)
+	if err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+	if resource.ETag() != `W/"header"` {
+		t.Errorf("Expected the ETag header, got: %s", resource.ETag())
+	}
+
+	err = resource.Reload(&resource)
+	if err != nil {
+		t.Fatalf("Error reloading resource: %s", err)
+	}
+	if resource.ETag() != `W/"body"` {
+		t.Errorf("Expected the ETag of the body, got: %s", resource.ETag())
+	}
+
+	resource.AssetTag = "test"
+	err = resource.Update()
+	if err != nil {
+		t.Fatalf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[2].CustomHeaders["If-Match"] != `W/"body"` {
+		t.Errorf("Unexpected If-Match header: %s", calls[2].CustomHeaders["If-Match"])
+	}
+	if resource.ETag() != `W/"patched"` {
+		t.Errorf("Expected the ETag of the update response, got: %s", resource.ETag())
+	}
+}
+
+// TestUpdateWithRetry tests retrying an update rejected because the
+// resource changed.
+func TestUpdateWithRetry(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Test/1", "AssetTag": "first"}`, http.Header{"Etag": []string{`W/"1"`}}),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Test/1", "AssetTag": "second"}`, http.Header{"Etag": []string{`W/"2"`}}),
+			},
+			http.MethodPatch: {
+				testResponse(http.StatusPreconditionFailed, "", nil),
+				nil,
+			},
+		},
+	}
+
+	var resource etagTestResource
+	err := resource.Get(testClient, "/redfish/v1/Test/1", &resource)
+	if err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+
+	var seen []string
+	err = UpdateWithRetry(&resource, func() error {
+		seen = append(seen, resource.AssetTag)
+		resource.AssetTag = "mine"
+		return nil
+	}, 0)
+	if err != nil {
+		t.Fatalf("Error updating resource: %s", err)
+	}
+
+	if len(seen) != 2 || seen[0] != "first" || seen[1] != "second" {
+		t.Errorf("Expected the mutation to be applied to each version, got: %v", seen)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 4 {
+		t.Fatalf("Expected 4 calls, got %d", len(calls))
+	}
+	if calls[1].CustomHeaders["If-Match"] != `W/"1"` || calls[3].CustomHeaders["If-Match"] != `W/"2"` {
+		t.Errorf("Unexpected If-Match headers: %s, %s",
+			calls[1].CustomHeaders["If-Match"], calls[3].CustomHeaders["If-Match"])
+	}
+	if calls[3].Payload != "map[AssetTag:mine]" {
+		t.Errorf("Unexpected update payload: %s", calls[3].Payload)
+	}
+}
+
+// TestUpdateWithRetryExhausted tests giving up after the maximum number of
+// attempts.
+func TestUpdateWithRetryExhausted(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Test/1", "AssetTag": "first"}`, http.Header{"Etag": []string{`W/"1"`}}),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Test/1", "AssetTag": "second"}`, http.Header{"Etag": []string{`W/"2"`}}),
+			},
+			http.MethodPatch: {
+				testResponse(http.StatusPreconditionFailed, "", nil),
+				testResponse(http.StatusPreconditionFailed, "", nil),
+			},
+		},
+	}
+
+	var resource etagTestResource
+	err := resource.Get(testClient, "/redfish/v1/Test/1", &resource)
+	if err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+
+	err = UpdateWithRetry(&resource, func() error {
+		resource.AssetTag = "mine"
+		return nil
+	}, 2)
+	if !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("Expected a precondition failed error, got: %v", err)
+	}
+
+	aborted := errors.New("aborted")
+	err = UpdateWithRetry(&resource, func() error { return aborted }, 2)
+	if err != aborted {
+		t.Errorf("Expected the mutation error, got: %v", err)
+	}
+}
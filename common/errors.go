@@ -0,0 +1,123 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Kinds of errors returned by the service. Errors returned by the library
+// match them with errors.Is, for example:
+//
+//	if errors.Is(err, common.ErrNotFound) {
+//		...
+//	}
+var (
+	// ErrNotFound is matched by errors for resources that do not exist.
+	ErrNotFound = errors.New("resource not found")
+	// ErrUnauthorized is matched by errors for requests rejected because the
+	// credentials are missing or invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPreconditionFailed is matched by errors for requests rejected
+	// because the resource changed since the ETag used in the request was
+	// read, or because the service requires an ETag.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrConflict is matched by errors for requests conflicting with the
+	// current state of the resource.
+	ErrConflict = errors.New("conflict")
+	// ErrServiceUnavailable is matched by errors for requests the service
+	// cannot handle for the time being.
+	ErrServiceUnavailable = errors.New("service unavailable")
+	// ErrActionNotSupported is matched by errors for actions or operations
+	// the service or resource does not support, whether the service rejected
+	// the request or the resource does not advertise the action.
+	ErrActionNotSupported = errors.New("action not supported")
+	// ErrReadOnly is matched by errors for requests changing resources
+	// through a client that can only read them, such as a FileClient.
+	ErrReadOnly = errors.New("read only client")
+)
+
+// Is reports whether the error is of the given kind, allowing errors.Is to
+// match it with the kinds of errors such as ErrNotFound.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.HTTPReturnedStatusCode == http.StatusNotFound ||
+			e.HTTPReturnedStatusCode == http.StatusGone ||
+			e.hasMessage("ResourceMissingAtURI")
+	case ErrUnauthorized:
+		return e.HTTPReturnedStatusCode == http.StatusUnauthorized
+	case ErrPreconditionFailed:
+		return e.HTTPReturnedStatusCode == http.StatusPreconditionFailed ||
+			e.HTTPReturnedStatusCode == http.StatusPreconditionRequired
+	case ErrConflict:
+		return e.HTTPReturnedStatusCode == http.StatusConflict
+	case ErrServiceUnavailable:
+		return e.HTTPReturnedStatusCode == http.StatusServiceUnavailable
+	case ErrActionNotSupported:
+		return e.HTTPReturnedStatusCode == http.StatusMethodNotAllowed ||
+			e.HTTPReturnedStatusCode == http.StatusNotImplemented ||
+			e.hasMessage("ActionNotSupported")
+	}
+	return false
+}
+
+// hasMessage reports whether the error or its extended information carry
+// a message with the given key, whatever the registry version.
+func (e *Error) hasMessage(key string) bool {
+	if strings.HasSuffix(e.Code, "."+key) {
+		return true
+	}
+	for _, info := range e.ExtendedInfos {
+		if strings.HasSuffix(info.MessageID, "."+key) {
+			return true
+		}
+	}
+	return false
+}
+
+// RelatedProperties returns the properties the extended information of the
+// error refers to, as JSON pointers.
+func (e *Error) RelatedProperties() []string {
+	var result []string
+	for _, info := range e.ExtendedInfos {
+		result = append(result, info.RelatedProperties...)
+	}
+	return result
+}
+
+// ConstructRequestError creates an error for an unsuccessful response to a
+// request, recording the request method and URL.
+func ConstructRequestError(method, url string, statusCode int, b []byte) error {
+	err := ConstructError(statusCode, b)
+	if e, ok := err.(*Error); ok {
+		e.Method = method
+		e.URL = url
+	}
+	return err
+}
+
+// actionNotSupportedError reports an action a resource does not advertise.
+type actionNotSupportedError struct {
+	message string
+}
+
+// NewActionNotSupportedError creates an error for an action or operation the
+// resource does not support. It matches ErrActionNotSupported.
+func NewActionNotSupportedError(format string, a ...interface{}) error {
+	return &actionNotSupportedError{message: fmt.Sprintf(format, a...)}
+}
+
+func (e *actionNotSupportedError) Error() string {
+	return e.message
+}
+
+// Is reports whether the target is ErrActionNotSupported.
+func (e *actionNotSupportedError) Is(target error) bool {
+	return target == ErrActionNotSupported
+}
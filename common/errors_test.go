@@ -0,0 +1,105 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestErrorKinds tests matching service errors with the kinds of errors.
+func TestErrorKinds(t *testing.T) {
+	kinds := []error{
+		ErrNotFound,
+		ErrUnauthorized,
+		ErrPreconditionFailed,
+		ErrConflict,
+		ErrServiceUnavailable,
+		ErrActionNotSupported,
+		ErrReadOnly,
+	}
+
+	tests := []struct {
+		statusCode int
+		body       string
+		expected   error
+	}{
+		{http.StatusNotFound, "", ErrNotFound},
+		{http.StatusGone, "", ErrNotFound},
+		{http.StatusUnauthorized, "", ErrUnauthorized},
+		{http.StatusPreconditionFailed, "", ErrPreconditionFailed},
+		{http.StatusPreconditionRequired, "", ErrPreconditionFailed},
+		{http.StatusConflict, "", ErrConflict},
+		{http.StatusServiceUnavailable, "", ErrServiceUnavailable},
+		{http.StatusMethodNotAllowed, "", ErrActionNotSupported},
+		{http.StatusNotImplemented, "", ErrActionNotSupported},
+		{http.StatusBadRequest, `{"error": {"code": "Base.1.8.GeneralError", "@Message.ExtendedInfo": [
+			{"MessageId": "Base.1.8.ActionNotSupported", "MessageArgs": ["Chassis.Reset"]}]}}`, ErrActionNotSupported},
+		{http.StatusBadRequest, `{"error": {"code": "Base.1.0.ResourceMissingAtURI"}}`, ErrNotFound},
+		{http.StatusInternalServerError, "", nil},
+	}
+
+	for _, test := range tests {
+		err := ConstructError(test.statusCode, []byte(test.body))
+		for _, kind := range kinds {
+			if errors.Is(err, kind) != (kind == test.expected) {
+				t.Errorf("%d %s: unexpected match of %v", test.statusCode, test.body, kind)
+			}
+		}
+	}
+}
+
+// TestErrorDetails tests the details of errors for failed requests.
+func TestErrorDetails(t *testing.T) {
+	err := ConstructRequestError(http.MethodPatch, "/redfish/v1/Chassis/1", http.StatusBadRequest, []byte(`{
+		"error": {
+			"code": "Base.1.8.GeneralError",
+			"message": "A general error has occurred.",
+			"@Message.ExtendedInfo": [
+				{
+					"MessageId": "Base.1.8.PropertyNotWritable",
+					"MessageSeverity": "Warning",
+					"RelatedProperties": ["#/SKU"]
+				}
+			]
+		}
+	}`))
+
+	var redfishError *Error
+	if !errors.As(err, &redfishError) {
+		t.Fatalf("Expected a service error, got: %v", err)
+	}
+
+	if redfishError.Method != http.MethodPatch || redfishError.URL != "/redfish/v1/Chassis/1" {
+		t.Errorf("Unexpected request: %s %s", redfishError.Method, redfishError.URL)
+	}
+
+	if redfishError.ExtendedInfos[0].MessageSeverity != WarningHealth {
+		t.Errorf("Unexpected MessageSeverity: %s", redfishError.ExtendedInfos[0].MessageSeverity)
+	}
+
+	if props := redfishError.RelatedProperties(); len(props) != 1 || props[0] != "#/SKU" {
+		t.Errorf("Unexpected RelatedProperties: %v", props)
+	}
+
+	if err.Error()[:4] != "400:" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+}
+
+// TestActionNotSupportedError tests errors for actions resources do not
+// advertise.
+func TestActionNotSupportedError(t *testing.T) {
+	err := NewActionNotSupportedError("%s is not supported by this service", "GenerateCSR")
+
+	if err.Error() != "GenerateCSR is not supported by this service" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+
+	if !errors.Is(err, ErrActionNotSupported) || errors.Is(err, ErrNotFound) {
+		t.Errorf("Unexpected error kind: %v", err)
+	}
+}
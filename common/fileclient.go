@@ -0,0 +1,166 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// FileClient is a read only Client serving GET requests from a directory of
+// resources in the layout of the DMTF Redfish mockups: each resource is an
+// index.json file in the directory matching its URI. This allows using the
+// library offline, for example on data captured from a service.
+//
+// Requests changing resources fail with an error matching ErrReadOnly.
+type FileClient struct {
+	fsys fs.FS
+	// root is the directory holding the service root.
+	root string
+}
+
+// NewFileClient creates a client serving the resources of a mockup
+// directory. The directory either holds the service root itself or a
+// redfish/v1 directory holding it.
+func NewFileClient(dir string) (*FileClient, error) {
+	return NewFileClientFS(os.DirFS(dir))
+}
+
+// NewFileClientFS creates a client serving the resources of a mockup file
+// system, in the same layout as for NewFileClient.
+func NewFileClientFS(fsys fs.FS) (*FileClient, error) {
+	c := &FileClient{fsys: fsys, root: "."}
+	if info, err := fs.Stat(fsys, "redfish/v1"); err == nil && info.IsDir() {
+		c.root = "redfish/v1"
+	}
+
+	if _, err := fs.Stat(fsys, path.Join(c.root, "index.json")); err != nil {
+		return nil, fmt.Errorf("no service root found in mockup: %w", err)
+	}
+
+	return c, nil
+}
+
+// errReadOnly reports a request changing resources through a read only
+// client.
+type errReadOnly struct {
+	method string
+	url    string
+}
+
+func (e *errReadOnly) Error() string {
+	return fmt.Sprintf("%s %s: the client is read only", e.method, e.url)
+}
+
+// Is reports whether the target is ErrReadOnly.
+func (e *errReadOnly) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// file returns the path of the file holding the resource at the given URI.
+func (c *FileClient) file(url string) (string, bool) {
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	url = strings.TrimSuffix(url, "/")
+
+	root := strings.TrimSuffix(DefaultServiceRoot, "/")
+	if url != root && !strings.HasPrefix(url, root+"/") {
+		return "", false
+	}
+
+	name := path.Join(c.root, strings.TrimPrefix(url, root), "index.json")
+	return name, fs.ValidPath(name)
+}
+
+// Get reads the resource at the given URI.
+func (c *FileClient) Get(url string) (*http.Response, error) {
+	return c.GetWithHeaders(url, nil)
+}
+
+// GetWithHeaders reads the resource at the given URI. The headers are
+// ignored.
+func (c *FileClient) GetWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	name, ok := c.file(url)
+	var body []byte
+	var err error
+	if ok {
+		body, err = fs.ReadFile(c.fsys, name)
+	}
+	if !ok || errors.Is(err, fs.ErrNotExist) {
+		message := fmt.Sprintf(`{"error": {"code": "Base.1.8.ResourceMissingAtURI", "message": "The resource at the URI %s was not found."}}`, url)
+		return nil, ConstructRequestError(http.MethodGet, url, http.StatusNotFound, []byte(message))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, nil
+}
+
+// Post fails since the client is read only.
+func (c *FileClient) Post(url string, payload interface{}) (*http.Response, error) {
+	return c.PostWithHeaders(url, payload, nil)
+}
+
+// PostWithHeaders fails since the client is read only.
+func (c *FileClient) PostWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return nil, &errReadOnly{method: http.MethodPost, url: url}
+}
+
+// PostMultipart fails since the client is read only.
+func (c *FileClient) PostMultipart(url string, payload map[string]io.Reader) (*http.Response, error) {
+	return c.PostMultipartWithHeaders(url, payload, nil)
+}
+
+// PostMultipartWithHeaders fails since the client is read only.
+func (c *FileClient) PostMultipartWithHeaders(url string, payload map[string]io.Reader, customHeaders map[string]string) (*http.Response, error) {
+	return nil, &errReadOnly{method: http.MethodPost, url: url}
+}
+
+// Patch fails since the client is read only.
+func (c *FileClient) Patch(url string, payload interface{}) (*http.Response, error) {
+	return c.PatchWithHeaders(url, payload, nil)
+}
+
+// PatchWithHeaders fails since the client is read only.
+func (c *FileClient) PatchWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return nil, &errReadOnly{method: http.MethodPatch, url: url}
+}
+
+// Put fails since the client is read only.
+func (c *FileClient) Put(url string, payload interface{}) (*http.Response, error) {
+	return c.PutWithHeaders(url, payload, nil)
+}
+
+// PutWithHeaders fails since the client is read only.
+func (c *FileClient) PutWithHeaders(url string, payload interface{}, customHeaders map[string]string) (*http.Response, error) {
+	return nil, &errReadOnly{method: http.MethodPut, url: url}
+}
+
+// Delete fails since the client is read only.
+func (c *FileClient) Delete(url string) (*http.Response, error) {
+	return c.DeleteWithHeaders(url, nil)
+}
+
+// DeleteWithHeaders fails since the client is read only.
+func (c *FileClient) DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error) {
+	return nil, &errReadOnly{method: http.MethodDelete, url: url}
+}
@@ -0,0 +1,102 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+)
+
+var fileClientMockup = fstest.MapFS{
+	"index.json":           {Data: []byte(`{"@odata.id": "/redfish/v1/", "Id": "RootService"}`)},
+	"Systems/index.json":   {Data: []byte(`{"@odata.id": "/redfish/v1/Systems"}`)},
+	"Systems/1/index.json": {Data: []byte(`{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`)},
+}
+
+// TestFileClientGet tests reading resources from a mockup.
+func TestFileClientGet(t *testing.T) {
+	c, err := NewFileClientFS(fileClientMockup)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	tests := map[string]string{
+		"/redfish/v1/":                     `{"@odata.id": "/redfish/v1/", "Id": "RootService"}`,
+		"/redfish/v1/Systems":              `{"@odata.id": "/redfish/v1/Systems"}`,
+		"/redfish/v1/Systems/1/":           `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`,
+		"/redfish/v1/Systems/1?$select=Id": `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`,
+		"/redfish/v1/Systems/1#/Status":    `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1"}`,
+	}
+	for uri, expected := range tests {
+		resp, err := c.Get(uri)
+		if err != nil {
+			t.Errorf("Error getting %s: %s", uri, err)
+			continue
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != expected {
+			t.Errorf("Unexpected response for %s: %d %s", uri, resp.StatusCode, body)
+		}
+	}
+
+	for _, uri := range []string{"/redfish/v1/Systems/2", "/redfish/v1/../index.json", "/other"} {
+		_, err := c.Get(uri)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected not found error for %s, got: %v", uri, err)
+		}
+	}
+}
+
+// TestFileClientRedfishDirectory tests mockups holding a redfish/v1
+// directory.
+func TestFileClientRedfishDirectory(t *testing.T) {
+	c, err := NewFileClientFS(fstest.MapFS{
+		"redfish/v1/index.json":         {Data: []byte(`{"Id": "RootService"}`)},
+		"redfish/v1/Systems/index.json": {Data: []byte(`{"Name": "Systems"}`)},
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	resp, err := c.Get("/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"Name": "Systems"}` {
+		t.Errorf("Unexpected body: %s", body)
+	}
+
+	if _, err := NewFileClientFS(fstest.MapFS{"Systems/index.json": {Data: []byte(`{}`)}}); err == nil {
+		t.Error("Expected an error for a mockup without service root")
+	}
+}
+
+// TestFileClientReadOnly tests that changes are rejected.
+func TestFileClientReadOnly(t *testing.T) {
+	c, err := NewFileClientFS(fileClientMockup)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	calls := []func() (*http.Response, error){
+		func() (*http.Response, error) { return c.Post("/redfish/v1/Systems", nil) },
+		func() (*http.Response, error) { return c.PostMultipart("/redfish/v1/Systems", nil) },
+		func() (*http.Response, error) { return c.Patch("/redfish/v1/Systems/1", nil) },
+		func() (*http.Response, error) { return c.Put("/redfish/v1/Systems/1", nil) },
+		func() (*http.Response, error) { return c.Delete("/redfish/v1/Systems/1") },
+	}
+	for i, call := range calls {
+		_, err := call()
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Call %d: expected read only error, got: %v", i, err)
+		}
+	}
+}
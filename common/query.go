@@ -0,0 +1,413 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// QueryOptions holds the OData query parameters applied to the requests made
+// through a QueryClient.
+type QueryOptions struct {
+	// Expand requests collections with their members expanded inline, saving
+	// one request per member.
+	Expand bool
+	// Select limits the properties returned by GetSelected and
+	// GetSelectedMembers to the given list. Properties needed to identify the
+	// resource are always returned by the service. Other requests return
+	// complete resources, so that they can be updated.
+	Select []string
+	// Filter is a $filter expression applied to collections, for example
+	// "SystemType eq 'Physical'".
+	Filter string
+	// Top limits the number of members returned for collections.
+	Top int
+	// Skip skips the given number of members of collections.
+	Skip int
+	// Only requests single member collections to return the member instead.
+	Only bool
+}
+
+// QuerySupport describes the query parameters a service supports, as
+// advertised in the ProtocolFeaturesSupported of the service root.
+type QuerySupport struct {
+	// ExpandAll is set if the asterisk $expand option is supported.
+	ExpandAll bool
+	// ExpandNoLinks is set if the period $expand option is supported.
+	ExpandNoLinks bool
+	// ExpandLevels is set if the $levels qualifier of $expand is supported.
+	ExpandLevels bool
+	// Select is set if $select is supported.
+	Select bool
+	// Filter is set if $filter is supported.
+	Filter bool
+	// TopSkip is set if $top and $skip are supported.
+	TopSkip bool
+	// Only is set if the only query parameter is supported.
+	Only bool
+}
+
+// QueryClient is a Client applying OData query parameters to requests. Query
+// parameters the service does not support are left out, falling back to the
+// behavior of the wrapped client.
+//
+// Collections retrieved through the client are expanded in a single request
+// when possible. The expanded members are kept so that getting them afterwards
+// does not need another request. They are only kept for the listing that
+// fetched them: each member is answered from the expansion once, members left
+// over after a page is listed with CollectList are dropped, and fetching the
+// next collection drops any remaining ones.
+//
+// The query options only apply when the *QueryClient itself is given to the
+// collection functions of this package, and so to the Get* and
+// ListReferenced* functions built on them. A Client wrapping a QueryClient
+// sends plain requests.
+type QueryClient struct {
+	Client
+
+	options QueryOptions
+	support QuerySupport
+
+	mu      sync.Mutex
+	members map[string][]byte
+}
+
+// NewQueryClient wraps a client to apply the given query options.
+func NewQueryClient(c Client, options QueryOptions, support QuerySupport) *QueryClient {
+	return &QueryClient{
+		Client:  c,
+		options: options,
+		support: support,
+		members: make(map[string][]byte),
+	}
+}
+
+// Options returns the query options applied by the client.
+func (c *QueryClient) Options() QueryOptions {
+	return c.options
+}
+
+// MaxConcurrentRequests returns the concurrency limit of the wrapped client.
+func (c *QueryClient) MaxConcurrentRequests() int {
+	if cl, ok := c.Client.(ConcurrencyLimiter); ok {
+		return cl.MaxConcurrentRequests()
+	}
+	return 0
+}
+
+// rawRequester is implemented by clients able to send a payload as is.
+type rawRequester interface {
+	RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error)
+}
+
+// RunRawRequestWithHeaders sends a payload as is through the wrapped client,
+// without query options.
+func (c *QueryClient) RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	client, ok := c.Client.(rawRequester)
+	if !ok {
+		return nil, fmt.Errorf("client does not support sending raw payloads")
+	}
+	return client.RunRawRequestWithHeaders(method, url, payloadBuffer, contentType, customHeaders)
+}
+
+// GetWithHeadersContext performs a GET request through the wrapped client tied
+// to ctx, without query options. Clients that cannot tie requests to a
+// context run it with GetWithHeaders.
+func (c *QueryClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	if client, ok := c.Client.(ContextGetter); ok {
+		return client.GetWithHeadersContext(ctx, url, customHeaders)
+	}
+	return c.Client.GetWithHeaders(url, customHeaders)
+}
+
+// Get performs a GET request, answering from the expanded collection members
+// if the resource was part of one.
+func (c *QueryClient) Get(uri string) (*http.Response, error) {
+	if body, ok := c.takeMember(uri); ok {
+		return memberResponse(body), nil
+	}
+	return c.Client.Get(uri)
+}
+
+// GetSelected gets a resource limited to the properties of the Select option.
+// Resources read this way are partial and must not be updated.
+func (c *QueryClient) GetSelected(uri string) (*http.Response, error) {
+	if query := c.selectQuery(); query != "" {
+		uri = appendQuery(uri, query)
+	}
+	return c.Client.Get(uri)
+}
+
+// GetSelectedMembers gets the members of a collection limited to the
+// properties of the Select option, expanding the collection when possible.
+// Members that are not expanded are retrieved one by one. The members are
+// partial, so they are not kept to answer later requests.
+func (c *QueryClient) GetSelectedMembers(uri string) ([]json.RawMessage, error) {
+	var result []json.RawMessage
+	seen := make(map[string]bool)
+	next := c.selectedCollectionURI(uri)
+	for next != "" && !seen[next] {
+		seen[next] = true
+
+		resp, err := c.Client.Get(next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Members  []json.RawMessage `json:"Members"`
+			NextLink string            `json:"Members@odata.nextLink"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range page.Members {
+			var properties map[string]json.RawMessage
+			if json.Unmarshal(member, &properties) == nil && len(properties) >= 2 {
+				result = append(result, member)
+				continue
+			}
+
+			// Not expanded, only a reference to the member
+			var id Link
+			if err := json.Unmarshal(member, &id); err != nil || id == "" {
+				continue
+			}
+			body, err := c.getSelectedBody(id.String())
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, body)
+		}
+		next = page.NextLink
+	}
+	return result, nil
+}
+
+// getSelectedBody gets the body of a resource limited to the selected
+// properties.
+func (c *QueryClient) getSelectedBody(uri string) ([]byte, error) {
+	resp, err := c.GetSelected(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// selectQuery returns the $select parameter.
+func (c *QueryClient) selectQuery() string {
+	if !c.support.Select || len(c.options.Select) == 0 {
+		return ""
+	}
+	return "$select=" + strings.Join(c.options.Select, ",")
+}
+
+// selectedCollectionURI returns the URI used to get the selected members of
+// a collection.
+func (c *QueryClient) selectedCollectionURI(uri string) string {
+	query := c.collectionQuery()
+	if expand := c.expandQuery(); expand != "" {
+		if selected := c.selectQuery(); selected != "" {
+			query += "&" + selected
+		}
+	}
+	if query == "" {
+		return uri
+	}
+	return appendQuery(uri, query)
+}
+
+// expandQuery returns the $expand parameter used to expand collection members.
+func (c *QueryClient) expandQuery() string {
+	if !c.options.Expand {
+		return ""
+	}
+
+	var expand string
+	switch {
+	case c.support.ExpandNoLinks:
+		expand = "."
+	case c.support.ExpandAll:
+		expand = "*"
+	default:
+		return ""
+	}
+
+	if c.support.ExpandLevels {
+		expand += "($levels=1)"
+	}
+	return "$expand=" + expand
+}
+
+// collectionQuery returns the query string used to get a collection.
+func (c *QueryClient) collectionQuery() string {
+	var params []string
+	if expand := c.expandQuery(); expand != "" {
+		params = append(params, expand)
+	}
+	if c.support.Filter && c.options.Filter != "" {
+		params = append(params, "$filter="+strings.ReplaceAll(url.QueryEscape(c.options.Filter), "+", "%20"))
+	}
+	if c.support.TopSkip && c.options.Top > 0 {
+		params = append(params, fmt.Sprintf("$top=%d", c.options.Top))
+	}
+	if c.support.TopSkip && c.options.Skip > 0 {
+		params = append(params, fmt.Sprintf("$skip=%d", c.options.Skip))
+	}
+	if c.support.Only && c.options.Only {
+		params = append(params, "only")
+	}
+	return strings.Join(params, "&")
+}
+
+// getCollection gets the first page of a collection applying the query
+// options.
+func (c *QueryClient) getCollection(uri string) (*Collection, error) {
+	c.resetMembers()
+
+	query := c.collectionQuery()
+	if query == "" {
+		return getCollection(c.Client, uri)
+	}
+
+	result, err := c.getCollectionPage(appendQuery(uri, query))
+	var cerr *Error
+	if errors.As(err, &cerr) &&
+		(cerr.HTTPReturnedStatusCode == http.StatusBadRequest ||
+			cerr.HTTPReturnedStatusCode == http.StatusNotImplemented) {
+		// Some services advertise query support they do not fully
+		// implement, use a plain request instead
+		return getCollection(c.Client, uri)
+	}
+	return result, err
+}
+
+// getCollectionPage gets a page of a collection and keeps the expanded
+// members.
+func (c *QueryClient) getCollectionPage(uri string) (*Collection, error) {
+	resp, err := c.Client.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		ODataID string            `json:"@odata.id"`
+		Members []json.RawMessage `json:"Members"`
+	}
+	err = json.Unmarshal(body, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Collection
+	if raw.Members == nil && raw.ODataID != "" && c.options.Only {
+		// The only parameter returned the single member itself
+		c.putMember(raw.ODataID, body)
+		result.ItemLinks = []string{raw.ODataID}
+		return &result, nil
+	}
+
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range raw.Members {
+		var properties map[string]json.RawMessage
+		if json.Unmarshal(member, &properties) != nil || len(properties) < 2 {
+			// Not expanded, only a reference to the member
+			continue
+		}
+
+		var id Link
+		if json.Unmarshal(member, &id) == nil && id != "" {
+			c.putMember(id.String(), member)
+		}
+	}
+
+	return &result, nil
+}
+
+// putMember keeps the body of an expanded member.
+func (c *QueryClient) putMember(uri string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.members[strings.TrimSuffix(uri, "/")] = body
+}
+
+// takeMember returns and forgets the body of an expanded member, so that
+// later requests get fresh data from the service.
+func (c *QueryClient) takeMember(uri string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := strings.TrimSuffix(uri, "/")
+	body, ok := c.members[key]
+	if ok {
+		delete(c.members, key)
+	}
+	return body, ok
+}
+
+// forgetMembers drops the expanded members of the given links that were not
+// requested.
+func (c *QueryClient) forgetMembers(links []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, link := range links {
+		delete(c.members, strings.TrimSuffix(link, "/"))
+	}
+}
+
+// resetMembers drops all the expanded members kept from earlier collections.
+func (c *QueryClient) resetMembers() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.members = make(map[string][]byte)
+}
+
+// memberResponse builds a response for an expanded member.
+func memberResponse(body []byte) *http.Response {
+	header := http.Header{}
+	var t struct {
+		ODataEtag string `json:"@odata.etag"`
+	}
+	if json.Unmarshal(body, &t) == nil && t.ODataEtag != "" {
+		header.Set("Etag", t.ODataEtag)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+// appendQuery adds query parameters to a URI.
+func appendQuery(uri, query string) string {
+	if strings.Contains(uri, "?") {
+		return uri + "&" + query
+	}
+	return uri + "?" + query
+}
@@ -0,0 +1,281 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var expandedCollectionBody = `{
+		"@odata.id": "/redfish/v1/Systems",
+		"Name": "Computer System Collection",
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Systems/1", "@odata.etag": "W/\"1\"", "Id": "1", "Name": "System 1"},
+			{"@odata.id": "/redfish/v1/Systems/2", "Id": "2", "Name": "System 2"}
+		]
+	}`
+
+// testResponse returns an http.Response with the given status, body and
+// headers.
+func testResponse(statusCode int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     header,
+	}
+}
+
+// TestQueryClientExpand tests getting members from an expanded collection.
+func TestQueryClientExpand(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, expandedCollectionBody, nil),
+				testResponse(http.StatusOK, `{"Id": "1"}`, nil),
+			},
+		},
+	}
+
+	client := NewQueryClient(testClient,
+		QueryOptions{Expand: true, Filter: "SystemType eq 'Physical'", Select: []string{"Name"}},
+		QuerySupport{ExpandNoLinks: true, ExpandLevels: true, Filter: true})
+
+	collection, err := GetCollection(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	if len(collection.ItemLinks) != 2 {
+		t.Errorf("Unexpected members: %v", collection.ItemLinks)
+	}
+
+	var entity Entity
+	err = entity.Get(client, "/redfish/v1/Systems/1", &entity)
+	if err != nil {
+		t.Fatalf("Error getting member: %s", err)
+	}
+
+	if entity.Name != "System 1" || entity.etag != `W/"1"` {
+		t.Errorf("Unexpected member: %#v", entity)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Expected a single request, got: %#v", calls)
+	}
+
+	expected := "/redfish/v1/Systems?$expand=.($levels=1)&$filter=SystemType%20eq%20%27Physical%27"
+	if calls[0].URL != expected {
+		t.Errorf("Unexpected collection URI: %s", calls[0].URL)
+	}
+
+	// Members are only served once from the expanded collection
+	_ = entity.Get(client, "/redfish/v1/Systems/1", &entity)
+	calls = testClient.CapturedCalls()
+	if len(calls) != 2 || calls[1].URL != "/redfish/v1/Systems/1" {
+		t.Errorf("Unexpected member request: %#v", calls)
+	}
+}
+
+// TestQueryClientMembersDropped tests that expanded members do not outlive
+// the listing that fetched them.
+func TestQueryClientMembersDropped(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, expandedCollectionBody, nil),
+				testResponse(http.StatusOK, `{"Id": "2"}`, nil),
+				testResponse(http.StatusOK, expandedCollectionBody, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Chassis", "Members": []}`, nil),
+				testResponse(http.StatusOK, `{"Id": "1"}`, nil),
+			},
+		},
+	}
+
+	client := NewQueryClient(testClient, QueryOptions{Expand: true}, QuerySupport{ExpandNoLinks: true})
+
+	// Only the first member is used by the listing
+	var entity Entity
+	err := CollectList(func(link string) {
+		if link == "/redfish/v1/Systems/1" {
+			_ = entity.Get(client, link, &entity)
+		}
+	}, client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error listing collection: %s", err)
+	}
+
+	_ = entity.Get(client, "/redfish/v1/Systems/2", &entity)
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 || calls[1].URL != "/redfish/v1/Systems/2" {
+		t.Fatalf("Left over member should be requested again: %#v", calls)
+	}
+
+	// Getting another collection drops the members of the previous one
+	_, err = GetCollection(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+	_, err = GetCollection(client, "/redfish/v1/Chassis")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	_ = entity.Get(client, "/redfish/v1/Systems/1", &entity)
+	calls = testClient.CapturedCalls()
+	if len(calls) != 5 || calls[4].URL != "/redfish/v1/Systems/1" {
+		t.Errorf("Stale member should be requested again: %#v", calls)
+	}
+}
+
+// TestQueryClientUnsupported tests falling back when the service does not
+// support the query options.
+func TestQueryClientUnsupported(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`, nil),
+			},
+		},
+	}
+
+	client := NewQueryClient(testClient, QueryOptions{Expand: true, Top: 5}, QuerySupport{})
+
+	collection, err := GetCollection(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	if len(collection.ItemLinks) != 1 || testClient.CapturedCalls()[0].URL != "/redfish/v1/Systems" {
+		t.Errorf("Unexpected request: %#v", testClient.CapturedCalls())
+	}
+}
+
+// TestQueryClientRejected tests falling back when the service rejects the
+// query it advertised.
+func TestQueryClientRejected(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusNotImplemented, "", nil),
+				testResponse(http.StatusOK, `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`, nil),
+			},
+		},
+	}
+
+	client := NewQueryClient(testClient, QueryOptions{Expand: true}, QuerySupport{ExpandAll: true})
+
+	collection, err := GetCollection(client, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting collection: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(collection.ItemLinks) != 1 || len(calls) != 2 || calls[0].URL != "/redfish/v1/Systems?$expand=*" {
+		t.Errorf("Unexpected requests: %#v", calls)
+	}
+
+}
+
+// TestQueryClientSelect tests that only explicit requests select properties.
+func TestQueryClientSelect(t *testing.T) {
+	testClient := &TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Name": "System 1", "AssetTag": ""}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Systems/1", "Name": "System 1"}`, nil),
+				testResponse(http.StatusOK, `{"Members": [{"@odata.id": "/redfish/v1/Systems/1", "Name": "System 1"}, {"@odata.id": "/redfish/v1/Systems/2"}]}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Systems/2", "Name": "System 2"}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Name": "System 1", "AssetTag": ""}`, nil),
+			},
+		},
+	}
+
+	client := NewQueryClient(testClient,
+		QueryOptions{Expand: true, Select: []string{"Name"}},
+		QuerySupport{ExpandAll: true, Select: true})
+
+	var entity Entity
+	if err := entity.Get(client, "/redfish/v1/Systems/1", &entity); err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+
+	resp, err := client.GetSelected("/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Error getting selected resource: %s", err)
+	}
+	resp.Body.Close()
+
+	members, err := client.GetSelectedMembers("/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("Error getting selected members: %s", err)
+	}
+	if len(members) != 2 || !strings.Contains(string(members[1]), "System 2") {
+		t.Errorf("Unexpected members: %s", members)
+	}
+
+	// Selected members are not used to answer later requests
+	if err := entity.Get(client, "/redfish/v1/Systems/1", &entity); err != nil {
+		t.Fatalf("Error getting resource: %s", err)
+	}
+
+	expected := []string{
+		"/redfish/v1/Systems/1",
+		"/redfish/v1/Systems/1?$select=Name",
+		"/redfish/v1/Systems?$expand=*&$select=Name",
+		"/redfish/v1/Systems/2?$select=Name",
+		"/redfish/v1/Systems/1",
+	}
+	calls := testClient.CapturedCalls()
+	if len(calls) != len(expected) {
+		t.Fatalf("Unexpected requests: %#v", calls)
+	}
+	for i, call := range calls {
+		if call.URL != expected[i] {
+			t.Errorf("Unexpected request %d: %s", i, call.URL)
+		}
+	}
+}
+
+// rawTestClient is a TestClient able to send raw payloads.
+type rawTestClient struct {
+	*TestClient
+	contentType string
+}
+
+func (c *rawTestClient) RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error) {
+	c.contentType = contentType
+	return testResponse(http.StatusAccepted, "", nil), nil
+}
+
+// TestQueryClientRawRequest tests forwarding raw payloads to the wrapped
+// client.
+func TestQueryClientRawRequest(t *testing.T) {
+	raw := &rawTestClient{TestClient: &TestClient{}}
+	client := NewQueryClient(raw, QueryOptions{Select: []string{"Name"}}, QuerySupport{Select: true})
+
+	resp, err := client.RunRawRequestWithHeaders(http.MethodPost, "/redfish/v1/UpdateService/upload",
+		strings.NewReader("image"), "application/octet-stream", nil)
+	if err != nil {
+		t.Fatalf("Error sending raw payload: %s", err)
+	}
+	resp.Body.Close()
+	if raw.contentType != "application/octet-stream" {
+		t.Errorf("Expected the request to be forwarded, got content type %q", raw.contentType)
+	}
+
+	client = NewQueryClient(&TestClient{}, QueryOptions{}, QuerySupport{})
+	if _, err := client.RunRawRequestWithHeaders(http.MethodPost, "/redfish/v1/UpdateService/upload",
+		strings.NewReader("image"), "application/octet-stream", nil); err == nil {
+		t.Error("Expected an error for a client unable to send raw payloads")
+	}
+}
@@ -0,0 +1,47 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package common
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestRetryAfter tests parsing the Retry-After header.
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, test := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if test.value != "" {
+			resp.Header.Set("Retry-After", test.value)
+		}
+		delay, ok := RetryAfter(resp)
+		if delay != test.delay || ok != test.ok {
+			t.Errorf("Unexpected delay for %q: %s %t", test.value, delay, ok)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if delay, ok := RetryAfter(resp); !ok || delay <= 58*time.Minute || delay > time.Hour {
+		t.Errorf("Unexpected delay for a future date: %s %t", delay, ok)
+	}
+
+	if _, ok := RetryAfter(nil); ok {
+		t.Error("Expected no delay without a response")
+	}
+}
@@ -138,7 +138,7 @@ func (c *TestClient) performAction(action, url string, payload interface{}, cust
 			return nil, err
 		}
 		defer resp.Body.Close()
-		return nil, ConstructError(resp.StatusCode, payload)
+		return nil, ConstructRequestError(action, url, resp.StatusCode, payload)
 	}
 	return resp, nil
 }
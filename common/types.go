@@ -5,6 +5,7 @@
 package common
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -30,6 +31,12 @@ type Client interface {
 	DeleteWithHeaders(url string, customHeaders map[string]string) (*http.Response, error)
 }
 
+// ContextGetter is implemented by clients able to tie a GET request to a
+// context other than their own.
+type ContextGetter interface {
+	GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error)
+}
+
 // Link is an OData link reference
 type Link string
 
@@ -661,6 +668,10 @@ type Error struct {
 	rawData []byte
 	// An integer that represents the status code returned by the API
 	HTTPReturnedStatusCode int `json:"-"`
+	// Method is the method of the request that failed, if known.
+	Method string `json:"-"`
+	// URL is the URL of the request that failed, if known.
+	URL string `json:"-"`
 	// A string indicating a specific MessageId from the message registry.
 	Code string `json:"code"`
 	// A human readable error message corresponding to the message in the message registry.
@@ -677,7 +688,6 @@ func (e *Error) Error() string {
 }
 
 // ErrExtendedInfo is for redfish ExtendedInfo error response
-// TODO: support RelatedProperties
 type ErrExtendedInfo struct {
 	// Indicating a specific error or message (not to be confused with the HTTP status code).
 	// This code can be used to access a detailed message from a message registry.
@@ -689,7 +699,14 @@ type ErrExtendedInfo struct {
 	// This shall be included in the response if a MessageId is specified for a parameterized message.
 	MessageArgs []string
 	// An optional string representing the severity of the error.
+	// This property has been deprecated in favor of MessageSeverity.
 	Severity string
+	// MessageSeverity is the severity of the error, using the values of the
+	// Health property of resource statuses.
+	MessageSeverity Health `json:",omitempty"`
+	// RelatedProperties is an optional array of JSON pointers indicating the
+	// properties described by the message.
+	RelatedProperties []string `json:",omitempty"`
 	// An optional string describing recommended action(s) to take to resolve the error.
 	Resolution string
 }
@@ -0,0 +1,432 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// CrawlOptions controls how Crawl walks a service.
+type CrawlOptions struct {
+	// Dir is the directory the resources are written to, in the layout of
+	// the DMTF Redfish mockups: each resource is written to the index.json
+	// file of the directory matching its URI, and its response headers to
+	// headers.json next to it.
+	Dir string
+	// MaxDepth limits how many links are followed from the service root.
+	// There is no limit if it is not positive.
+	MaxDepth int
+	// Include limits the crawl to the resources matching one of the given
+	// patterns, along with the resources leading to them. Patterns use the
+	// path.Match syntax and match the resources below the matching URI too,
+	// for example "/redfish/v1/Systems/*/Storage".
+	Include []string
+	// Exclude lists patterns of resources not to crawl, with the same syntax
+	// as Include. The resources below a matching URI are not crawled
+	// either.
+	Exclude []string
+	// MaxConcurrentRequests limits how many resources are fetched at the
+	// same time. Defaults to DefaultMaxConcurrentRequests.
+	MaxConcurrentRequests int
+	// Mask replaces serial numbers and MAC addresses with values derived
+	// from them, and removes credentials, so that the resources can be
+	// shared.
+	Mask bool
+}
+
+// CrawlResult describes the resources written by Crawl.
+type CrawlResult struct {
+	// Resources holds the URIs of the resources written, sorted.
+	Resources []string
+}
+
+// ignoredCrawlHeaders lists the response headers not recorded, because they
+// change with each response or hold credentials.
+var ignoredCrawlHeaders = map[string]bool{
+	"Connection":        true,
+	"Content-Length":    true,
+	"Date":              true,
+	"Keep-Alive":        true,
+	"Set-Cookie":        true,
+	"Transfer-Encoding": true,
+	"X-Auth-Token":      true,
+}
+
+// Crawl walks every resource reachable from the service root through
+// @odata.id links and writes them to a directory in the layout of the DMTF
+// Redfish mockups. The result can be served again with common.FileClient.
+//
+// Resources that cannot be fetched are skipped. The crawl goes on without
+// them and their errors are returned as a common.CollectionError along with
+// the result.
+func Crawl(c common.Client, options CrawlOptions) (*CrawlResult, error) { //nolint:gocritic
+	if options.Dir == "" {
+		return nil, fmt.Errorf("no directory given to write the resources to")
+	}
+
+	crawler := newCrawler(c, options)
+	crawler.store = crawler.write
+	crawler.run()
+
+	result := &CrawlResult{Resources: crawler.resources}
+	if crawler.collectionError.Empty() {
+		return result, nil
+	}
+	return result, crawler.collectionError
+}
+
+// crawler holds the state of a crawl.
+type crawler struct {
+	client  common.Client
+	options CrawlOptions
+	// store keeps a crawled resource.
+	store func(uri string, body map[string]interface{}, header http.Header) error
+
+	mu sync.Mutex
+	// visited records the URIs already crawled or about to be.
+	visited         map[string]bool
+	resources       []string
+	collectionError *common.CollectionError
+}
+
+// newCrawler creates a crawler.
+func newCrawler(c common.Client, options CrawlOptions) *crawler { //nolint:gocritic
+	return &crawler{
+		client:          c,
+		options:         options,
+		visited:         make(map[string]bool),
+		collectionError: common.NewCollectionError(),
+	}
+}
+
+// run crawls the resources reachable from the service root.
+func (crawler *crawler) run() {
+	root := crawlURI(common.DefaultServiceRoot)
+	crawler.visited[root] = true
+	level := []string{root}
+	for depth := 0; len(level) > 0; depth++ {
+		links := crawler.crawlLevel(level)
+		if crawler.options.MaxDepth > 0 && depth >= crawler.options.MaxDepth {
+			break
+		}
+		level = crawler.next(links)
+	}
+	sort.Strings(crawler.resources)
+}
+
+// crawlLevel fetches and writes the given resources, returning the links
+// they hold.
+func (crawler *crawler) crawlLevel(uris []string) []string {
+	limit := crawler.options.MaxConcurrentRequests
+	if limit <= 0 {
+		limit = DefaultMaxConcurrentRequests
+	}
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	var links []string
+	for _, uri := range uris {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(uri string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			found, err := crawler.crawl(uri)
+
+			crawler.mu.Lock()
+			defer crawler.mu.Unlock()
+			if err != nil {
+				crawler.collectionError.Failures[uri] = err
+				return
+			}
+			crawler.resources = append(crawler.resources, uri)
+			links = append(links, found...)
+		}(uri)
+	}
+	wg.Wait()
+
+	return links
+}
+
+// next returns the links to crawl at the next level.
+func (crawler *crawler) next(links []string) []string {
+	root := crawlURI(common.DefaultServiceRoot)
+	var result []string
+	for _, link := range links {
+		if !strings.HasPrefix(link, root+"/") || crawler.visited[link] || !crawler.allowed(link) {
+			continue
+		}
+		crawler.visited[link] = true
+		result = append(result, link)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// allowed checks a URI against the include and exclude patterns.
+func (crawler *crawler) allowed(uri string) bool {
+	for _, pattern := range crawler.options.Exclude {
+		if matchCrawlPattern(pattern, uri) {
+			return false
+		}
+	}
+
+	if len(crawler.options.Include) == 0 {
+		return true
+	}
+	for _, pattern := range crawler.options.Include {
+		if matchCrawlPattern(pattern, uri) || leadsToCrawlPattern(pattern, uri) {
+			return true
+		}
+	}
+	return false
+}
+
+// crawl fetches and stores a resource, returning the links it holds.
+func (crawler *crawler) crawl(uri string) ([]string, error) {
+	target := uri
+	if uri == crawlURI(common.DefaultServiceRoot) {
+		target = common.DefaultServiceRoot
+	}
+
+	body, header, err := crawler.fetch(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []string
+	collectLinks(body, &links)
+
+	if crawler.options.Mask {
+		maskValue(body)
+	}
+
+	return links, crawler.store(uri, body, header)
+}
+
+// fetch gets a resource, merging the pages of collections.
+func (crawler *crawler) fetch(uri string) (map[string]interface{}, http.Header, error) {
+	body, header, err := crawler.get(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		nextLink, ok := body["Members@odata.nextLink"].(string)
+		if !ok || nextLink == "" {
+			break
+		}
+		page, _, err := crawler.get(nextLink)
+		if err != nil {
+			return nil, nil, err
+		}
+		members, _ := body["Members"].([]interface{})
+		more, _ := page["Members"].([]interface{})
+		body["Members"] = append(members, more...)
+		if page["Members@odata.nextLink"] == nil {
+			delete(body, "Members@odata.nextLink")
+		} else {
+			body["Members@odata.nextLink"] = page["Members@odata.nextLink"]
+		}
+	}
+
+	return body, header, nil
+}
+
+// get gets a JSON object.
+func (crawler *crawler) get(uri string) (map[string]interface{}, http.Header, error) {
+	resp, err := crawler.client.Get(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	var body map[string]interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, nil, fmt.Errorf("resource %s: %w", uri, err)
+	}
+	if body == nil {
+		return nil, nil, fmt.Errorf("resource %s is not a JSON object", uri)
+	}
+
+	return body, resp.Header, nil
+}
+
+// write writes a resource and its headers.
+func (crawler *crawler) write(uri string, body map[string]interface{}, header http.Header) error {
+	dir := filepath.Join(crawler.options.Dir, filepath.FromSlash(strings.TrimPrefix(uri, "/")))
+	rel, err := filepath.Rel(filepath.Clean(crawler.options.Dir), dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return fmt.Errorf("resource %s is outside of the crawl directory", uri)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	b, err := marshalCrawled(body)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), b, 0o600); err != nil {
+		return err
+	}
+
+	recorded := make(map[string]string)
+	for key, values := range header {
+		if !ignoredCrawlHeaders[http.CanonicalHeaderKey(key)] && len(values) > 0 {
+			recorded[key] = strings.Join(values, ", ")
+		}
+	}
+	b, err = marshalCrawled(map[string]interface{}{http.MethodGet: recorded})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "headers.json"), b, 0o600)
+}
+
+// marshalCrawled encodes a crawled value as indented JSON.
+func marshalCrawled(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// collectLinks appends the resources a JSON value links to.
+func collectLinks(value interface{}, links *[]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if key == "@odata.id" {
+				if link, ok := item.(string); ok && link != "" {
+					*links = append(*links, crawlURI(link))
+				}
+				continue
+			}
+			collectLinks(item, links)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectLinks(item, links)
+		}
+	}
+}
+
+// crawlURI returns the URI of the resource a link points to, without its
+// fragment, query and trailing slash. Dot segments are resolved, so that the
+// links leaving the service root are recognized.
+func crawlURI(link string) string {
+	if i := strings.IndexAny(link, "?#"); i >= 0 {
+		link = link[:i]
+	}
+	return path.Clean(link)
+}
+
+// matchCrawlPattern checks whether a URI or one of its parents matches a
+// pattern.
+func matchCrawlPattern(pattern, uri string) bool {
+	pattern = crawlURI(pattern)
+	for {
+		if ok, _ := path.Match(pattern, uri); ok {
+			return true
+		}
+		i := strings.LastIndex(uri, "/")
+		if i <= 0 {
+			return false
+		}
+		uri = uri[:i]
+	}
+}
+
+// leadsToCrawlPattern checks whether a URI is a parent of the resources
+// matching a pattern.
+func leadsToCrawlPattern(pattern, uri string) bool {
+	patternSegments := strings.Split(crawlURI(pattern), "/")
+	uriSegments := strings.Split(uri, "/")
+	if len(uriSegments) >= len(patternSegments) {
+		return false
+	}
+	for i, segment := range uriSegments {
+		if ok, _ := path.Match(patternSegments[i], segment); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// macAddressPattern matches MAC addresses.
+var macAddressPattern = regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`)
+
+// maskValue replaces the serial numbers and MAC addresses found in a JSON
+// value and removes credentials.
+func maskValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			s, isString := item.(string)
+			switch {
+			case isCredential(key):
+				v[key] = nil
+			case isString && s != "" && strings.HasSuffix(key, "SerialNumber"):
+				v[key] = "SN" + strings.ToUpper(maskHash(s)[:10])
+			case isString && macAddressPattern.MatchString(s):
+				v[key] = maskMACAddress(s)
+			default:
+				maskValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if s, ok := item.(string); ok && macAddressPattern.MatchString(s) {
+				v[i] = maskMACAddress(s)
+				continue
+			}
+			maskValue(item)
+		}
+	}
+}
+
+// isCredential checks whether a property holds a credential.
+func isCredential(key string) bool {
+	switch key {
+	case "Password", "Token", "KerberosKeytab", "Passphrase", "PreSharedKey", "SecretKey":
+		return true
+	}
+	return strings.HasSuffix(key, "Password")
+}
+
+// maskMACAddress returns a locally administered MAC address derived from the
+// given one, so that distinct addresses remain distinct.
+func maskMACAddress(address string) string {
+	h := maskHash(strings.ToLower(strings.ReplaceAll(address, "-", ":")))
+	return fmt.Sprintf("02:%s:%s:%s:%s:%s", h[0:2], h[2:4], h[4:6], h[6:8], h[8:10])
+}
+
+// maskHash returns the hexadecimal SHA-256 hash of a value.
+func maskHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
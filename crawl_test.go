@@ -0,0 +1,303 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+	"github.com/bcohee/gofish/redfishtest"
+)
+
+func newCrawlServer(t *testing.T) *redfishtest.Server {
+	server, err := redfishtest.NewServer(map[string]interface{}{
+		"/redfish/v1": map[string]interface{}{
+			"Id":          "RootService",
+			"Systems":     map[string]interface{}{"@odata.id": "/redfish/v1/Systems"},
+			"JsonSchemas": map[string]interface{}{"@odata.id": "/redfish/v1/JsonSchemas"},
+			"Managers":    map[string]interface{}{"@odata.id": "/redfish/v1/Managers"},
+		},
+		"/redfish/v1/Systems": map[string]interface{}{
+			"Members":             []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/"}},
+			"Members@odata.count": 1,
+		},
+		"/redfish/v1/Systems/1": map[string]interface{}{
+			"Id":                 "1",
+			"SerialNumber":       "ABC123",
+			"EthernetInterfaces": map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces"},
+			"Links": map[string]interface{}{
+				"Chassis": []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Chassis/1"}},
+			},
+		},
+		"/redfish/v1/Systems/1/EthernetInterfaces": map[string]interface{}{
+			"Members":             []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"}},
+			"Members@odata.count": 1,
+		},
+		"/redfish/v1/Systems/1/EthernetInterfaces/1": map[string]interface{}{
+			"Id":                  "1",
+			"MACAddress":          "AA:BB:CC:DD:EE:FF",
+			"PermanentMACAddress": "aa:bb:cc:dd:ee:ff",
+			"Status":              map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1#/Status"},
+		},
+		"/redfish/v1/JsonSchemas": map[string]interface{}{
+			"Members": []interface{}{},
+		},
+		"/redfish/v1/Managers": map[string]interface{}{
+			"Members":             []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Managers/1"}},
+			"Members@odata.count": 1,
+		},
+		"/redfish/v1/Managers/1": map[string]interface{}{
+			"Id": "1",
+			"NetworkProtocol": map[string]interface{}{
+				"SNMP": map[string]interface{}{"CommunityStrings": []interface{}{}},
+			},
+			"LDAP": map[string]interface{}{
+				"Authentication": map[string]interface{}{"Username": "cn=admin", "Password": "secret"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	return server
+}
+
+func readCrawled(t *testing.T, dir, uri, name string) map[string]interface{} {
+	b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(uri, "/")), name))
+	if err != nil {
+		t.Fatalf("Error reading %s of %s: %s", name, uri, err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Error decoding %s of %s: %s", name, uri, err)
+	}
+	return result
+}
+
+// TestCrawl tests writing a service as a mockup.
+func TestCrawl(t *testing.T) {
+	server := newCrawlServer(t)
+	defer server.Close()
+	client, err := Connect(ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	dir := t.TempDir()
+	result, err := Crawl(client, CrawlOptions{
+		Dir:     dir,
+		Exclude: []string{"/redfish/v1/JsonSchemas"},
+		Mask:    true,
+	})
+
+	// The chassis the system links to does not exist
+	var collectionError *common.CollectionError
+	if !errors.As(err, &collectionError) || len(collectionError.Failures) != 1 ||
+		!errors.Is(collectionError.Failures["/redfish/v1/Chassis/1"], common.ErrNotFound) {
+		t.Errorf("Expected the missing chassis to fail, got: %v", err)
+	}
+
+	expected := []string{
+		"/redfish/v1",
+		"/redfish/v1/Managers",
+		"/redfish/v1/Managers/1",
+		"/redfish/v1/SessionService",
+		"/redfish/v1/SessionService/Sessions",
+		"/redfish/v1/Systems",
+		"/redfish/v1/Systems/1",
+		"/redfish/v1/Systems/1/EthernetInterfaces",
+		"/redfish/v1/Systems/1/EthernetInterfaces/1",
+	}
+	if !reflect.DeepEqual(result.Resources, expected) {
+		t.Errorf("Unexpected resources: %v", result.Resources)
+	}
+
+	system := readCrawled(t, dir, "/redfish/v1/Systems/1", "index.json")
+	if system["SerialNumber"] == "ABC123" || !strings.HasPrefix(system["SerialNumber"].(string), "SN") {
+		t.Errorf("Expected the serial number to be masked, got: %v", system["SerialNumber"])
+	}
+
+	ethernet := readCrawled(t, dir, "/redfish/v1/Systems/1/EthernetInterfaces/1", "index.json")
+	mac := ethernet["MACAddress"].(string)
+	if !strings.HasPrefix(mac, "02:") || mac != ethernet["PermanentMACAddress"] {
+		t.Errorf("Expected the MAC addresses to be masked the same way, got: %v %v", mac, ethernet["PermanentMACAddress"])
+	}
+
+	manager := readCrawled(t, dir, "/redfish/v1/Managers/1", "index.json")
+	authentication := manager["LDAP"].(map[string]interface{})["Authentication"].(map[string]interface{})
+	if authentication["Password"] != nil || authentication["Username"] != "cn=admin" {
+		t.Errorf("Expected the password to be removed, got: %v", authentication)
+	}
+
+	headers := readCrawled(t, dir, "/redfish/v1/Systems/1", "headers.json")["GET"].(map[string]interface{})
+	if headers["Etag"] == nil || headers["Allow"] != "GET, HEAD, PATCH, DELETE" || headers["Date"] != nil {
+		t.Errorf("Unexpected recorded headers: %v", headers)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "redfish", "v1", "JsonSchemas")); !os.IsNotExist(err) {
+		t.Errorf("Expected excluded resources not to be written: %v", err)
+	}
+
+	// The crawl can be used offline
+	files, err := common.NewFileClient(dir)
+	if err != nil {
+		t.Fatalf("Error creating file client: %s", err)
+	}
+	service, err := ServiceRoot(files)
+	if err != nil {
+		t.Fatalf("Error getting service root: %s", err)
+	}
+	systems, err := service.Systems()
+	if err != nil || len(systems) != 1 || systems[0].SerialNumber != system["SerialNumber"] {
+		t.Errorf("Unexpected systems from the crawl: %v %v", systems, err)
+	}
+}
+
+// TestCrawlFilters tests limiting the crawl.
+func TestCrawlFilters(t *testing.T) {
+	server := newCrawlServer(t)
+	defer server.Close()
+	client, err := Connect(ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	result, err := Crawl(client, CrawlOptions{Dir: t.TempDir(), MaxDepth: 1})
+	if err != nil {
+		t.Errorf("Error crawling: %s", err)
+	}
+	expected := []string{
+		"/redfish/v1",
+		"/redfish/v1/JsonSchemas",
+		"/redfish/v1/Managers",
+		"/redfish/v1/SessionService",
+		"/redfish/v1/SessionService/Sessions",
+		"/redfish/v1/Systems",
+	}
+	if !reflect.DeepEqual(result.Resources, expected) {
+		t.Errorf("Unexpected resources with a maximum depth: %v", result.Resources)
+	}
+
+	result, err = Crawl(client, CrawlOptions{
+		Dir:                   t.TempDir(),
+		Include:               []string{"/redfish/v1/Systems/*/EthernetInterfaces"},
+		MaxConcurrentRequests: 1,
+	})
+	if err != nil {
+		t.Errorf("Error crawling: %s", err)
+	}
+	expected = []string{
+		"/redfish/v1",
+		"/redfish/v1/Systems",
+		"/redfish/v1/Systems/1",
+		"/redfish/v1/Systems/1/EthernetInterfaces",
+		"/redfish/v1/Systems/1/EthernetInterfaces/1",
+	}
+	if !reflect.DeepEqual(result.Resources, expected) {
+		t.Errorf("Unexpected resources with an include filter: %v", result.Resources)
+	}
+
+	if _, err := Crawl(client, CrawlOptions{}); err == nil {
+		t.Error("Expected an error without a directory")
+	}
+}
+
+// TestCrawlDotSegments tests that links with dot segments cannot escape the
+// service root or the crawl directory.
+func TestCrawlDotSegments(t *testing.T) {
+	server, err := redfishtest.NewServer(map[string]interface{}{
+		"/redfish/v1": map[string]interface{}{
+			"Id":       "RootService",
+			"Systems":  map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1/.."},
+			"Escape":   map[string]interface{}{"@odata.id": "/redfish/v1/../../../escape"},
+			"Managers": map[string]interface{}{"@odata.id": "/redfish/v1/Systems/../../v1/../../escape"},
+		},
+		"/redfish/v1/Systems": map[string]interface{}{
+			"Members": []interface{}{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+	client, err := Connect(ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "mockup")
+	result, err := Crawl(client, CrawlOptions{Dir: dir, MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("Error crawling: %s", err)
+	}
+
+	expected := []string{
+		"/redfish/v1",
+		"/redfish/v1/SessionService",
+		"/redfish/v1/SessionService/Sessions",
+		"/redfish/v1/Systems",
+	}
+	if !reflect.DeepEqual(result.Resources, expected) {
+		t.Errorf("Unexpected resources: %v", result.Resources)
+	}
+
+	entries, err := os.ReadDir(parent)
+	if err != nil || len(entries) != 1 || entries[0].Name() != "mockup" {
+		t.Errorf("Expected nothing to be written outside of the crawl directory: %v %v", entries, err)
+	}
+
+	crawler := newCrawler(client, CrawlOptions{Dir: dir})
+	if err := crawler.write("/redfish/v1/../../../escape", map[string]interface{}{}, nil); err == nil {
+		t.Error("Expected writing outside of the crawl directory to fail")
+	}
+}
+
+// TestCrawlRelativeDir tests crawling into the current directory or one
+// relative to it.
+func TestCrawlRelativeDir(t *testing.T) {
+	server, err := redfishtest.NewServer(map[string]interface{}{
+		"/redfish/v1": map[string]interface{}{
+			"Id": "RootService",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+	client, err := Connect(ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting working directory: %s", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Error changing directory: %s", err)
+	}
+	defer os.Chdir(wd) //nolint:errcheck
+
+	for _, dir := range []string{".", "mockup", "./nested/../mockup2"} {
+		if _, err := Crawl(client, CrawlOptions{Dir: dir, MaxDepth: 1}); err != nil {
+			t.Fatalf("Error crawling into %s: %s", dir, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "redfish", "v1", "index.json")); err != nil {
+			t.Errorf("Service root was not written into %s: %s", dir, err)
+		}
+
+		crawler := newCrawler(client, CrawlOptions{Dir: dir})
+		if err := crawler.write("/redfish/v1/../../../escape", map[string]interface{}{}, nil); err == nil {
+			t.Errorf("Expected writing outside of %s to fail", dir)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"sort"
+)
+
+// multipartBody streams a multipart/form-data payload without buffering the
+// content of its parts. It can be rewound for retries when all parts are
+// seekable.
+type multipartBody struct {
+	// segments alternates the encoded part headers and boundaries with the
+	// readers holding the part content.
+	segments []io.Reader
+	// offsets holds the initial position of seekable part readers.
+	offsets map[io.Seeker]int64
+	// size is the total length of the payload, or -1 if it is unknown.
+	size int64
+	// contentType is the Content-Type header value, including the boundary.
+	contentType string
+
+	reader io.Reader
+}
+
+// namedReader is implemented by readers sent as file parts, such as os.File.
+type namedReader interface {
+	io.Reader
+	Name() string
+}
+
+// newMultipartBody prepares the multipart payload. Fields are sent before
+// files and in name order, since some services expect the parameters to come
+// before the content they apply to.
+func newMultipartBody(payload map[string]io.Reader) (*multipartBody, error) {
+	var fields, files []string
+	for key, reader := range payload {
+		if _, ok := reader.(namedReader); ok {
+			files = append(files, key)
+		} else {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	sort.Strings(files)
+
+	body := &multipartBody{offsets: make(map[io.Seeker]int64)}
+	var header bytes.Buffer
+	writer := multipart.NewWriter(&header)
+
+	for _, key := range append(fields, files...) {
+		reader := payload[key]
+		var err error
+		if file, ok := reader.(namedReader); ok {
+			_, err = writer.CreateFormFile(key, filepath.Base(file.Name()))
+		} else {
+			_, err = createFormField(key, writer)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := body.track(reader)
+		if err != nil {
+			return nil, err
+		}
+		body.addSegment(&header, int64(header.Len()))
+		body.segments = append(body.segments, reader)
+		body.addSize(size)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	body.addSegment(&header, int64(header.Len()))
+
+	body.contentType = writer.FormDataContentType()
+	body.reader = io.MultiReader(body.segments...)
+	return body, nil
+}
+
+// addSegment moves the encoded headers and boundaries into a segment.
+func (body *multipartBody) addSegment(header *bytes.Buffer, size int64) {
+	body.segments = append(body.segments, bytes.NewReader(append([]byte(nil), header.Bytes()...)))
+	body.addSize(size)
+	header.Reset()
+}
+
+// addSize accounts for a segment in the total size.
+func (body *multipartBody) addSize(size int64) {
+	if body.size < 0 || size < 0 {
+		body.size = -1
+		return
+	}
+	body.size += size
+}
+
+// track records the initial position of seekable readers and returns how
+// much content is left to read, or -1 if it cannot be known.
+func (body *multipartBody) track(reader io.Reader) (int64, error) {
+	switch r := reader.(type) {
+	case io.Seeker:
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1, nil
+		}
+		end, err := r.Seek(0, io.SeekEnd)
+		if err != nil {
+			return -1, err
+		}
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return -1, err
+		}
+		body.offsets[r] = offset
+		return end - offset, nil
+	case interface{ Len() int }:
+		return int64(r.Len()), nil
+	}
+	return -1, nil
+}
+
+// Read reads the next bytes of the payload.
+func (body *multipartBody) Read(p []byte) (int, error) {
+	return body.reader.Read(p)
+}
+
+// Seek rewinds the payload. Only seeking to the start is supported, and only
+// if all parts are seekable.
+func (body *multipartBody) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekStart {
+		return 0, fmt.Errorf("multipart payload can only be rewound to the start")
+	}
+
+	for _, segment := range body.segments {
+		seeker, ok := segment.(io.Seeker)
+		if !ok {
+			return 0, fmt.Errorf("multipart payload contains a part that cannot be rewound")
+		}
+		if _, err := seeker.Seek(body.offsets[seeker], io.SeekStart); err != nil {
+			return 0, err
+		}
+	}
+
+	body.reader = io.MultiReader(body.segments...)
+	return 0, nil
+}
+
+// Size returns the length of the payload, or -1 if it is unknown.
+func (body *multipartBody) Size() int64 {
+	return body.size
+}
@@ -0,0 +1,131 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// TestMultipartBody tests streaming and rewinding multipart payloads.
+func TestMultipartBody(t *testing.T) {
+	image := filepath.Join(t.TempDir(), "firmware.bin")
+	if err := os.WriteFile(image, []byte("firmware image content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(image)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	body, err := newMultipartBody(map[string]io.Reader{
+		"UpdateFile":       file,
+		"UpdateParameters": strings.NewReader(`{"Targets": []}`),
+	})
+	if err != nil {
+		t.Fatalf("Error creating payload: %s", err)
+	}
+
+	first, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if int64(len(first)) != body.Size() {
+		t.Errorf("Size %d does not match payload length %d", body.Size(), len(first))
+	}
+
+	if _, err = body.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Error rewinding payload: %s", err)
+	}
+	second, _ := io.ReadAll(body)
+	if !bytes.Equal(first, second) {
+		t.Error("Rewound payload differs from the original")
+	}
+
+	_, params, _ := mime.ParseMediaType(body.contentType)
+	reader := multipart.NewReader(bytes.NewReader(first), params["boundary"])
+
+	part, err := reader.NextPart()
+	if err != nil || part.FormName() != "UpdateParameters" || part.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Parameters should be sent first: %v %v", part, err)
+	}
+
+	part, err = reader.NextPart()
+	if err != nil || part.FormName() != "UpdateFile" || part.FileName() != "firmware.bin" {
+		t.Fatalf("Unexpected file part: %v %v", part, err)
+	}
+	content, _ := io.ReadAll(part)
+	if string(content) != "firmware image content" {
+		t.Errorf("Unexpected file content: %s", content)
+	}
+}
+
+// TestPostMultipartStream tests sending a payload that cannot be measured.
+func TestPostMultipartStream(t *testing.T) {
+	var contentLength int64
+	var received string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == common.DefaultServiceRoot {
+			w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+			return
+		}
+
+		contentLength = r.ContentLength
+		file, _, err := r.FormFile("UpdateFile")
+		if err == nil {
+			content, _ := io.ReadAll(file)
+			received = string(content)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		pipeWriter.Write([]byte("streamed image")) //nolint
+		pipeWriter.Close()
+	}()
+
+	resp, err := client.PostMultipart("/redfish/v1/UpdateService/upload", map[string]io.Reader{
+		"UpdateFile": &namedPipe{PipeReader: pipeReader},
+	})
+	if err != nil {
+		t.Fatalf("Error posting payload: %s", err)
+	}
+	resp.Body.Close()
+
+	if received != "streamed image" {
+		t.Errorf("Unexpected file content: %s", received)
+	}
+
+	if contentLength != -1 {
+		t.Errorf("Payload of unknown length should be chunked, got length %d", contentLength)
+	}
+}
+
+type namedPipe struct {
+	*io.PipeReader
+}
+
+func (namedPipe) Name() string {
+	return "image.bin"
+}
@@ -5,8 +5,14 @@
 package redfish
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"sort"
+	"strconv"
 
 	"github.com/bcohee/gofish/common"
 )
@@ -195,16 +201,16 @@ type AccountService struct {
 	// accounts shall contain a link to a Resource Collection of type
 	// ManagerAccountCollection.
 	accounts string
-	// activeDirectory shall contain the first Active
+	// ActiveDirectory shall contain the first Active
 	// Directory external account provider that this Account Service
 	// supports.  If the Account Service supports one or more Active
 	// Directory services as an external account provider, this entity shall
 	// be populated by default.  This entity shall not be present in the
 	// AdditionalExternalAccountProviders Resource Collection.
-	// activeDirectory string
+	ActiveDirectory ExternalAccountProvider
 	// additionalExternalAccountProviders shall contain the
 	// additional external account providers that this Account Service uses.
-	// additionalExternalAccountProviders string
+	additionalExternalAccountProviders string
 	// AuthFailureLoggingThreshold shall contain the
 	// threshold for when an authorization failure is logged.  This value
 	// represents a modulo function.  The failure shall be logged every `n`th
@@ -261,7 +267,8 @@ func (accountservice *AccountService) UnmarshalJSON(b []byte) error {
 	}
 	var t struct {
 		temp
-		Links AccountLinks
+		Links                              AccountLinks
+		AdditionalExternalAccountProviders common.Link
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -278,6 +285,7 @@ func (accountservice *AccountService) UnmarshalJSON(b []byte) error {
 	// Extract the links to other entities for later
 	accountservice.accounts = t.Links.Accounts.String()
 	accountservice.roles = t.Links.Roles.String()
+	accountservice.additionalExternalAccountProviders = t.AdditionalExternalAccountProviders.String()
 
 	// This is a read/write object, so we need to save the raw object data for later
 	accountservice.rawData = b
@@ -304,6 +312,8 @@ func (accountservice *AccountService) Update() error {
 		"LocalAccountAuth",
 		"ServiceEnabled",
 	}
+	readWriteFields = append(readWriteFields, externalAccountProviderFields("ActiveDirectory.")...)
+	readWriteFields = append(readWriteFields, externalAccountProviderFields("LDAP.")...)
 
 	originalElement := reflect.ValueOf(original).Elem()
 	currentElement := reflect.ValueOf(accountservice).Elem()
@@ -311,6 +321,21 @@ func (accountservice *AccountService) Update() error {
 	return accountservice.Entity.Update(originalElement, currentElement, readWriteFields)
 }
 
+// externalAccountProviderFields returns the read/write properties of an
+// external account provider, prefixed with the path of the provider.
+func externalAccountProviderFields(prefix string) []string {
+	return []string{
+		prefix + "Authentication.AuthenticationType",
+		prefix + "Authentication.KerberosKeytab",
+		prefix + "Authentication.Password",
+		prefix + "Authentication.Token",
+		prefix + "Authentication.Username",
+		prefix + "RemoteRoleMapping",
+		prefix + "ServiceAddresses",
+		prefix + "ServiceEnabled",
+	}
+}
+
 // GetAccountService will get the AccountService instance from the Redfish
 // service.
 func GetAccountService(c common.Client, uri string) (*AccountService, error) {
@@ -327,3 +352,151 @@ func (accountservice *AccountService) Accounts() ([]*ManagerAccount, error) {
 func (accountservice *AccountService) Roles() ([]*Role, error) {
 	return ListReferencedRoles(accountservice.Client, accountservice.roles)
 }
+
+// AdditionalExternalAccountProviders gets the external account providers
+// of the account service other than the LDAP and ActiveDirectory ones.
+func (accountservice *AccountService) AdditionalExternalAccountProviders() ([]*AdditionalExternalAccountProvider, error) {
+	return ListReferencedAdditionalExternalAccountProviders(accountservice.Client, accountservice.additionalExternalAccountProviders)
+}
+
+// isUnsupportedOperation checks whether the service rejected a request
+// because it does not implement the operation.
+func isUnsupportedOperation(err error) bool {
+	return errors.Is(err, common.ErrActionNotSupported)
+}
+
+// CreateAccount creates a new enabled account with the given credentials and
+// role. The account is POSTed to the accounts collection, and looked up by
+// user name if the service returns neither its location nor its body.
+// Services that pre-allocate a fixed set of account slots reject that, in
+// which case the first free slot is configured instead.
+func (accountservice *AccountService) CreateAccount(userName, password, roleID string) (*ManagerAccount, error) {
+	if userName == "" {
+		return nil, fmt.Errorf("user name must be supplied")
+	}
+
+	t := struct {
+		UserName string
+		Password string
+		RoleID   string `json:"RoleId"`
+		Enabled  bool
+	}{
+		UserName: userName,
+		Password: password,
+		RoleID:   roleID,
+		Enabled:  true,
+	}
+
+	resp, err := accountservice.Client.Post(accountservice.accounts, t)
+	if err == nil {
+		defer resp.Body.Close()
+		if location := resp.Header.Get("Location"); location != "" {
+			return GetManagerAccount(accountservice.Client, location)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			// Neither a Location nor the account, look it up by name
+			return accountservice.Account(userName)
+		}
+
+		var account ManagerAccount
+		err = json.Unmarshal(body, &account)
+		if err != nil {
+			return nil, err
+		}
+		account.SetClient(accountservice.Client)
+		return &account, nil
+	}
+
+	if !isUnsupportedOperation(err) {
+		return nil, err
+	}
+
+	return accountservice.createAccountInSlot(t)
+}
+
+// createAccountInSlot configures the first free account slot that the
+// service allows to be used.
+func (accountservice *AccountService) createAccountInSlot(payload interface{}) (*ManagerAccount, error) {
+	accounts, err := accountservice.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	// Slots are tried in order as some services reserve the first ones
+	sort.Slice(accounts, func(i, j int) bool {
+		return accountSlotLess(accounts[i].ID, accounts[j].ID)
+	})
+
+	var lastErr error
+	for _, account := range accounts {
+		if account.UserName != "" {
+			continue
+		}
+
+		lastErr = account.Patch(account.ODataID, payload)
+		if lastErr == nil {
+			return GetManagerAccount(accountservice.Client, account.ODataID)
+		}
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("no free account slot available")
+}
+
+// accountSlotLess orders account IDs numerically when possible.
+func accountSlotLess(a, b string) bool {
+	ai, aErr := strconv.Atoi(a)
+	bi, bErr := strconv.Atoi(b)
+	if aErr == nil && bErr == nil {
+		return ai < bi
+	}
+	return a < b
+}
+
+// Account gets the account with the given user name.
+func (accountservice *AccountService) Account(userName string) (*ManagerAccount, error) {
+	accounts, err := accountservice.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.UserName == userName {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account %s not found", userName)
+}
+
+// DeleteAccount deletes the account with the given user name. Services that
+// pre-allocate a fixed set of account slots do not allow deleting them, in
+// which case the slot is cleared and disabled instead.
+func (accountservice *AccountService) DeleteAccount(userName string) error {
+	account, err := accountservice.Account(userName)
+	if err != nil {
+		return err
+	}
+
+	resp, err := accountservice.Client.Delete(account.ODataID)
+	if err == nil {
+		return resp.Body.Close()
+	}
+
+	if !isUnsupportedOperation(err) {
+		return err
+	}
+
+	t := struct {
+		UserName string
+		Enabled  bool
+	}{}
+	return account.Patch(account.ODataID, t)
+}
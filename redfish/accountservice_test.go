@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -97,3 +98,204 @@ func TestAccountServiceUpdate(t *testing.T) {
 		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestAccountServiceUpdateActiveDirectory tests updating the Active Directory
+// settings of the account service.
+func TestAccountServiceUpdateActiveDirectory(t *testing.T) {
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(accountServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.ActiveDirectory.ServiceEnabled = true
+	result.ActiveDirectory.ServiceAddresses = []string{"ad.example.com"}
+	result.ActiveDirectory.Authentication.Username = "admin"
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if len(calls) != 1 {
+		t.Fatalf("Expected one call to be made, captured: %v", calls)
+	}
+
+	if !strings.Contains(calls[0].Payload, "ActiveDirectory:map[Authentication:map[Username:admin] ServiceAddresses:[ad.example.com] ServiceEnabled:true]") {
+		t.Errorf("Unexpected update payload: %s", calls[0].Payload)
+	}
+
+	result.ActiveDirectory.PasswordSet = true
+	err = result.Update()
+
+	if err == nil || err.Error() != "ActiveDirectory.PasswordSet field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+}
+
+var accountsCollectionBody = `{
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/AccountService/Accounts/1"},
+			{"@odata.id": "/redfish/v1/AccountService/Accounts/2"}
+		]
+	}`
+
+// TestAccountServiceCreateAccount tests creating an account with POST.
+func TestAccountServiceCreateAccount(t *testing.T) {
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(accountServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusCreated, "",
+					http.Header{"Location": []string{"/redfish/v1/AccountService/Accounts/3"}}),
+			},
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/3", "Id": "3", "UserName": "operator"}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	account, err := result.CreateAccount("operator", "password", "Operator")
+	if err != nil {
+		t.Fatalf("Error creating account: %s", err)
+	}
+
+	if account.ID != "3" {
+		t.Errorf("Unexpected account: %#v", account)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/AccountService/Accounts" ||
+		!strings.Contains(calls[0].Payload, "RoleId:Operator") ||
+		!strings.Contains(calls[0].Payload, "Enabled:true") {
+		t.Errorf("Unexpected create request: %#v", calls[0])
+	}
+}
+
+// TestAccountServiceCreateAccountNoContent tests creating an account when the
+// service returns neither its location nor its body.
+func TestAccountServiceCreateAccountNoContent(t *testing.T) {
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(accountServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusNoContent, "", nil),
+			},
+			http.MethodGet: {
+				testResponse(http.StatusOK, accountsCollectionBody, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/1", "Id": "1", "UserName": "root"}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/2", "Id": "2", "UserName": "operator"}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	account, err := result.CreateAccount("operator", "password", "Operator")
+	if err != nil {
+		t.Fatalf("Error creating account: %s", err)
+	}
+
+	if account.ID != "2" || account.UserName != "operator" {
+		t.Errorf("Unexpected account: %#v", account)
+	}
+}
+
+// TestAccountServiceCreateAccountSlot tests creating an account on services
+// with fixed account slots.
+func TestAccountServiceCreateAccountSlot(t *testing.T) {
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(accountServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusMethodNotAllowed, "", nil),
+			},
+			http.MethodGet: {
+				testResponse(http.StatusOK, accountsCollectionBody, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/1", "Id": "1", "UserName": "root"}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/2", "Id": "2", "UserName": ""}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/2", "Id": "2", "UserName": "operator"}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	account, err := result.CreateAccount("operator", "password", "Operator")
+	if err != nil {
+		t.Fatalf("Error creating account: %s", err)
+	}
+
+	if account.ID != "2" || account.UserName != "operator" {
+		t.Errorf("Unexpected account: %#v", account)
+	}
+
+	var patch *common.TestAPICall
+	for _, call := range testClient.CapturedCalls() {
+		if call.Action == http.MethodPatch {
+			call := call
+			patch = &call
+		}
+	}
+	if patch == nil || patch.URL != "/redfish/v1/AccountService/Accounts/2" ||
+		!strings.Contains(patch.Payload, "UserName:operator") {
+		t.Errorf("Expected the free slot to be configured, got: %#v", patch)
+	}
+}
+
+// TestAccountServiceDeleteAccountSlot tests clearing an account slot when
+// the service does not allow deleting accounts.
+func TestAccountServiceDeleteAccountSlot(t *testing.T) {
+	var result AccountService
+	err := json.NewDecoder(strings.NewReader(accountServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodDelete: {
+				testResponse(http.StatusMethodNotAllowed, "", nil),
+			},
+			http.MethodGet: {
+				testResponse(http.StatusOK, accountsCollectionBody, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/1", "Id": "1", "UserName": "root"}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/AccountService/Accounts/2", "Id": "2", "UserName": "operator"}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	err = result.DeleteAccount("operator")
+	if err != nil {
+		t.Fatalf("Error deleting account: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	last := calls[len(calls)-1]
+	if last.Action != http.MethodPatch || last.URL != "/redfish/v1/AccountService/Accounts/2" ||
+		!strings.Contains(last.Payload, "Enabled:false") || !strings.Contains(last.Payload, "UserName:") {
+		t.Errorf("Expected the slot to be cleared, got: %#v", last)
+	}
+}
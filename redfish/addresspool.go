@@ -0,0 +1,174 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// AddressPoolGenZ shall contain Gen-Z related properties for an address
+// pool.
+type AddressPoolGenZ struct {
+	// AccessKey shall contain the Gen-Z Core Specification-defined 6-bit
+	// Access Key for the address pool.
+	AccessKey string
+	// MaxCID shall contain the maximum value for the Gen-Z Core
+	// Specification-defined Component Identifier (CID).
+	MaxCID int
+	// MaxSID shall contain the maximum value for the Gen-Z Core
+	// Specification-defined Subnet Identifier (SID).
+	MaxSID int
+	// MinCID shall contain the minimum value for the Gen-Z Core
+	// Specification-defined Component Identifier (CID).
+	MinCID int
+	// MinSID shall contain the minimum value for the Gen-Z Core
+	// Specification-defined Subnet Identifier (SID).
+	MinSID int
+}
+
+// AddressPool shall represent an address pool in a Redfish implementation.
+type AddressPool struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Ethernet shall contain the Ethernet related properties for this
+	// address pool, such as the IPv4 and ASN ranges and the BGP settings.
+	Ethernet json.RawMessage
+	// GenZ shall contain the Gen-Z related properties for this address pool.
+	GenZ AddressPoolGenZ
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// endpoints are the endpoints associated with this address pool.
+	endpoints []string
+	// zones are the zones associated with this address pool.
+	zones []string
+}
+
+// UnmarshalJSON unmarshals a AddressPool object from the raw JSON.
+func (addresspool *AddressPool) UnmarshalJSON(b []byte) error {
+	type temp AddressPool
+	type links struct {
+		Endpoints common.Links
+		Zones     common.Links
+	}
+	var t struct {
+		temp
+		Links links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*addresspool = AddressPool(t.temp)
+
+	// Extract the links to other entities for later
+	addresspool.endpoints = t.Links.Endpoints.ToStrings()
+	addresspool.zones = t.Links.Zones.ToStrings()
+
+	return nil
+}
+
+// Endpoints gets the endpoints associated with this address pool.
+func (addresspool *AddressPool) Endpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range addresspool.endpoints {
+		item, err := GetEndpoint(addresspool.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Zones gets the zones associated with this address pool.
+func (addresspool *AddressPool) Zones() ([]*Zone, error) {
+	var result []*Zone
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range addresspool.zones {
+		item, err := GetZone(addresspool.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// GetAddressPool will get a AddressPool instance from the service.
+func GetAddressPool(c common.Client, uri string) (*AddressPool, error) {
+	var addressPool AddressPool
+	return &addressPool, addressPool.Get(c, uri, &addressPool)
+}
+
+// ListReferencedAddressPools gets the collection of AddressPool from
+// a provided reference.
+func ListReferencedAddressPools(c common.Client, link string) ([]*AddressPool, error) { //nolint:dupl
+	var result []*AddressPool
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *AddressPool
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		addresspool, err := GetAddressPool(c, link)
+		ch <- GetResult{Item: addresspool, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,63 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var addressPoolBody = `{
+		"@odata.id": "/redfish/v1/Fabrics/GenZ/AddressPools/1",
+		"@odata.type": "#AddressPool.v1_2_1.AddressPool",
+		"Id": "1",
+		"Name": "Gen-Z Address Pool 1",
+		"GenZ": {
+			"MinCID": 1,
+			"MaxCID": 4096,
+			"MinSID": 1,
+			"MaxSID": 32,
+			"AccessKey": "0x1A"
+		},
+		"Links": {
+			"Endpoints": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/GenZ/Endpoints/1"
+				}
+			],
+			"Zones": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/GenZ/Zones/1"
+				}
+			]
+		}
+	}`
+
+// TestAddressPool tests the parsing of AddressPool objects.
+func TestAddressPool(t *testing.T) {
+	var result AddressPool
+	err := json.NewDecoder(strings.NewReader(addressPoolBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.GenZ.MaxCID != 4096 {
+		t.Errorf("Invalid GenZ MaxCID: %d", result.GenZ.MaxCID)
+	}
+
+	if result.endpoints[0] != "/redfish/v1/Fabrics/GenZ/Endpoints/1" {
+		t.Errorf("Invalid Endpoints links: %v", result.endpoints)
+	}
+
+	if result.zones[0] != "/redfish/v1/Fabrics/GenZ/Zones/1" {
+		t.Errorf("Invalid Zones links: %v", result.zones)
+	}
+}
@@ -7,6 +7,7 @@ package redfish
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/bcohee/gofish/common"
@@ -197,7 +198,28 @@ func (bios *Bios) AllowedAttributeUpdateApplyTimes() []common.ApplyTime {
 }
 
 // UpdateBiosAttributesApplyAt is used to update attribute values and set apply time together
-func (bios *Bios) UpdateBiosAttributesApplyAt(attrs SettingsAttributes, applyTime common.ApplyTime) error { //nolint:dupl
+func (bios *Bios) UpdateBiosAttributesApplyAt(attrs SettingsAttributes, applyTime common.ApplyTime) error {
+	resp, err := bios.updateBiosAttributes(attrs, applyTime)
+	if err != nil || resp == nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// UpdateBiosAttributesApplyAtWithTask is used to update attribute values and
+// set apply time together. It returns a TaskMonitor to follow the update
+// when the service applies it asynchronously.
+func (bios *Bios) UpdateBiosAttributesApplyAtWithTask(attrs SettingsAttributes, applyTime common.ApplyTime) (*TaskMonitor, error) {
+	resp, err := bios.updateBiosAttributes(attrs, applyTime)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskMonitor(bios.Client, resp)
+}
+
+// updateBiosAttributes sends the changed attributes to the settings object.
+// The response is nil if there was nothing to update.
+func (bios *Bios) updateBiosAttributes(attrs SettingsAttributes, applyTime common.ApplyTime) (*http.Response, error) { //nolint:dupl
 	payload := make(map[string]interface{})
 
 	// Get a representation of the object's original state so we can find what
@@ -205,7 +227,7 @@ func (bios *Bios) UpdateBiosAttributesApplyAt(attrs SettingsAttributes, applyTim
 	original := new(Bios)
 	err := original.UnmarshalJSON(bios.rawData)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for key := range attrs {
@@ -217,31 +239,27 @@ func (bios *Bios) UpdateBiosAttributesApplyAt(attrs SettingsAttributes, applyTim
 
 	resp, err := bios.Client.Get(bios.settingsTarget)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
+	resp.Body.Close()
 
 	// If there are any allowed updates, try to send updates to the system and
 	// return the result.
-	if len(payload) > 0 {
-		data := map[string]interface{}{"Attributes": payload}
-		if applyTime != "" {
-			data["@Redfish.SettingsApplyTime"] = map[string]string{"ApplyTime": string(applyTime)}
-		}
+	if len(payload) == 0 {
+		return nil, nil
+	}
 
-		var header = make(map[string]string)
-		if resp.Header["Etag"] != nil {
-			header["If-Match"] = resp.Header["Etag"][0]
-		}
+	data := map[string]interface{}{"Attributes": payload}
+	if applyTime != "" {
+		data["@Redfish.SettingsApplyTime"] = map[string]string{"ApplyTime": string(applyTime)}
+	}
 
-		resp, err = bios.Client.PatchWithHeaders(bios.settingsTarget, data, header)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+	var header = make(map[string]string)
+	if resp.Header["Etag"] != nil {
+		header["If-Match"] = resp.Header["Etag"][0]
 	}
 
-	return nil
+	return bios.Client.PatchWithHeaders(bios.settingsTarget, data, header)
 }
 
 // UpdateBiosAttributes is used to update attribute values.
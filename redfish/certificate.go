@@ -0,0 +1,274 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// CertificateType is the format of a certificate.
+type CertificateType string
+
+const (
+	// PEMCertificateType shall indicate the format of the certificate shall
+	// be a Privacy Enhanced Mail (PEM)-encoded string, containing RFC5280
+	// defined structures.
+	PEMCertificateType CertificateType = "PEM"
+	// PEMchainCertificateType shall indicate the format of the certificate
+	// shall be a Privacy Enhanced Mail (PEM)-encoded string, containing
+	// RFC5280 defined structures, which represent a certificate chain.
+	PEMchainCertificateType CertificateType = "PEMchain"
+	// PKCS7CertificateType shall indicate the format of the certificate
+	// shall be a Privacy Enhanced Mail (PEM)-encoded string, containing
+	// RFC2315 defined structures.
+	PKCS7CertificateType CertificateType = "PKCS7"
+)
+
+// CertificateUsageType is the usage of a certificate.
+type CertificateUsageType string
+
+const (
+	// UserCertificateUsageType This certificate is a user certificate like
+	// those associated with a manager account.
+	UserCertificateUsageType CertificateUsageType = "User"
+	// WebCertificateUsageType This certificate is a web or HTTPS certificate
+	// like those used for event destinations.
+	WebCertificateUsageType CertificateUsageType = "Web"
+	// SSHCertificateUsageType This certificate is used for SSH.
+	SSHCertificateUsageType CertificateUsageType = "SSH"
+	// DeviceCertificateUsageType This certificate is a device type
+	// certificate like those associated with SPDM and other standards.
+	DeviceCertificateUsageType CertificateUsageType = "Device"
+	// PlatformCertificateUsageType This certificate is a platform type
+	// certificate like those associated with SPDM and other standards.
+	PlatformCertificateUsageType CertificateUsageType = "Platform"
+	// BIOSCertificateUsageType This certificate is a BIOS certificate like
+	// those associated with UEFI.
+	BIOSCertificateUsageType CertificateUsageType = "BIOS"
+)
+
+// KeyUsage is the usages of a key contained within a certificate.
+type KeyUsage string
+
+const (
+	// DigitalSignatureKeyUsage Verifies digital signatures, other than
+	// signatures on certificates and CRLs.
+	DigitalSignatureKeyUsage KeyUsage = "DigitalSignature"
+	// NonRepudiationKeyUsage Verifies digital signatures, other than
+	// signatures on certificates and CRLs, and provides a non-repudiation
+	// service that protects against the signing entity falsely denying some
+	// action.
+	NonRepudiationKeyUsage KeyUsage = "NonRepudiation"
+	// KeyEnciphermentKeyUsage Enciphers private or secret keys.
+	KeyEnciphermentKeyUsage KeyUsage = "KeyEncipherment"
+	// DataEnciphermentKeyUsage Directly enciphers raw user data without an
+	// intervening symmetric cipher.
+	DataEnciphermentKeyUsage KeyUsage = "DataEncipherment"
+	// KeyAgreementKeyUsage Key agreement.
+	KeyAgreementKeyUsage KeyUsage = "KeyAgreement"
+	// KeyCertSignKeyUsage Verifies signatures on public key certificates.
+	KeyCertSignKeyUsage KeyUsage = "KeyCertSign"
+	// CRLSigningKeyUsage Verifies signatures on certificate revocation lists
+	// (CRLs).
+	CRLSigningKeyUsage KeyUsage = "CRLSigning"
+	// EncipherOnlyKeyUsage Enciphers data while performing a key agreement.
+	EncipherOnlyKeyUsage KeyUsage = "EncipherOnly"
+	// DecipherOnlyKeyUsage Deciphers data while performing a key agreement.
+	DecipherOnlyKeyUsage KeyUsage = "DecipherOnly"
+	// ServerAuthenticationKeyUsage TLS WWW server authentication.
+	ServerAuthenticationKeyUsage KeyUsage = "ServerAuthentication"
+	// ClientAuthenticationKeyUsage TLS WWW client authentication.
+	ClientAuthenticationKeyUsage KeyUsage = "ClientAuthentication"
+	// CodeSigningKeyUsage Signs downloadable executable code.
+	CodeSigningKeyUsage KeyUsage = "CodeSigning"
+	// EmailProtectionKeyUsage Email protection.
+	EmailProtectionKeyUsage KeyUsage = "EmailProtection"
+	// TimestampingKeyUsage Binds the hash of an object to a time.
+	TimestampingKeyUsage KeyUsage = "Timestamping"
+	// OCSPSigningKeyUsage Signs OCSP responses.
+	OCSPSigningKeyUsage KeyUsage = "OCSPSigning"
+)
+
+// Identifier shall contain the properties that identify the issuer or
+// subject of a certificate.
+type Identifier struct {
+	// City shall contain the city or locality of the organization of the
+	// entity.
+	City string
+	// CommonName shall contain the fully qualified domain name of the entity.
+	CommonName string
+	// Country shall contain the two-letter ISO code for the country of the
+	// organization of the entity.
+	Country string
+	// Email shall contain the email address of the contact within the
+	// organization of the entity.
+	Email string
+	// Organization shall contain the name of the organization of the entity.
+	Organization string
+	// OrganizationalUnit shall contain the name of the unit or division of
+	// the organization of the entity.
+	OrganizationalUnit string
+	// State shall contain the state, province, or region of the organization
+	// of the entity.
+	State string
+}
+
+// Certificate shall represent a certificate for a Redfish implementation.
+type Certificate struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CertificateString shall contain the certificate, and the format shall
+	// follow the requirements specified by the CertificateType property
+	// value.
+	CertificateString string
+	// CertificateType shall contain the format type for the certificate.
+	CertificateType CertificateType
+	// CertificateUsageTypes shall contain an array describing the types or
+	// purposes for this certificate.
+	CertificateUsageTypes []CertificateUsageType
+	// Description provides a description of this resource.
+	Description string
+	// Fingerprint shall be a string containing the ASCII representation of
+	// the fingerprint of the certificate.
+	Fingerprint string
+	// FingerprintHashAlgorithm shall be a string containing the hash
+	// algorithm used for generating the Fingerprint property.
+	FingerprintHashAlgorithm string
+	// Issuer shall contain an object containing information about the issuer
+	// of the certificate.
+	Issuer Identifier
+	// KeyUsage shall contain the key usage extension, which defines the
+	// purpose of the public keys in this certificate.
+	KeyUsage []KeyUsage
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// SerialNumber shall be a string containing the ASCII representation of
+	// the serial number of the certificate.
+	SerialNumber string
+	// SignatureAlgorithm shall be a string containing the algorithm used for
+	// generating the signature of the certificate.
+	SignatureAlgorithm string
+	// Subject shall contain an object containing information about the
+	// subject of the certificate.
+	Subject Identifier
+	// ValidNotAfter shall contain the date when the certificate validity
+	// period ends.
+	ValidNotAfter string
+	// ValidNotBefore shall contain the date when the certificate validity
+	// period begins.
+	ValidNotBefore string
+	// rekeyTarget is the URL to send Rekey requests.
+	rekeyTarget string
+	// renewTarget is the URL to send Renew requests.
+	renewTarget string
+}
+
+// UnmarshalJSON unmarshals a Certificate object from the raw JSON.
+func (certificate *Certificate) UnmarshalJSON(b []byte) error {
+	type temp Certificate
+	type actions struct {
+		Rekey struct {
+			Target string
+		} `json:"#Certificate.Rekey"`
+		Renew struct {
+			Target string
+		} `json:"#Certificate.Renew"`
+	}
+	var t struct {
+		temp
+		Actions actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*certificate = Certificate(t.temp)
+	certificate.rekeyTarget = t.Actions.Rekey.Target
+	certificate.renewTarget = t.Actions.Renew.Target
+
+	return nil
+}
+
+// Expiry returns the time when the certificate validity period ends.
+func (certificate *Certificate) Expiry() (time.Time, error) {
+	return time.Parse(time.RFC3339, certificate.ValidNotAfter)
+}
+
+// ExpiresWithin returns true if the certificate validity period ends within
+// the given duration from now. Certificates whose expiry date cannot be
+// parsed are reported as expiring, so that they get looked at.
+func (certificate *Certificate) ExpiresWithin(d time.Duration) bool {
+	expiry, err := certificate.Expiry()
+	if err != nil {
+		return true
+	}
+	return time.Until(expiry) < d
+}
+
+// Renew shall generate a certificate signing request using the existing
+// information and key pair of the certificate.
+func (certificate *Certificate) Renew() (*CSRResponse, error) {
+	return postForCSR(certificate.Client, certificate.renewTarget, struct{}{})
+}
+
+// GetCertificate will get a Certificate instance from the service.
+func GetCertificate(c common.Client, uri string) (*Certificate, error) {
+	var certificate Certificate
+	return &certificate, certificate.Get(c, uri, &certificate)
+}
+
+// ListReferencedCertificates gets the collection of Certificate from
+// a provided reference.
+func ListReferencedCertificates(c common.Client, link string) ([]*Certificate, error) { //nolint:dupl
+	var result []*Certificate
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Certificate
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		certificate, err := GetCertificate(c, link)
+		ch <- GetResult{Item: certificate, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
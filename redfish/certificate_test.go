@@ -0,0 +1,110 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var certificateBody = `{
+		"@odata.id": "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1",
+		"@odata.type": "#Certificate.v1_5_0.Certificate",
+		"Id": "1",
+		"Name": "HTTPS Certificate",
+		"CertificateString": "-----BEGIN CERTIFICATE-----\nMIIFsTCC [** truncated example **] GXG5zljlu\n-----END CERTIFICATE-----",
+		"CertificateType": "PEM",
+		"CertificateUsageTypes": ["Web"],
+		"Issuer": {
+			"Country": "US",
+			"State": "Oregon",
+			"City": "Portland",
+			"Organization": "Contoso",
+			"OrganizationalUnit": "ABC",
+			"CommonName": "manager.contoso.org"
+		},
+		"Subject": {
+			"Country": "US",
+			"State": "Oregon",
+			"City": "Portland",
+			"Organization": "Contoso",
+			"OrganizationalUnit": "ABC",
+			"CommonName": "manager.contoso.org"
+		},
+		"ValidNotBefore": "2018-09-07T13:22:05Z",
+		"ValidNotAfter": "2019-09-07T13:22:05Z",
+		"KeyUsage": ["KeyEncipherment", "ServerAuthentication"],
+		"SerialNumber": "5d:7a:d8:df:f6:fc:c1:b3:ef:e9:35:9d:9f:7e:a7:1a",
+		"Fingerprint": "A6:E9:D2:5B:7F:3A:5E:DB:92:9F:A4:B7:D6:5D:56:6C:E2:A9:D8:0E",
+		"FingerprintHashAlgorithm": "TPM_ALG_SHA1",
+		"SignatureAlgorithm": "sha256WithRSAEncryption",
+		"Actions": {
+			"#Certificate.Rekey": {
+				"target": "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1/Actions/Certificate.Rekey"
+			},
+			"#Certificate.Renew": {
+				"target": "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1/Actions/Certificate.Renew"
+			}
+		}
+	}`
+
+// TestCertificate tests the parsing of Certificate objects.
+func TestCertificate(t *testing.T) {
+	var result Certificate
+	err := json.NewDecoder(strings.NewReader(certificateBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CertificateType != PEMCertificateType {
+		t.Errorf("Invalid CertificateType: %s", result.CertificateType)
+	}
+
+	if result.CertificateUsageTypes[0] != WebCertificateUsageType {
+		t.Errorf("Invalid CertificateUsageTypes: %v", result.CertificateUsageTypes)
+	}
+
+	if result.Subject.CommonName != "manager.contoso.org" {
+		t.Errorf("Invalid subject CommonName: %s", result.Subject.CommonName)
+	}
+
+	if result.KeyUsage[1] != ServerAuthenticationKeyUsage {
+		t.Errorf("Invalid KeyUsage: %v", result.KeyUsage)
+	}
+
+	if result.renewTarget != "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1/Actions/Certificate.Renew" {
+		t.Errorf("Invalid Renew target: %s", result.renewTarget)
+	}
+
+	expiry, err := result.Expiry()
+	if err != nil {
+		t.Errorf("Error parsing expiry: %s", err)
+	}
+
+	if !expiry.Equal(time.Date(2019, 9, 7, 13, 22, 5, 0, time.UTC)) {
+		t.Errorf("Invalid expiry: %s", expiry)
+	}
+
+	if !result.ExpiresWithin(0) {
+		t.Error("Certificate expired in 2019 should be reported as expiring")
+	}
+
+	result.ValidNotAfter = time.Now().Add(90 * 24 * time.Hour).Format(time.RFC3339)
+	if result.ExpiresWithin(30 * 24 * time.Hour) {
+		t.Error("Certificate should not expire within 30 days")
+	}
+
+	result.ValidNotAfter = ""
+	if !result.ExpiresWithin(0) {
+		t.Error("Certificate without a valid expiry should be reported as expiring")
+	}
+}
@@ -0,0 +1,79 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// CertificateLocations shall represent the certificate location properties
+// for a Redfish implementation.
+type CertificateLocations struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// certificates shall contain links to the certificates installed on the
+	// service.
+	certificates []string
+}
+
+// UnmarshalJSON unmarshals a CertificateLocations object from the raw JSON.
+func (certificatelocations *CertificateLocations) UnmarshalJSON(b []byte) error {
+	type temp CertificateLocations
+	var t struct {
+		temp
+		Links struct {
+			Certificates common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*certificatelocations = CertificateLocations(t.temp)
+	certificatelocations.certificates = t.Links.Certificates.ToStrings()
+
+	return nil
+}
+
+// GetCertificateLocations will get a CertificateLocations instance from the
+// service.
+func GetCertificateLocations(c common.Client, uri string) (*CertificateLocations, error) {
+	var certificateLocations CertificateLocations
+	return &certificateLocations, certificateLocations.Get(c, uri, &certificateLocations)
+}
+
+// Certificates gets the certificates installed on the service.
+func (certificatelocations *CertificateLocations) Certificates() ([]*Certificate, error) {
+	var result []*Certificate
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range certificatelocations.certificates {
+		certificate, err := GetCertificate(certificatelocations.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, certificate)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,50 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var certificateLocationsBody = `{
+		"@odata.id": "/redfish/v1/CertificateService/CertificateLocations",
+		"@odata.type": "#CertificateLocations.v1_0_2.CertificateLocations",
+		"Id": "CertificateLocations",
+		"Name": "Certificate Locations",
+		"Links": {
+			"Certificates": [
+				{
+					"@odata.id": "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1"
+				},
+				{
+					"@odata.id": "/redfish/v1/AccountService/Accounts/1/Certificates/1"
+				}
+			]
+		}
+	}`
+
+// TestCertificateLocations tests the parsing of CertificateLocations objects.
+func TestCertificateLocations(t *testing.T) {
+	var result CertificateLocations
+	err := json.NewDecoder(strings.NewReader(certificateLocationsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CertificateLocations" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if len(result.certificates) != 2 {
+		t.Errorf("Expected 2 certificates, got %d", len(result.certificates))
+	}
+
+	if result.certificates[1] != "/redfish/v1/AccountService/Accounts/1/Certificates/1" {
+		t.Errorf("Invalid certificate link: %s", result.certificates[1])
+	}
+}
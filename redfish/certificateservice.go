@@ -0,0 +1,251 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// CertificateService shall represent the certificate service properties for
+// a Redfish implementation.
+type CertificateService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// certificateLocations shall contain a link to a resource of type
+	// CertificateLocations.
+	certificateLocations string
+	// generateCSRTarget is the URL to send GenerateCSR requests.
+	generateCSRTarget string
+	// replaceCertificateTarget is the URL to send ReplaceCertificate
+	// requests.
+	replaceCertificateTarget string
+}
+
+// UnmarshalJSON unmarshals a CertificateService object from the raw JSON.
+func (certificateservice *CertificateService) UnmarshalJSON(b []byte) error {
+	type temp CertificateService
+	type actions struct {
+		GenerateCSR struct {
+			Target string
+		} `json:"#CertificateService.GenerateCSR"`
+		ReplaceCertificate struct {
+			Target string
+		} `json:"#CertificateService.ReplaceCertificate"`
+	}
+	var t struct {
+		temp
+		Actions              actions
+		CertificateLocations common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*certificateservice = CertificateService(t.temp)
+	certificateservice.certificateLocations = t.CertificateLocations.String()
+	certificateservice.generateCSRTarget = t.Actions.GenerateCSR.Target
+	certificateservice.replaceCertificateTarget = t.Actions.ReplaceCertificate.Target
+
+	return nil
+}
+
+// GetCertificateService will get a CertificateService instance from the
+// service.
+func GetCertificateService(c common.Client, uri string) (*CertificateService, error) {
+	var certificateService CertificateService
+	return &certificateService, certificateService.Get(c, uri, &certificateService)
+}
+
+// CertificateLocations gets the resource listing the installed certificates.
+func (certificateservice *CertificateService) CertificateLocations() (*CertificateLocations, error) {
+	if certificateservice.certificateLocations == "" {
+		return nil, nil
+	}
+	return GetCertificateLocations(certificateservice.Client, certificateservice.certificateLocations)
+}
+
+// Certificates gets every certificate installed on the service, as listed in
+// the certificate locations.
+func (certificateservice *CertificateService) Certificates() ([]*Certificate, error) {
+	locations, err := certificateservice.CertificateLocations()
+	if err != nil || locations == nil {
+		return nil, err
+	}
+	return locations.Certificates()
+}
+
+// ExpiringCertificates gets the installed certificates whose validity period
+// ends within the given duration from now, including ones that already
+// expired.
+func (certificateservice *CertificateService) ExpiringCertificates(within time.Duration) ([]*Certificate, error) {
+	certificates, err := certificateservice.Certificates()
+
+	var result []*Certificate
+	for _, certificate := range certificates {
+		if certificate.ExpiresWithin(within) {
+			result = append(result, certificate)
+		}
+	}
+
+	return result, err
+}
+
+// CSRParameters contains the information used to generate a certificate
+// signing request.
+type CSRParameters struct {
+	// AlternativeNames shall contain an array of additional host names of
+	// the component to secure.
+	AlternativeNames []string `json:",omitempty"`
+	// ChallengePassword shall contain the challenge password to apply to the
+	// certificate for revocation requests.
+	ChallengePassword string `json:",omitempty"`
+	// City shall contain the city or locality of the organization making the
+	// request.
+	City string
+	// CommonName shall contain the fully qualified domain name of the
+	// component to secure.
+	CommonName string
+	// ContactPerson shall contain the name of the user making the request.
+	ContactPerson string `json:",omitempty"`
+	// Country shall contain the two-letter ISO code for the country of the
+	// organization making the request.
+	Country string
+	// Email shall contain the email address of the contact within the
+	// organization making the request.
+	Email string `json:",omitempty"`
+	// KeyBitLength shall contain the length of the key, in bits, if needed
+	// based on the KeyPairAlgorithm parameter value.
+	KeyBitLength int `json:",omitempty"`
+	// KeyCurveID shall contain the curve ID to use with the key, if needed
+	// based on the KeyPairAlgorithm parameter value.
+	KeyCurveID string `json:"KeyCurveId,omitempty"`
+	// KeyPairAlgorithm shall contain the type of key-pair for use with
+	// signing algorithms, for example TPM_ALG_RSA or TPM_ALG_ECDSA.
+	KeyPairAlgorithm string `json:",omitempty"`
+	// KeyUsage shall contain the usage of the key contained in the
+	// certificate.
+	KeyUsage []KeyUsage `json:",omitempty"`
+	// Organization shall contain the name of the organization making the
+	// request.
+	Organization string
+	// OrganizationalUnit shall contain the name of the unit or division of
+	// the organization making the request.
+	OrganizationalUnit string
+	// State shall contain the state, province, or region of the organization
+	// making the request.
+	State string
+}
+
+// CSRResponse contains the result of a certificate signing request
+// generation.
+type CSRResponse struct {
+	// CertificateCollection shall contain a link to the certificate
+	// collection where the certificate is installed once signed.
+	CertificateCollection string
+	// CSRString shall contain the Privacy Enhanced Mail (PEM)-encoded string
+	// of the certificate signing request.
+	CSRString string
+}
+
+// UnmarshalJSON unmarshals a CSRResponse object from the raw JSON.
+func (csrresponse *CSRResponse) UnmarshalJSON(b []byte) error {
+	var t struct {
+		CertificateCollection common.Link
+		CSRString             string
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	csrresponse.CertificateCollection = t.CertificateCollection.String()
+	csrresponse.CSRString = t.CSRString
+
+	return nil
+}
+
+// odataLink is used to reference a resource in action parameters.
+type odataLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+// GenerateCSR shall make a certificate signing request. The certificate
+// collection is where the certificate is installed once signed, for example
+// the HTTPS certificates collection of a manager's network protocol.
+func (certificateservice *CertificateService) GenerateCSR(certificateCollection string, parameters *CSRParameters) (*CSRResponse, error) {
+	if certificateservice.generateCSRTarget == "" {
+		return nil, common.NewActionNotSupportedError("GenerateCSR is not supported by this service")
+	}
+	if parameters == nil {
+		return nil, fmt.Errorf("certificate signing request parameters must be supplied")
+	}
+
+	t := struct {
+		CertificateCollection odataLink
+		*CSRParameters
+	}{
+		CertificateCollection: odataLink{ODataID: certificateCollection},
+		CSRParameters:         parameters,
+	}
+
+	return postForCSR(certificateservice.Client, certificateservice.generateCSRTarget, t)
+}
+
+// postForCSR posts an action returning a certificate signing request.
+func postForCSR(c common.Client, target string, payload interface{}) (*CSRResponse, error) {
+	if target == "" {
+		return nil, common.NewActionNotSupportedError("action is not supported by this service")
+	}
+
+	resp, err := c.Post(target, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result CSRResponse
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ReplaceCertificate shall replace the certificate at certificateURI with the
+// given certificate.
+func (certificateservice *CertificateService) ReplaceCertificate(certificateURI, certificateString string, certificateType CertificateType) error {
+	if certificateservice.replaceCertificateTarget == "" {
+		return common.NewActionNotSupportedError("ReplaceCertificate is not supported by this service")
+	}
+
+	t := struct {
+		CertificateURI    odataLink `json:"CertificateUri"`
+		CertificateString string
+		CertificateType   CertificateType
+	}{
+		CertificateURI:    odataLink{ODataID: certificateURI},
+		CertificateString: certificateString,
+		CertificateType:   certificateType,
+	}
+
+	return certificateservice.Post(certificateservice.replaceCertificateTarget, t)
+}
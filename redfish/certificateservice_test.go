@@ -0,0 +1,199 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var certificateServiceBody = `{
+		"@odata.id": "/redfish/v1/CertificateService",
+		"@odata.type": "#CertificateService.v1_0_4.CertificateService",
+		"Id": "CertificateService",
+		"Name": "Certificate Service",
+		"Description": "Actions available to manage certificates",
+		"Actions": {
+			"#CertificateService.GenerateCSR": {
+				"target": "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR"
+			},
+			"#CertificateService.ReplaceCertificate": {
+				"target": "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate"
+			}
+		},
+		"CertificateLocations": {
+			"@odata.id": "/redfish/v1/CertificateService/CertificateLocations"
+		}
+	}`
+
+// TestCertificateService tests the parsing of CertificateService objects.
+func TestCertificateService(t *testing.T) {
+	var result CertificateService
+	err := json.NewDecoder(strings.NewReader(certificateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CertificateService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.certificateLocations != "/redfish/v1/CertificateService/CertificateLocations" {
+		t.Errorf("Invalid CertificateLocations link: %s", result.certificateLocations)
+	}
+
+	if result.generateCSRTarget != "/redfish/v1/CertificateService/Actions/CertificateService.GenerateCSR" {
+		t.Errorf("Invalid GenerateCSR target: %s", result.generateCSRTarget)
+	}
+
+	if result.replaceCertificateTarget != "/redfish/v1/CertificateService/Actions/CertificateService.ReplaceCertificate" {
+		t.Errorf("Invalid ReplaceCertificate target: %s", result.replaceCertificateTarget)
+	}
+}
+
+// TestCertificateServiceGenerateCSR tests the GenerateCSR call.
+func TestCertificateServiceGenerateCSR(t *testing.T) {
+	var result CertificateService
+	err := json.NewDecoder(strings.NewReader(certificateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusOK, `{
+					"CSRString": "-----BEGIN CERTIFICATE REQUEST-----...-----END CERTIFICATE REQUEST-----",
+					"CertificateCollection": {
+						"@odata.id": "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates"
+					}
+				}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	csr, err := result.GenerateCSR("/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates", &CSRParameters{
+		City:               "Portland",
+		CommonName:         "manager.contoso.org",
+		Country:            "US",
+		Organization:       "Contoso",
+		OrganizationalUnit: "ABC",
+		State:              "Oregon",
+		KeyPairAlgorithm:   "TPM_ALG_RSA",
+		KeyBitLength:       2048,
+	})
+
+	if err != nil {
+		t.Errorf("Error making GenerateCSR call: %s", err)
+	}
+
+	if !strings.HasPrefix(csr.CSRString, "-----BEGIN CERTIFICATE REQUEST-----") {
+		t.Errorf("Invalid CSRString: %s", csr.CSRString)
+	}
+
+	if csr.CertificateCollection != "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates" {
+		t.Errorf("Invalid CertificateCollection: %s", csr.CertificateCollection)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != result.generateCSRTarget {
+		t.Errorf("Unexpected GenerateCSR URL: %s", calls[0].URL)
+	}
+
+	for _, expected := range []string{
+		"CertificateCollection:map[@odata.id:/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates]",
+		"CommonName:manager.contoso.org",
+		"KeyBitLength:2048",
+	} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Unexpected GenerateCSR payload: %s", calls[0].Payload)
+		}
+	}
+
+	if strings.Contains(calls[0].Payload, "ChallengePassword") {
+		t.Errorf("Unset optional parameters should not be sent: %s", calls[0].Payload)
+	}
+}
+
+// TestCertificateServiceReplaceCertificate tests the ReplaceCertificate call.
+func TestCertificateServiceReplaceCertificate(t *testing.T) {
+	var result CertificateService
+	err := json.NewDecoder(strings.NewReader(certificateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ReplaceCertificate("/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1", "PEMDATA", PEMCertificateType)
+
+	if err != nil {
+		t.Errorf("Error making ReplaceCertificate call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	for _, expected := range []string{
+		"CertificateUri:map[@odata.id:/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1]",
+		"CertificateString:PEMDATA",
+		"CertificateType:PEM",
+	} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Unexpected ReplaceCertificate payload: %s", calls[0].Payload)
+		}
+	}
+}
+
+// TestCertificateServiceExpiringCertificates tests listing the installed
+// certificates close to their expiry.
+func TestCertificateServiceExpiringCertificates(t *testing.T) {
+	var result CertificateService
+	err := json.NewDecoder(strings.NewReader(certificateServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	later := time.Now().Add(365 * 24 * time.Hour).Format(time.RFC3339)
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, certificateLocationsBody, nil),
+				testResponse(http.StatusOK, certificateBody, nil),
+				testResponse(http.StatusOK, `{
+					"@odata.id": "/redfish/v1/AccountService/Accounts/1/Certificates/1",
+					"Id": "1",
+					"ValidNotAfter": "`+later+`"
+				}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	certificates, err := result.ExpiringCertificates(30 * 24 * time.Hour)
+
+	if err != nil {
+		t.Errorf("Error getting expiring certificates: %s", err)
+	}
+
+	if len(certificates) != 1 {
+		t.Fatalf("Expected 1 expiring certificate, got %d", len(certificates))
+	}
+
+	if certificates[0].ODataID != "/redfish/v1/Managers/BMC/NetworkProtocol/HTTPS/Certificates/1" {
+		t.Errorf("Unexpected expiring certificate: %s", certificates[0].ODataID)
+	}
+}
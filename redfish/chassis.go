@@ -226,6 +226,11 @@ type Chassis struct {
 	thermal         string
 	power           string
 	networkAdapters string
+	// sensors shall be a link to a collection of type SensorCollection.
+	sensors            string
+	powerSubsystem     string
+	thermalSubsystem   string
+	environmentMetrics string
 	// logServices shall be a link to a collection of type LogServiceCollection.
 	logServices     string
 	computerSystems []string
@@ -259,14 +264,18 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 
 	var t struct {
 		temp
-		Assembly        common.Link
-		Drives          common.Link
-		Thermal         common.Link
-		Power           common.Link
-		NetworkAdapters common.Link
-		LogServices     common.Link
-		Links           linkReference
-		Actions         Actions
+		Assembly           common.Link
+		Drives             common.Link
+		Thermal            common.Link
+		Power              common.Link
+		NetworkAdapters    common.Link
+		Sensors            common.Link
+		PowerSubsystem     common.Link
+		ThermalSubsystem   common.Link
+		EnvironmentMetrics common.Link
+		LogServices        common.Link
+		Links              linkReference
+		Actions            Actions
 	}
 
 	err := json.Unmarshal(b, &t)
@@ -286,6 +295,10 @@ func (chassis *Chassis) UnmarshalJSON(b []byte) error {
 	chassis.thermal = t.Thermal.String()
 	chassis.power = t.Power.String()
 	chassis.networkAdapters = t.NetworkAdapters.String()
+	chassis.sensors = t.Sensors.String()
+	chassis.powerSubsystem = t.PowerSubsystem.String()
+	chassis.thermalSubsystem = t.ThermalSubsystem.String()
+	chassis.environmentMetrics = t.EnvironmentMetrics.String()
 	chassis.logServices = t.LogServices.String()
 	chassis.computerSystems = t.Links.ComputerSystems.ToStrings()
 	chassis.resourceBlocks = t.Links.ResourceBlocks.ToStrings()
@@ -442,6 +455,40 @@ func (chassis *Chassis) Power() (*Power, error) {
 	return GetPower(chassis.Client, chassis.power)
 }
 
+// Sensors gets the sensors of this chassis.
+func (chassis *Chassis) Sensors() ([]*Sensor, error) {
+	return ListReferencedSensors(chassis.Client, chassis.sensors)
+}
+
+// PowerSubsystem gets the power subsystem of this chassis. It replaces the
+// Power resource on newer services.
+func (chassis *Chassis) PowerSubsystem() (*PowerSubsystem, error) {
+	if chassis.powerSubsystem == "" {
+		return nil, nil
+	}
+
+	return GetPowerSubsystem(chassis.Client, chassis.powerSubsystem)
+}
+
+// ThermalSubsystem gets the thermal subsystem of this chassis. It replaces
+// the Thermal resource on newer services.
+func (chassis *Chassis) ThermalSubsystem() (*ThermalSubsystem, error) {
+	if chassis.thermalSubsystem == "" {
+		return nil, nil
+	}
+
+	return GetThermalSubsystem(chassis.Client, chassis.thermalSubsystem)
+}
+
+// EnvironmentMetrics gets the environmental metrics of this chassis.
+func (chassis *Chassis) EnvironmentMetrics() (*EnvironmentMetrics, error) {
+	if chassis.environmentMetrics == "" {
+		return nil, nil
+	}
+
+	return GetEnvironmentMetrics(chassis.Client, chassis.environmentMetrics)
+}
+
 // ComputerSystems returns the collection of systems from this chassis
 func (chassis *Chassis) ComputerSystems() ([]*ComputerSystem, error) {
 	var result []*ComputerSystem
@@ -0,0 +1,293 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"github.com/bcohee/gofish/common"
+)
+
+// ReadingKind is the kind of data a chassis reading reports.
+type ReadingKind string
+
+const (
+	// TemperatureReadingKind is a temperature, in degrees Celsius.
+	TemperatureReadingKind ReadingKind = "Temperature"
+	// FanReadingKind is a fan speed, in RPM or percent.
+	FanReadingKind ReadingKind = "Fan"
+	// PowerReadingKind is a power consumption, in watts.
+	PowerReadingKind ReadingKind = "Power"
+)
+
+// ChassisReading is a single temperature, fan or power reading of a
+// chassis, whichever resource of the service it was taken from.
+type ChassisReading struct {
+	// Kind is the kind of data the reading reports.
+	Kind ReadingKind
+	// Name is the name of the sensor or device the reading comes from.
+	Name string
+	// Source is the URI of the resource the reading was taken from.
+	Source string
+	// PhysicalContext is the area or device the reading applies to.
+	PhysicalContext common.PhysicalContext
+	// Value is the reading itself.
+	Value float32
+	// Units is the unit of the reading, using the same UCUM symbols as
+	// sensors, for example "Cel", "RPM", "%" or "W".
+	Units string
+	// Status is the status of the sensor or device.
+	Status common.Status
+}
+
+// ChassisReadings holds the temperature, fan and power readings of a
+// chassis.
+type ChassisReadings struct {
+	// Temperatures holds the temperature readings.
+	Temperatures []ChassisReading
+	// Fans holds the fan speed readings.
+	Fans []ChassisReading
+	// Power holds the power consumption readings.
+	Power []ChassisReading
+}
+
+// Readings gets the temperature, fan and power readings of the chassis. The
+// readings are taken from the Sensors collection when the service
+// implements it. The kinds of readings the sensors do not report are taken
+// from the fans of the ThermalSubsystem, the EnvironmentMetrics and the
+// power supplies of the PowerSubsystem, and finally from the deprecated
+// Thermal and Power resources. Failures to get some of the resources are
+// returned as a CollectionError along with the readings that could be taken.
+func (chassis *Chassis) Readings() (*ChassisReadings, error) {
+	result := &ChassisReadings{}
+	collectionError := common.NewCollectionError()
+
+	if chassis.sensors != "" {
+		sensors, err := chassis.Sensors()
+		if err != nil {
+			collectionError.Failures[chassis.sensors] = err
+		}
+		for _, sensor := range sensors {
+			result.addSensor(sensor)
+		}
+	}
+
+	if len(result.Fans) == 0 && chassis.thermalSubsystem != "" {
+		result.addThermalSubsystem(chassis, collectionError)
+	}
+
+	if (len(result.Temperatures) == 0 || len(result.Power) == 0) && chassis.environmentMetrics != "" {
+		metrics, err := chassis.EnvironmentMetrics()
+		if err != nil {
+			collectionError.Failures[chassis.environmentMetrics] = err
+		} else {
+			result.addEnvironmentMetrics(metrics)
+		}
+	}
+
+	if len(result.Power) == 0 && chassis.powerSubsystem != "" {
+		result.addPowerSubsystem(chassis, collectionError)
+	}
+
+	if (len(result.Temperatures) == 0 || len(result.Fans) == 0) && chassis.thermal != "" {
+		thermal, err := chassis.Thermal()
+		if err != nil {
+			collectionError.Failures[chassis.thermal] = err
+		} else {
+			result.addThermal(thermal)
+		}
+	}
+
+	if len(result.Power) == 0 && chassis.power != "" {
+		power, err := chassis.Power()
+		if err != nil {
+			collectionError.Failures[chassis.power] = err
+		} else {
+			result.addPower(power)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// addSensor adds the reading of a sensor if it is of a kind of interest.
+func (readings *ChassisReadings) addSensor(sensor *Sensor) {
+	reading := ChassisReading{
+		Name:            sensor.Name,
+		Source:          sensor.ODataID,
+		PhysicalContext: sensor.PhysicalContext,
+		Value:           sensor.Reading,
+		Units:           sensor.ReadingUnits,
+		Status:          sensor.Status,
+	}
+
+	switch {
+	case sensor.ReadingType == TemperatureReadingType:
+		reading.Kind = TemperatureReadingKind
+		readings.Temperatures = append(readings.Temperatures, reading)
+	case sensor.ReadingType == RotationalReadingType,
+		sensor.ReadingType == PercentReadingType && sensor.PhysicalContext == common.FanPhysicalContext:
+		reading.Kind = FanReadingKind
+		readings.Fans = append(readings.Fans, reading)
+	case sensor.ReadingType == PowerReadingType:
+		reading.Kind = PowerReadingKind
+		readings.Power = append(readings.Power, reading)
+	}
+}
+
+// addThermalSubsystem adds the speed readings of the fans of the thermal
+// subsystem.
+func (readings *ChassisReadings) addThermalSubsystem(chassis *Chassis, collectionError *common.CollectionError) {
+	subsystem, err := chassis.ThermalSubsystem()
+	if err != nil {
+		collectionError.Failures[chassis.thermalSubsystem] = err
+		return
+	}
+
+	fans, err := subsystem.Fans()
+	if err != nil {
+		collectionError.Failures[subsystem.fans] = err
+	}
+
+	for _, fan := range fans {
+		readings.Fans = append(readings.Fans, ChassisReading{
+			Kind:            FanReadingKind,
+			Name:            fan.Name,
+			Source:          memberSource(fan.SpeedPercent.DataSourceURI, fan.ODataID),
+			PhysicalContext: fan.PhysicalContext,
+			Value:           fan.SpeedPercent.Reading,
+			Units:           "%",
+			Status:          fan.Status,
+		})
+	}
+}
+
+// addEnvironmentMetrics adds the temperature and power consumption of the
+// chassis reported by its environment metrics, for the kinds of readings that
+// were not already found.
+func (readings *ChassisReadings) addEnvironmentMetrics(metrics *EnvironmentMetrics) {
+	if len(readings.Temperatures) == 0 && metrics.TemperatureCelsius != (SensorExcerpt{}) {
+		readings.Temperatures = append(readings.Temperatures, ChassisReading{
+			Kind:            TemperatureReadingKind,
+			Name:            metrics.Name,
+			Source:          memberSource(metrics.TemperatureCelsius.DataSourceURI, metrics.ODataID),
+			PhysicalContext: common.ChassisPhysicalContext,
+			Value:           metrics.TemperatureCelsius.Reading,
+			Units:           "Cel",
+		})
+	}
+
+	if len(readings.Power) == 0 && metrics.PowerWatts.SensorExcerpt != (SensorExcerpt{}) {
+		readings.Power = append(readings.Power, ChassisReading{
+			Kind:            PowerReadingKind,
+			Name:            metrics.Name,
+			Source:          memberSource(metrics.PowerWatts.DataSourceURI, metrics.ODataID),
+			PhysicalContext: common.ChassisPhysicalContext,
+			Value:           metrics.PowerWatts.Reading,
+			Units:           "W",
+		})
+	}
+}
+
+// addPowerSubsystem adds the input power readings of the power supplies of
+// the power subsystem.
+func (readings *ChassisReadings) addPowerSubsystem(chassis *Chassis, collectionError *common.CollectionError) {
+	subsystem, err := chassis.PowerSubsystem()
+	if err != nil {
+		collectionError.Failures[chassis.powerSubsystem] = err
+		return
+	}
+
+	powerSupplies, err := subsystem.PowerSupplies()
+	if err != nil {
+		collectionError.Failures[subsystem.powerSupplies] = err
+	}
+
+	for _, powerSupply := range powerSupplies {
+		if powerSupply.metrics == "" {
+			continue
+		}
+		metrics, err := powerSupply.Metrics()
+		if err != nil {
+			collectionError.Failures[powerSupply.metrics] = err
+			continue
+		}
+		readings.Power = append(readings.Power, ChassisReading{
+			Kind:            PowerReadingKind,
+			Name:            powerSupply.Name,
+			Source:          memberSource(metrics.InputPowerWatts.DataSourceURI, metrics.ODataID),
+			PhysicalContext: common.PowerSupplyPhysicalContext,
+			Value:           metrics.InputPowerWatts.Reading,
+			Units:           "W",
+			Status:          powerSupply.Status,
+		})
+	}
+}
+
+// addThermal adds the readings of the deprecated Thermal resource, for the
+// kinds of readings that were not already found.
+func (readings *ChassisReadings) addThermal(thermal *Thermal) {
+	if len(readings.Temperatures) == 0 {
+		for i := range thermal.Temperatures {
+			temperature := &thermal.Temperatures[i]
+			readings.Temperatures = append(readings.Temperatures, ChassisReading{
+				Kind:            TemperatureReadingKind,
+				Name:            temperature.Name,
+				Source:          memberSource(temperature.ODataID, thermal.ODataID),
+				PhysicalContext: common.PhysicalContext(temperature.PhysicalContext),
+				Value:           temperature.ReadingCelsius,
+				Units:           "Cel",
+				Status:          temperature.Status,
+			})
+		}
+	}
+
+	if len(readings.Fans) == 0 {
+		for i := range thermal.Fans {
+			fan := &thermal.Fans[i]
+			units := string(fan.ReadingUnits)
+			if fan.ReadingUnits == PercentReadingUnits {
+				units = "%"
+			}
+			readings.Fans = append(readings.Fans, ChassisReading{
+				Kind:            FanReadingKind,
+				Name:            fan.Name,
+				Source:          memberSource(fan.ODataID, thermal.ODataID),
+				PhysicalContext: common.PhysicalContext(fan.PhysicalContext),
+				Value:           fan.Reading,
+				Units:           units,
+				Status:          fan.Status,
+			})
+		}
+	}
+}
+
+// addPower adds the power consumption readings of the deprecated Power
+// resource.
+func (readings *ChassisReadings) addPower(power *Power) {
+	for i := range power.PowerControl {
+		control := &power.PowerControl[i]
+		readings.Power = append(readings.Power, ChassisReading{
+			Kind:            PowerReadingKind,
+			Name:            control.Name,
+			Source:          memberSource(control.ODataID, power.ODataID),
+			PhysicalContext: control.PhysicalContext,
+			Value:           control.PowerConsumedWatts,
+			Units:           "W",
+			Status:          control.Status,
+		})
+	}
+}
+
+// memberSource returns the URI of an object embedded in a resource, which
+// is the URI of the resource itself if the object does not have one.
+func memberSource(memberID, resourceID string) string {
+	if memberID != "" {
+		return memberID
+	}
+	return resourceID
+}
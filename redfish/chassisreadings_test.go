@@ -0,0 +1,349 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var sensorsChassisBody = `{
+		"@odata.type": "#Chassis.v1_21_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/1U",
+		"Id": "1U",
+		"Name": "Computer System Chassis",
+		"Sensors": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Sensors"
+		},
+		"PowerSubsystem": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem"
+		},
+		"ThermalSubsystem": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem"
+		},
+		"EnvironmentMetrics": {
+			"@odata.id": "/redfish/v1/Chassis/1U/EnvironmentMetrics"
+		},
+		"Power": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Power"
+		}
+	}`
+
+var sensorsPowerChassisBody = `{
+		"@odata.type": "#Chassis.v1_21_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/1U",
+		"Id": "1U",
+		"Name": "Computer System Chassis",
+		"Sensors": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Sensors"
+		},
+		"Power": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Power"
+		}
+	}`
+
+var subsystemsChassisBody = `{
+		"@odata.type": "#Chassis.v1_21_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/1U",
+		"Id": "1U",
+		"Name": "Computer System Chassis",
+		"PowerSubsystem": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem"
+		},
+		"ThermalSubsystem": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem"
+		},
+		"EnvironmentMetrics": {
+			"@odata.id": "/redfish/v1/Chassis/1U/EnvironmentMetrics"
+		},
+		"Thermal": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Thermal"
+		},
+		"Power": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Power"
+		}
+	}`
+
+var legacyChassisBody = `{
+		"@odata.type": "#Chassis.v1_10_0.Chassis",
+		"@odata.id": "/redfish/v1/Chassis/1U",
+		"Id": "1U",
+		"Name": "Computer System Chassis",
+		"Thermal": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Thermal"
+		},
+		"Power": {
+			"@odata.id": "/redfish/v1/Chassis/1U/Power"
+		}
+	}`
+
+var sensorCollectionBody = `{
+		"@odata.type": "#SensorCollection.SensorCollection",
+		"@odata.id": "/redfish/v1/Chassis/1U/Sensors",
+		"Name": "Sensors",
+		"Members@odata.count": 1,
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1U/Sensors/CPU1Temp"
+			}
+		]
+	}`
+
+var legacyThermalBody = `{
+		"@odata.type": "#Thermal.v1_7_0.Thermal",
+		"@odata.id": "/redfish/v1/Chassis/1U/Thermal",
+		"Id": "Thermal",
+		"Name": "Thermal",
+		"Temperatures": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1U/Thermal#/Temperatures/0",
+				"MemberId": "0",
+				"Name": "CPU1 Temp",
+				"ReadingCelsius": 41,
+				"PhysicalContext": "CPU"
+			}
+		],
+		"Fans": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1U/Thermal#/Fans/0",
+				"MemberId": "0",
+				"Name": "BaseBoard System Fan",
+				"Reading": 2100,
+				"ReadingUnits": "RPM",
+				"PhysicalContext": "Backplane"
+			}
+		]
+	}`
+
+var legacyPowerBody = `{
+		"@odata.type": "#Power.v1_5_0.Power",
+		"@odata.id": "/redfish/v1/Chassis/1U/Power",
+		"Id": "Power",
+		"Name": "Power",
+		"PowerControl": [
+			{
+				"@odata.id": "/redfish/v1/Chassis/1U/Power#/PowerControl/0",
+				"MemberId": "0",
+				"Name": "System Power Control",
+				"PowerConsumedWatts": 344,
+				"PhysicalContext": "Chassis"
+			}
+		]
+	}`
+
+// TestChassisSubsystemLinks tests the parsing of the links to the sensors and
+// subsystems of a chassis.
+func TestChassisSubsystemLinks(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(sensorsChassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.sensors != "/redfish/v1/Chassis/1U/Sensors" {
+		t.Errorf("Invalid Sensors link: %s", result.sensors)
+	}
+
+	if result.powerSubsystem != "/redfish/v1/Chassis/1U/PowerSubsystem" {
+		t.Errorf("Invalid PowerSubsystem link: %s", result.powerSubsystem)
+	}
+
+	if result.thermalSubsystem != "/redfish/v1/Chassis/1U/ThermalSubsystem" {
+		t.Errorf("Invalid ThermalSubsystem link: %s", result.thermalSubsystem)
+	}
+
+	if result.environmentMetrics != "/redfish/v1/Chassis/1U/EnvironmentMetrics" {
+		t.Errorf("Invalid EnvironmentMetrics link: %s", result.environmentMetrics)
+	}
+}
+
+// TestChassisReadingsFromSensors tests taking readings from the sensors,
+// falling back to the Power resource for the missing power readings.
+func TestChassisReadingsFromSensors(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(sensorsPowerChassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, sensorCollectionBody, nil),
+				testResponse(http.StatusOK, sensorBody, nil),
+				testResponse(http.StatusOK, legacyPowerBody, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	readings, err := result.Readings()
+	if err != nil {
+		t.Fatalf("Error getting readings: %s", err)
+	}
+
+	if len(readings.Temperatures) != 1 {
+		t.Fatalf("Expected one temperature reading, got: %v", readings.Temperatures)
+	}
+
+	temperature := readings.Temperatures[0]
+	if temperature.Kind != TemperatureReadingKind || temperature.Value != 41.5 || temperature.Units != "Cel" ||
+		temperature.Source != "/redfish/v1/Chassis/1U/Sensors/CPU1Temp" {
+		t.Errorf("Unexpected temperature reading: %v", temperature)
+	}
+
+	if len(readings.Fans) != 0 {
+		t.Errorf("Unexpected fan readings: %v", readings.Fans)
+	}
+
+	if len(readings.Power) != 1 || readings.Power[0].Value != 344 || readings.Power[0].Units != "W" {
+		t.Errorf("Unexpected power readings: %v", readings.Power)
+	}
+
+	if len(testClient.CapturedCalls()) != 3 {
+		t.Errorf("Unexpected calls: %v", testClient.CapturedCalls())
+	}
+}
+
+// TestChassisReadingsFromSubsystems tests taking readings from the thermal and
+// power subsystems and the environment metrics, without using the deprecated
+// resources.
+func TestChassisReadingsFromSubsystems(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(subsystemsChassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, thermalSubsystemBody, nil),
+				testResponse(http.StatusOK, `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1"}]}`, nil),
+				testResponse(http.StatusOK, fanUnitBody, nil),
+				testResponse(http.StatusOK, `{
+					"@odata.id": "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+					"Id": "EnvironmentMetrics",
+					"Name": "Chassis Environment Metrics",
+					"TemperatureCelsius": {"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/AmbientTemp", "Reading": 24.5}
+				}`, nil),
+				testResponse(http.StatusOK, powerSubsystemBody, nil),
+				testResponse(http.StatusOK, `{"Members@odata.count": 1, "Members": [{"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1"}]}`, nil),
+				testResponse(http.StatusOK, powerSupplyUnitBody, nil),
+				testResponse(http.StatusOK, powerSupplyMetricsBody, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	readings, err := result.Readings()
+	if err != nil {
+		t.Fatalf("Error getting readings: %s", err)
+	}
+
+	if len(readings.Fans) != 1 || readings.Fans[0].Value != 45 || readings.Fans[0].Units != "%" ||
+		readings.Fans[0].Source != "/redfish/v1/Chassis/1U/Sensors/FanBay1" {
+		t.Errorf("Unexpected fan readings: %v", readings.Fans)
+	}
+
+	if len(readings.Temperatures) != 1 || readings.Temperatures[0].Value != 24.5 ||
+		readings.Temperatures[0].PhysicalContext != common.ChassisPhysicalContext {
+		t.Errorf("Unexpected temperature readings: %v", readings.Temperatures)
+	}
+
+	if len(readings.Power) != 1 || readings.Power[0].Value != 374 || readings.Power[0].Name != "Power Supply Bay 1" {
+		t.Errorf("Unexpected power readings: %v", readings.Power)
+	}
+
+	for _, call := range testClient.CapturedCalls() {
+		if call.URL == "/redfish/v1/Chassis/1U/Thermal" || call.URL == "/redfish/v1/Chassis/1U/Power" {
+			t.Errorf("Deprecated resource should not be used: %s", call.URL)
+		}
+	}
+}
+
+// TestChassisReadingsFromLegacyResources tests taking readings from the
+// Thermal and Power resources.
+func TestChassisReadingsFromLegacyResources(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(legacyChassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, legacyThermalBody, nil),
+				testResponse(http.StatusOK, legacyPowerBody, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	readings, err := result.Readings()
+	if err != nil {
+		t.Fatalf("Error getting readings: %s", err)
+	}
+
+	if len(readings.Temperatures) != 1 || readings.Temperatures[0].Value != 41 ||
+		readings.Temperatures[0].PhysicalContext != common.CPUPhysicalContext ||
+		readings.Temperatures[0].Source != "/redfish/v1/Chassis/1U/Thermal#/Temperatures/0" {
+		t.Errorf("Unexpected temperature readings: %v", readings.Temperatures)
+	}
+
+	if len(readings.Fans) != 1 || readings.Fans[0].Value != 2100 || readings.Fans[0].Units != "RPM" {
+		t.Errorf("Unexpected fan readings: %v", readings.Fans)
+	}
+
+	if len(readings.Power) != 1 || readings.Power[0].Name != "System Power Control" {
+		t.Errorf("Unexpected power readings: %v", readings.Power)
+	}
+}
+
+// TestChassisReadingsPartialFailure tests that readings are returned along
+// with the failures to get some of the resources.
+func TestChassisReadingsPartialFailure(t *testing.T) {
+	var result Chassis
+	err := json.NewDecoder(strings.NewReader(legacyChassisBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	notFound := testResponse(http.StatusOK, `{"error": {"code": "Base.1.0.ResourceMissingAtURI", "message": "Not found"}}`, nil)
+	notFound.StatusCode = http.StatusNotFound
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				notFound,
+				testResponse(http.StatusOK, legacyPowerBody, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	readings, err := result.Readings()
+	collectionError, ok := err.(*common.CollectionError)
+	if !ok {
+		t.Fatalf("Expected a CollectionError, got: %v", err)
+	}
+
+	if _, ok := collectionError.Failures["/redfish/v1/Chassis/1U/Thermal"]; !ok {
+		t.Errorf("Missing Thermal failure: %v", collectionError.Failures)
+	}
+
+	if len(readings.Power) != 1 {
+		t.Errorf("Unexpected power readings: %v", readings.Power)
+	}
+}
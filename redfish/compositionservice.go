@@ -6,7 +6,10 @@ package redfish
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
 
 	"github.com/bcohee/gofish/common"
 )
@@ -93,6 +96,99 @@ func GetCompositionService(c common.Client, uri string) (*CompositionService, er
 	return &compositionservice, compositionservice.Get(c, uri, &compositionservice)
 }
 
+// ResourceBlocks gets the resource blocks available for composition.
+func (compositionservice *CompositionService) ResourceBlocks() ([]*ResourceBlock, error) {
+	return ListReferencedResourceBlocks(compositionservice.Client, compositionservice.resourceBlocks)
+}
+
+// ResourceZones gets the zones grouping the resource blocks that can be
+// composed together.
+func (compositionservice *CompositionService) ResourceZones() ([]*Zone, error) {
+	return ListReferencedZones(compositionservice.Client, compositionservice.resourceZones)
+}
+
+// ComposeSystem requests a specific composition: a computer system named
+// name is created in the systems collection from the given resource blocks.
+// The blocks are checked first, a ResourceBlocksUnavailableError listing
+// the ones that are reserved or unavailable is returned if any cannot be
+// used. It returns the link to the new system.
+func (compositionservice *CompositionService) ComposeSystem(systemCollection, name string, blocks []*ResourceBlock) (string, error) {
+	if strings.TrimSpace(systemCollection) == "" {
+		return "", fmt.Errorf("systems collection uri should not be empty")
+	}
+	if len(blocks) == 0 {
+		return "", fmt.Errorf("at least one resource block must be supplied")
+	}
+
+	err := CheckResourceBlocksAvailable(blocks)
+	if err != nil {
+		return "", err
+	}
+
+	links := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		links = append(links, block.ODataID)
+	}
+
+	t := struct {
+		Name  string `json:",omitempty"`
+		Links struct {
+			ResourceBlocks []odataLink
+		}
+	}{Name: name}
+	t.Links.ResourceBlocks = odataLinks(links)
+
+	return composeSystem(compositionservice.Client, systemCollection, t)
+}
+
+// ComposeConstrainedSystem requests a constrained composition: the service
+// chooses resource blocks of the zone fulfilling the request, which holds
+// the properties of the wanted computer system as described by the
+// capabilities object the zone advertises. It returns the link to the new
+// system.
+func (compositionservice *CompositionService) ComposeConstrainedSystem(zone *Zone, request interface{}) (string, error) {
+	capability := zone.CollectionCapabilities.Capability(common.ComputerSystemConstrainedCompositionCapabilityUseCase)
+	if capability == nil || capability.TargetCollection == "" {
+		return "", fmt.Errorf("zone %s does not support constrained composition", zone.ID)
+	}
+
+	return composeSystem(compositionservice.Client, capability.TargetCollection, request)
+}
+
+// DecomposeSystem deletes a composed computer system, freeing its resource
+// blocks.
+func (compositionservice *CompositionService) DecomposeSystem(uri string) error {
+	// validate uri
+	if strings.TrimSpace(uri) == "" {
+		return fmt.Errorf("uri should not be empty")
+	}
+
+	resp, err := compositionservice.Client.Delete(uri)
+	if err == nil {
+		defer resp.Body.Close()
+	}
+
+	return err
+}
+
+// composeSystem posts a composition request and returns the link to the new
+// system.
+func composeSystem(c common.Client, uri string, payload interface{}) (string, error) {
+	resp, err := c.Post(uri, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// return the system link from returned location
+	systemLink := resp.Header.Get("Location")
+	if urlParser, err := url.ParseRequestURI(systemLink); err == nil {
+		systemLink = urlParser.RequestURI()
+	}
+
+	return systemLink, nil
+}
+
 // ListReferencedCompositionServices gets the collection of CompositionService from
 // a provided reference.
 func ListReferencedCompositionServices(c common.Client, link string) ([]*CompositionService, error) { //nolint:dupl
@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -110,3 +111,141 @@ func TestCompositionServiceUpdate(t *testing.T) {
 		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
 	}
 }
+
+var resourceZoneBody = `{
+		"@odata.id": "/redfish/v1/CompositionService/ResourceZones/1",
+		"@odata.type": "#Zone.v1_6_1.Zone",
+		"Id": "1",
+		"Name": "Resource Zone 1",
+		"Links": {
+			"ResourceBlocks": [
+				{
+					"@odata.id": "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1"
+				}
+			]
+		},
+		"@Redfish.CollectionCapabilities": {
+			"@odata.type": "#CollectionCapabilities.v1_4_0.CollectionCapabilities",
+			"Capabilities": [
+				{
+					"CapabilitiesObject": {
+						"@odata.id": "/redfish/v1/Systems/Capabilities"
+					},
+					"UseCase": "ComputerSystemConstrainedComposition",
+					"Links": {
+						"TargetCollection": {
+							"@odata.id": "/redfish/v1/Systems"
+						}
+					}
+				}
+			]
+		}
+	}`
+
+// TestCompositionServiceComposeSystem tests a specific composition.
+func TestCompositionServiceComposeSystem(t *testing.T) {
+	var result CompositionService
+	err := json.NewDecoder(strings.NewReader(compositionServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	var block ResourceBlock
+	err = json.NewDecoder(strings.NewReader(resourceBlockBody)).Decode(&block)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	resp := testResponse(http.StatusOK, "", nil)
+	resp.StatusCode = http.StatusCreated
+	resp.Header = http.Header{}
+	resp.Header.Set("Location", "/redfish/v1/Systems/NewSystem")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {resp},
+		},
+	}
+	result.SetClient(testClient)
+
+	link, err := result.ComposeSystem("/redfish/v1/Systems", "NewSystem", []*ResourceBlock{&block})
+	if err != nil {
+		t.Fatalf("Error composing system: %s", err)
+	}
+
+	if link != "/redfish/v1/Systems/NewSystem" {
+		t.Errorf("Invalid link to the new system: %s", link)
+	}
+
+	calls := testClient.CapturedCalls()
+	expected := "map[Links:map[ResourceBlocks:[map[@odata.id:/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1]]] Name:NewSystem]"
+	if calls[0].URL != "/redfish/v1/Systems" || calls[0].Payload != expected {
+		t.Errorf("Unexpected compose call: %v", calls[0])
+	}
+
+	block.CompositionStatus.Reserved = true
+	_, err = result.ComposeSystem("/redfish/v1/Systems", "NewSystem", []*ResourceBlock{&block})
+	if _, ok := err.(*ResourceBlocksUnavailableError); !ok {
+		t.Errorf("Reserved blocks should be reported: %v", err)
+	}
+
+	if len(testClient.CapturedCalls()) != 1 {
+		t.Error("No composition should be requested with reserved blocks")
+	}
+
+	err = result.DecomposeSystem(link)
+	if err != nil {
+		t.Errorf("Error decomposing system: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if calls[1].Action != http.MethodDelete || calls[1].URL != link {
+		t.Errorf("Unexpected decompose call: %v", calls[1])
+	}
+}
+
+// TestCompositionServiceComposeConstrainedSystem tests a constrained
+// composition.
+func TestCompositionServiceComposeConstrainedSystem(t *testing.T) {
+	var result CompositionService
+	err := json.NewDecoder(strings.NewReader(compositionServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	var zone Zone
+	err = json.NewDecoder(strings.NewReader(resourceZoneBody)).Decode(&zone)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if zone.resourceBlocks[0] != "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1" {
+		t.Errorf("Invalid ResourceBlocks links: %v", zone.resourceBlocks)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	request := map[string]interface{}{
+		"Name":             "Constrained",
+		"ProcessorSummary": map[string]int{"Count": 2},
+	}
+	_, err = result.ComposeConstrainedSystem(&zone, request)
+	if err != nil {
+		t.Fatalf("Error composing system: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Systems" || !strings.Contains(calls[0].Payload, "ProcessorSummary:map[Count:2]") {
+		t.Errorf("Unexpected compose call: %v", calls[0])
+	}
+
+	zone.CollectionCapabilities.Capabilities = nil
+	_, err = result.ComposeConstrainedSystem(&zone, request)
+	if err == nil {
+		t.Error("Zones without constrained composition capability should be rejected")
+	}
+}
@@ -536,6 +536,8 @@ type ComputerSystem struct {
 	UUID string
 	// Chassis is an array of references to the chassis in which this system is contained.
 	chassis []string
+	// resourceBlocks are the resource blocks this system is composed from.
+	resourceBlocks []string
 	// resetTarget is the internal URL to send reset targets to.
 	resetTarget string
 	// SupportedResetTypes, if provided, is the reset types this system supports.
@@ -607,6 +609,7 @@ func (computersystem *ComputerSystem) UnmarshalJSON(b []byte) error {
 	computersystem.pcieDevices = t.PCIeDevices.ToStrings()
 	computersystem.pcieFunctions = t.PCIeFunctions.ToStrings()
 	computersystem.chassis = t.Links.Chassis.ToStrings()
+	computersystem.resourceBlocks = t.Links.ResourceBlocks.ToStrings()
 	computersystem.resetTarget = t.Actions.ComputerSystemReset.Target
 	computersystem.SupportedResetTypes = t.Actions.ComputerSystemReset.AllowedResetTypes
 	computersystem.setDefaultBootOrderTarget = t.Actions.SetDefaultBootOrder.Target
@@ -638,7 +641,20 @@ func (computersystem *ComputerSystem) Update() error {
 
 	readWriteFields := []string{
 		"AssetTag",
+		"Boot.AliasBootOrder",
+		"Boot.AutomaticRetryAttempts",
+		"Boot.AutomaticRetryConfig",
+		"Boot.BootNext",
+		"Boot.BootOrder",
+		"Boot.BootOrderPropertySelection",
+		"Boot.BootSourceOverrideEnabled",
+		"Boot.BootSourceOverrideMode",
+		"Boot.BootSourceOverrideTarget",
+		"Boot.UefiTargetBootSourceOverride",
 		"HostName",
+		"HostWatchdogTimer.FunctionEnabled",
+		"HostWatchdogTimer.TimeoutAction",
+		"HostWatchdogTimer.WarningAction",
 		"IndicatorLED",
 		"PowerRestorePolicy",
 	}
@@ -824,6 +840,27 @@ func (computersystem *ComputerSystem) Processors() ([]*Processor, error) {
 	return ListReferencedProcessors(computersystem.Client, computersystem.processors)
 }
 
+// ResourceBlocks gets the resource blocks this system is composed from.
+func (computersystem *ComputerSystem) ResourceBlocks() ([]*ResourceBlock, error) {
+	var result []*ResourceBlock
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range computersystem.resourceBlocks {
+		item, err := GetResourceBlock(computersystem.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
 // SecureBoot gets the secure boot information for the system.
 func (computersystem *ComputerSystem) SecureBoot() (*SecureBoot, error) {
 	if computersystem.secureBoot == "" {
@@ -848,7 +885,19 @@ func (computersystem *ComputerSystem) SetBoot(b Boot) error { //nolint
 // 4-second hold of the Power Button). The ForceRestart value shall perform a
 // ForceOff action followed by a On action.
 func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
-	// Make sure the requested reset type is supported by the system
+	if err := computersystem.checkResetType(resetType); err != nil {
+		return err
+	}
+
+	t := struct {
+		ResetType ResetType
+	}{ResetType: resetType}
+
+	return computersystem.Post(computersystem.resetTarget, t)
+}
+
+// checkResetType makes sure the requested reset type is supported.
+func (computersystem *ComputerSystem) checkResetType(resetType ResetType) error {
 	valid := false
 	if len(computersystem.SupportedResetTypes) > 0 {
 		for _, allowed := range computersystem.SupportedResetTypes {
@@ -867,11 +916,26 @@ func (computersystem *ComputerSystem) Reset(resetType ResetType) error {
 			resetType)
 	}
 
+	return nil
+}
+
+// ResetWithTask shall perform a reset of the ComputerSystem and return a
+// TaskMonitor to follow the reset when the service completes it
+// asynchronously.
+func (computersystem *ComputerSystem) ResetWithTask(resetType ResetType) (*TaskMonitor, error) {
+	if err := computersystem.checkResetType(resetType); err != nil {
+		return nil, err
+	}
+
 	t := struct {
 		ResetType ResetType
 	}{ResetType: resetType}
 
-	return computersystem.Post(computersystem.resetTarget, t)
+	resp, err := computersystem.PostWithResponse(computersystem.resetTarget, t)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskMonitor(computersystem.Client, resp)
 }
 
 // UpdateBootAttributesApplyAt is used to update attribute values and set apply time together
@@ -931,7 +995,7 @@ func (computersystem *ComputerSystem) UpdateBootAttributes(attrs SettingsAttribu
 func (computersystem *ComputerSystem) SetDefaultBootOrder() error {
 	// This action wasn't added until 1.5.0, make sure this is supported.
 	if computersystem.setDefaultBootOrderTarget == "" {
-		return fmt.Errorf("SetDefaultBootOrder is not supported by this system") //nolint:golint
+		return common.NewActionNotSupportedError("SetDefaultBootOrder is not supported by this system") //nolint:golint
 	}
 
 	return computersystem.Post(computersystem.setDefaultBootOrderTarget, nil)
@@ -354,3 +354,42 @@ func TestBootOption(t *testing.T) {
 		t.Errorf("Received invalid uefidevicepath: %s", result.UefiDevicePath)
 	}
 }
+
+// TestComputerSystemUpdateBoot tests updating the boot settings.
+func TestComputerSystemUpdateBoot(t *testing.T) {
+	var result ComputerSystem
+	err := json.NewDecoder(strings.NewReader(computerSystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.Boot.BootSourceOverrideTarget = HddBootSourceOverrideTarget
+	result.HostWatchdogTimer.FunctionEnabled = true
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "Boot:map[BootSourceOverrideTarget:Hdd]") {
+		t.Errorf("Unexpected Boot update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "HostWatchdogTimer:map[FunctionEnabled:true]") {
+		t.Errorf("Unexpected HostWatchdogTimer update payload: %s", calls[0].Payload)
+	}
+
+	result.Boot.BootSourceOverrideEnabled = ContinuousBootSourceOverrideEnabled
+	result.BIOSVersion = "1.0"
+	err = result.Update()
+
+	if err == nil || err.Error() != "BiosVersion field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+}
@@ -461,3 +461,14 @@ func (drive *Drive) PCIeFunctions() ([]*PCIeFunction, error) {
 func (drive *Drive) SecureErase() error {
 	return drive.Post(drive.secureEraseTarget, nil)
 }
+
+// SecureEraseWithTask shall perform a type of erase of the drive and return
+// a TaskMonitor to follow the erase when the service completes it
+// asynchronously.
+func (drive *Drive) SecureEraseWithTask() (*TaskMonitor, error) {
+	resp, err := drive.PostWithResponse(drive.secureEraseTarget, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskMonitor(drive.Client, resp)
+}
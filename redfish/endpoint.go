@@ -150,13 +150,13 @@ type Endpoint struct {
 	PortsCount int
 	// addressPools shall contain an array of links to
 	// resources of type AddressPool with which this endpoint is associated.
-	// addressPools []string
+	addressPools []string
 	// AddressPoolsCount is the number of AddressPools.
 	AddressPoolsCount int
 	// connectedPorts shall contain an array of links to
 	// resources of type Port that represent ports associated with this
 	// endpoint.
-	// connectedPorts []string
+	connectedPorts []string
 	// ConnectedPortCount is the number of ConnectedPorts.
 	ConnectedPortsCount int
 }
@@ -211,10 +211,87 @@ func (endpoint *Endpoint) UnmarshalJSON(b []byte) error {
 	endpoint.NetworkDeviceFunctionCount = t.Links.NetworkDeviceFunctionCount
 	endpoint.ports = t.Links.Ports.ToStrings()
 	endpoint.PortsCount = t.Links.PortsCount
+	endpoint.addressPools = t.Links.AddressPools.ToStrings()
+	endpoint.AddressPoolsCount = t.Links.AddressPoolsCount
+	endpoint.connectedPorts = t.Links.ConnectedPorts.ToStrings()
+	endpoint.ConnectedPortsCount = t.Links.ConnectedPortsCount
 
 	return nil
 }
 
+// Fabric gets the fabric this endpoint belongs to. It returns nil if the
+// endpoint is not part of a fabric.
+func (endpoint *Endpoint) Fabric() (*Fabric, error) {
+	link := fabricLink(endpoint.ODataID)
+	if link == "" {
+		return nil, nil
+	}
+	return GetFabric(endpoint.Client, link)
+}
+
+// Ports gets the ports utilized by this endpoint.
+func (endpoint *Endpoint) Ports() ([]*Port, error) {
+	var result []*Port
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range endpoint.ports {
+		item, err := GetPort(endpoint.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ConnectedPorts gets the ports associated with this endpoint.
+func (endpoint *Endpoint) ConnectedPorts() ([]*Port, error) {
+	var result []*Port
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range endpoint.connectedPorts {
+		item, err := GetPort(endpoint.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// AddressPools gets the address pools with which this endpoint is associated.
+func (endpoint *Endpoint) AddressPools() ([]*AddressPool, error) {
+	var result []*AddressPool
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range endpoint.addressPools {
+		item, err := GetAddressPool(endpoint.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
 // GetEndpoint will get a Endpoint instance from the service.
 func GetEndpoint(c common.Client, uri string) (*Endpoint, error) {
 	var endpoint Endpoint
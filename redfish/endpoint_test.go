@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -148,3 +149,53 @@ func TestEndpoint(t *testing.T) {
 		t.Errorf("Received durable name format: %s", result.Identifiers[0].DurableNameFormat)
 	}
 }
+
+// TestEndpointFabric tests getting the fabric an endpoint belongs to.
+func TestEndpointFabric(t *testing.T) {
+	var result Endpoint
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Endpoints/Host1",
+		"Id": "Host1",
+		"Links": {
+			"ConnectedPorts": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Switches/1/Ports/1"
+				}
+			],
+			"ConnectedPorts@odata.count": 1
+		}
+	}`)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.connectedPorts[0] != "/redfish/v1/Fabrics/NVMeoF/Switches/1/Ports/1" || result.ConnectedPortsCount != 1 {
+		t.Errorf("Invalid ConnectedPorts links: %v", result.connectedPorts)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {testResponse(http.StatusOK, fabricBody, nil)},
+		},
+	}
+	result.SetClient(testClient)
+
+	fabric, err := result.Fabric()
+	if err != nil {
+		t.Fatalf("Error getting fabric: %s", err)
+	}
+
+	if fabric.ID != "NVMeoF" {
+		t.Errorf("Unexpected fabric: %s", fabric.ID)
+	}
+
+	if calls := testClient.CapturedCalls(); calls[0].URL != "/redfish/v1/Fabrics/NVMeoF" {
+		t.Errorf("Unexpected fabric URL: %s", calls[0].URL)
+	}
+
+	result.ODataID = "/redfish/v1/Chassis/1/Endpoints/1"
+	if fabric, err := result.Fabric(); fabric != nil || err != nil {
+		t.Errorf("Endpoints outside of fabrics should have no fabric: %v %v", fabric, err)
+	}
+}
@@ -0,0 +1,90 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// EnvironmentMetrics shall represent the environmental metrics of a device,
+// such as the temperature, humidity and power consumption of a chassis.
+type EnvironmentMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DewPointCelsius shall contain the dew point, in degree Celsius units,
+	// based on the temperature and humidity values for this resource.
+	DewPointCelsius SensorExcerpt
+	// EnergykWh shall contain the total energy, in kilowatt-hour units, for
+	// this resource.
+	EnergykWh SensorEnergykWhExcerpt
+	// FanSpeedsPercent shall contain the fan speeds, in percent units, for
+	// this resource.
+	FanSpeedsPercent []SensorFanArrayExcerpt
+	// HumidityPercent shall contain the humidity, in percent units, for this
+	// resource.
+	HumidityPercent SensorExcerpt
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PowerLoadPercent shall contain the power load, in percent units, for
+	// this device that represents the total power of the device.
+	PowerLoadPercent SensorExcerpt
+	// PowerWatts shall contain the total power, in watt units, for this
+	// resource.
+	PowerWatts SensorPowerExcerpt
+	// TemperatureCelsius shall contain the temperature, in degree Celsius
+	// units, for this resource.
+	TemperatureCelsius SensorExcerpt
+	// resetMetricsTarget is the URL to send ResetMetrics requests.
+	resetMetricsTarget string
+}
+
+// UnmarshalJSON unmarshals an EnvironmentMetrics object from the raw JSON.
+func (environmentmetrics *EnvironmentMetrics) UnmarshalJSON(b []byte) error {
+	type temp EnvironmentMetrics
+	type actions struct {
+		ResetMetrics struct {
+			Target string
+		} `json:"#EnvironmentMetrics.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions actions
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*environmentmetrics = EnvironmentMetrics(t.temp)
+	environmentmetrics.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	return nil
+}
+
+// ResetMetrics shall reset any time intervals or counted values for this
+// device.
+func (environmentmetrics *EnvironmentMetrics) ResetMetrics() error {
+	if environmentmetrics.resetMetricsTarget == "" {
+		return common.NewActionNotSupportedError("ResetMetrics is not supported by this resource")
+	}
+	return environmentmetrics.Post(environmentmetrics.resetMetricsTarget, struct{}{})
+}
+
+// GetEnvironmentMetrics will get an EnvironmentMetrics instance from the
+// service.
+func GetEnvironmentMetrics(c common.Client, uri string) (*EnvironmentMetrics, error) {
+	var environmentMetrics EnvironmentMetrics
+	return &environmentMetrics, environmentMetrics.Get(c, uri, &environmentMetrics)
+}
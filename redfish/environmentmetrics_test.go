@@ -0,0 +1,100 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var environmentMetricsBody = `{
+		"@odata.type": "#EnvironmentMetrics.v1_3_0.EnvironmentMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1U/EnvironmentMetrics",
+		"Id": "EnvironmentMetrics",
+		"Name": "Chassis Environment Metrics",
+		"TemperatureCelsius": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/AmbientTemp",
+			"Reading": 25.4
+		},
+		"PowerWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/TotalPower",
+			"Reading": 374,
+			"PowerFactor": 0.98
+		},
+		"EnergykWh": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/TotalEnergy",
+			"Reading": 36166,
+			"LifetimeReading": 42000
+		},
+		"FanSpeedsPercent": [
+			{
+				"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/FanBay1",
+				"DeviceName": "Fan Bay 1",
+				"PhysicalContext": "Fan",
+				"Reading": 45,
+				"SpeedRPM": 2200
+			}
+		],
+		"Actions": {
+			"#EnvironmentMetrics.ResetMetrics": {
+				"target": "/redfish/v1/Chassis/1U/EnvironmentMetrics/Actions/EnvironmentMetrics.ResetMetrics"
+			}
+		}
+	}`
+
+// TestEnvironmentMetrics tests the parsing of EnvironmentMetrics objects.
+func TestEnvironmentMetrics(t *testing.T) {
+	var result EnvironmentMetrics
+	err := json.NewDecoder(strings.NewReader(environmentMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.TemperatureCelsius.Reading != 25.4 {
+		t.Errorf("Invalid TemperatureCelsius: %v", result.TemperatureCelsius)
+	}
+
+	if result.PowerWatts.Reading != 374 || result.PowerWatts.PowerFactor != 0.98 {
+		t.Errorf("Invalid PowerWatts: %v", result.PowerWatts)
+	}
+
+	if result.EnergykWh.LifetimeReading != 42000 {
+		t.Errorf("Invalid EnergykWh: %v", result.EnergykWh)
+	}
+
+	fan := result.FanSpeedsPercent[0]
+	if fan.DeviceName != "Fan Bay 1" || fan.PhysicalContext != common.FanPhysicalContext || fan.SpeedRPM != 2200 {
+		t.Errorf("Invalid FanSpeedsPercent: %v", result.FanSpeedsPercent)
+	}
+}
+
+// TestEnvironmentMetricsResetMetrics tests the ResetMetrics call.
+func TestEnvironmentMetricsResetMetrics(t *testing.T) {
+	var result EnvironmentMetrics
+	err := json.NewDecoder(strings.NewReader(environmentMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ResetMetrics()
+
+	if err != nil {
+		t.Errorf("Error making ResetMetrics call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/Chassis/1U/EnvironmentMetrics/Actions/EnvironmentMetrics.ResetMetrics" {
+		t.Errorf("Unexpected ResetMetrics URL: %s", calls[0].URL)
+	}
+}
@@ -261,14 +261,24 @@ func (ethernetinterface *EthernetInterface) Update() error {
 
 	readWriteFields := []string{
 		"AutoNeg",
+		"DHCPv4",
+		"DHCPv6",
 		"FQDN",
 		"FullDuplex",
 		"HostName",
+		"IPv4StaticAddresses.Address",
+		"IPv4StaticAddresses.Gateway",
+		"IPv4StaticAddresses.SubnetMask",
+		"IPv6AddressPolicyTable",
+		"IPv6StaticAddresses",
+		"IPv6StaticDefaultGateways",
 		"InterfaceEnabled",
 		"MACAddress",
 		"MTUSize",
 		"SpeedMbps",
+		"StatelessAddressAutoConfig",
 		"StaticNameServers",
+		"VLAN",
 	}
 
 	originalElement := reflect.ValueOf(original).Elem()
@@ -238,3 +238,46 @@ func TestEthernetInterfaceIPv6(t *testing.T) {
 		t.Errorf("The 3nd IPv6 address's prefix length should be 128, got: %d", result.IPv6Addresses[1].PrefixLength)
 	}
 }
+
+// TestEthernetInterfaceUpdateStaticAddresses tests updating the static
+// addresses of the interface.
+func TestEthernetInterfaceUpdateStaticAddresses(t *testing.T) {
+	var result EthernetInterface
+	err := json.NewDecoder(strings.NewReader(ethernetInterfaceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.IPv4StaticAddresses = append(result.IPv4StaticAddresses, IPv4Address{
+		Address:    "192.168.0.10",
+		Gateway:    "192.168.0.1",
+		SubnetMask: "255.255.255.0",
+	})
+	result.DHCPv4.DHCPEnabled = true
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "IPv4StaticAddresses:[map[Address:192.168.0.10 Gateway:192.168.0.1 SubnetMask:255.255.255.0]]") {
+		t.Errorf("Unexpected IPv4StaticAddresses update payload: %s", calls[0].Payload)
+	}
+
+	if !strings.Contains(calls[0].Payload, "DHCPv4:map[DHCPEnabled:true]") {
+		t.Errorf("Unexpected DHCPv4 update payload: %s", calls[0].Payload)
+	}
+
+	result.IPv4StaticAddresses[0].AddressOrigin = StaticIPv4AddressOrigin
+	err = result.Update()
+
+	if err == nil || err.Error() != "IPv4StaticAddresses.AddressOrigin field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+}
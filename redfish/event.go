@@ -0,0 +1,125 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// Event is used to represent an event notification sent by a Redfish
+// service, either through a push subscription or a Server-Sent Event stream.
+type Event struct {
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// ID uniquely identifies the resource.
+	ID string `json:"Id"`
+	// Name is the name of the resource or array element.
+	Name string
+	// Context shall contain a client supplied context for the event
+	// destination to which this event is being sent.
+	Context string
+	// Description provides a description of this resource.
+	Description string
+	// Events shall contain an array of objects that represent the occurrence
+	// of one or more events.
+	Events []EventRecord
+	// EventsCount is the number of events in the Events array.
+	EventsCount int `json:"Events@odata.count"`
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+}
+
+// EventRecord shall represent a single event.
+type EventRecord struct {
+	// Context shall contain a client supplied context for the event
+	// destination to which this event is being sent. This property was
+	// deprecated in favor of the top-level Context property of the Event.
+	Context string
+	// EventGroupID shall indicate that events are related and shall have the
+	// same value in the case where multiple event messages are produced by the
+	// same root cause.
+	EventGroupID int `json:"EventGroupId"`
+	// EventID shall contain a service-defined unique identifier for the event.
+	EventID string `json:"EventId"`
+	// EventTimestamp shall indicate the time the event occurred.
+	EventTimestamp string
+	// EventType shall indicate the type of event.
+	EventType EventType
+	// MemberID shall uniquely identify the member within the collection.
+	MemberID string `json:"MemberId"`
+	// Message shall contain a human-readable event message.
+	Message string
+	// MessageArgs shall contain an array of message arguments that are
+	// substituted for the arguments in the message when looked up in the
+	// message registry.
+	MessageArgs []string
+	// MessageID shall contain a MessageId, as defined in the Redfish
+	// specification.
+	MessageID string `json:"MessageId"`
+	// MessageSeverity shall contain the severity of the message.
+	MessageSeverity common.Health
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// OriginOfCondition shall contain a link to the resource or object that
+	// originated the condition that caused the event to be generated.
+	OriginOfCondition string
+	// Severity shall contain the severity of the event, as defined in the
+	// Status section of the Redfish specification.
+	Severity string
+}
+
+// UnmarshalJSON unmarshals an EventRecord object from the raw JSON.
+func (eventrecord *EventRecord) UnmarshalJSON(b []byte) error {
+	type temp EventRecord
+	var t struct {
+		temp
+		OriginOfCondition common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*eventrecord = EventRecord(t.temp)
+	eventrecord.OriginOfCondition = t.OriginOfCondition.String()
+
+	return nil
+}
+
+// isMetricReportPayload reports whether the raw JSON describes a MetricReport
+// rather than an Event.
+func isMetricReportPayload(b []byte) bool {
+	var t struct {
+		ODataType string `json:"@odata.type"`
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return false
+	}
+	return strings.HasPrefix(t.ODataType, "#MetricReport.")
+}
+
+// decodeEventPayload decodes a notification payload into either an Event or a
+// MetricReport, depending on its @odata.type.
+func decodeEventPayload(b []byte) (*Event, *MetricReport, error) {
+	if isMetricReportPayload(b) {
+		var report MetricReport
+		if err := json.Unmarshal(b, &report); err != nil {
+			return nil, nil, err
+		}
+		return nil, &report, nil
+	}
+
+	var event Event
+	if err := json.Unmarshal(b, &event); err != nil {
+		return nil, nil, err
+	}
+	return &event, nil, nil
+}
@@ -0,0 +1,258 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxEventPayloadSize limits how much of a request body the listener reads.
+const maxEventPayloadSize = 10 << 20
+
+// EventHandlerFunc is called for every event record received by an
+// EventListener.
+type EventHandlerFunc func(event *Event, record *EventRecord)
+
+// MetricReportHandlerFunc is called for every metric report received by an
+// EventListener.
+type MetricReportHandlerFunc func(report *MetricReport)
+
+// EventListener is an http.Handler that receives the events a Redfish service
+// pushes to an EventDestination and dispatches them to registered handlers.
+type EventListener struct {
+	// Context is the client supplied string set on the subscription. When it
+	// is not empty, payloads carrying a different context are rejected.
+	Context string
+	// ErrorHandler is optionally called when a received payload cannot be
+	// handled.
+	ErrorHandler func(err error)
+
+	mu             sync.RWMutex
+	handlers       map[EventType][]EventHandlerFunc
+	allHandlers    []EventHandlerFunc
+	reportHandlers []MetricReportHandlerFunc
+}
+
+// NewEventListener creates a new listener for events sent with the given
+// subscription context.
+func NewEventListener(eventContext string) *EventListener {
+	return &EventListener{
+		Context:  eventContext,
+		handlers: make(map[EventType][]EventHandlerFunc),
+	}
+}
+
+// Handle registers a handler for event records of the given type.
+func (listener *EventListener) Handle(eventType EventType, handler EventHandlerFunc) {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	if listener.handlers == nil {
+		listener.handlers = make(map[EventType][]EventHandlerFunc)
+	}
+	listener.handlers[eventType] = append(listener.handlers[eventType], handler)
+}
+
+// HandleAll registers a handler for every event record, regardless of type.
+func (listener *EventListener) HandleAll(handler EventHandlerFunc) {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	listener.allHandlers = append(listener.allHandlers, handler)
+}
+
+// HandleMetricReports registers a handler for metric reports.
+func (listener *EventListener) HandleMetricReports(handler MetricReportHandlerFunc) {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	listener.reportHandlers = append(listener.reportHandlers, handler)
+}
+
+// ServeHTTP decodes an event payload posted by the service and dispatches it.
+func (listener *EventListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventPayloadSize))
+	if err != nil {
+		listener.reportError(err)
+		http.Error(w, "unable to read payload", http.StatusBadRequest)
+		return
+	}
+
+	event, report, err := decodeEventPayload(body)
+	if err != nil {
+		listener.reportError(fmt.Errorf("unable to decode event payload: %w", err))
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if report != nil {
+		if !listener.contextMatches(report.Context) {
+			listener.reportError(fmt.Errorf("metric report received with unexpected context %q", report.Context))
+			http.Error(w, "unexpected context", http.StatusForbidden)
+			return
+		}
+		listener.dispatchMetricReport(report)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	eventContext := event.Context
+	if eventContext == "" && len(event.Events) > 0 {
+		// Older services only set the deprecated per-record context
+		eventContext = event.Events[0].Context
+	}
+	if !listener.contextMatches(eventContext) {
+		listener.reportError(fmt.Errorf("event received with unexpected context %q", eventContext))
+		http.Error(w, "unexpected context", http.StatusForbidden)
+		return
+	}
+
+	listener.dispatchEvent(event)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// contextMatches checks a received context against the expected one.
+func (listener *EventListener) contextMatches(eventContext string) bool {
+	return listener.Context == "" || listener.Context == eventContext
+}
+
+// dispatchEvent calls the handlers registered for each record of the event.
+func (listener *EventListener) dispatchEvent(event *Event) {
+	listener.mu.RLock()
+	defer listener.mu.RUnlock()
+
+	for i := range event.Events {
+		record := &event.Events[i]
+		for _, handler := range listener.handlers[record.EventType] {
+			handler(event, record)
+		}
+		for _, handler := range listener.allHandlers {
+			handler(event, record)
+		}
+	}
+}
+
+// dispatchMetricReport calls the metric report handlers.
+func (listener *EventListener) dispatchMetricReport(report *MetricReport) {
+	listener.mu.RLock()
+	defer listener.mu.RUnlock()
+
+	for _, handler := range listener.reportHandlers {
+		handler(report)
+	}
+}
+
+func (listener *EventListener) reportError(err error) {
+	if listener.ErrorHandler != nil {
+		listener.ErrorHandler(err)
+	}
+}
+
+// EventListenerConfig holds the settings used by EventListener.Run.
+type EventListenerConfig struct {
+	// Address is the local address to listen on, for example ":8443".
+	Address string
+	// Destination is the URL the service should send events to. This is the
+	// address of the listener as reachable from the service.
+	Destination string
+	// EventTypes is the list of EventType to subscribe to.
+	EventTypes []EventType
+	// HTTPHeaders is optional and gives the opportunity to specify any
+	// arbitrary HTTP headers required for the event POST operation.
+	HTTPHeaders map[string]string
+	// TLSConfig enables HTTPS on the listener when set. It must contain the
+	// server certificate unless CertFile and KeyFile are given.
+	TLSConfig *tls.Config
+	// CertFile is the optional path to the listener's certificate.
+	CertFile string
+	// KeyFile is the optional path to the listener's private key.
+	KeyFile string
+	// ShutdownTimeout limits how long to wait for in-flight events when
+	// stopping. Defaults to 5 seconds.
+	ShutdownTimeout time.Duration
+}
+
+// Run starts receiving events on the configured address, creates an event
+// subscription pointing at it and blocks until ctx is done. The subscription
+// is then deleted and the listener shut down.
+func (listener *EventListener) Run(ctx context.Context, eventservice *EventService, config *EventListenerConfig) error {
+	if strings.TrimSpace(config.Destination) == "" {
+		return fmt.Errorf("empty destination is not valid")
+	}
+
+	ln, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		return err
+	}
+
+	useTLS := config.TLSConfig != nil || config.CertFile != ""
+	server := &http.Server{
+		Handler:           listener,
+		TLSConfig:         config.TLSConfig,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if useTLS {
+			serveErr <- server.ServeTLS(ln, config.CertFile, config.KeyFile)
+		} else {
+			serveErr <- server.Serve(ln)
+		}
+	}()
+
+	subscription, err := eventservice.CreateEventSubscription(
+		config.Destination,
+		config.EventTypes,
+		config.HTTPHeaders,
+		RedfishEventDestinationProtocol,
+		listener.Context,
+		nil,
+	)
+	if err != nil {
+		_ = listener.shutdown(server, config.ShutdownTimeout)
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+	}
+
+	deleteErr := eventservice.DeleteEventSubscription(subscription)
+	shutdownErr := listener.shutdown(server, config.ShutdownTimeout)
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if deleteErr != nil {
+		return deleteErr
+	}
+	return shutdownErr
+}
+
+// shutdown gracefully stops the server.
+func (listener *EventListener) shutdown(server *http.Server, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,176 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var eventPayloadBody = `{
+		"@odata.type": "#Event.v1_4_0.Event",
+		"Id": "1",
+		"Name": "Event Array",
+		"Context": "gofish-listener",
+		"Events": [
+			{
+				"EventType": "Alert",
+				"EventId": "4593",
+				"Severity": "Warning",
+				"MessageSeverity": "Warning",
+				"Message": "The LAN has been disconnected",
+				"MessageId": "Alert.1.0.LanDisconnect",
+				"MessageArgs": ["EthernetInterface 1", "/redfish/v1/Systems/1"],
+				"OriginOfCondition": {
+					"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"
+				}
+			},
+			{
+				"EventType": "StatusChange",
+				"EventId": "4594",
+				"MessageId": "ResourceEvent.1.0.ResourceStatusChangedWarning"
+			}
+		]
+	}`
+
+// TestEventListenerDispatch tests dispatching received events to handlers.
+func TestEventListenerDispatch(t *testing.T) {
+	listener := NewEventListener("gofish-listener")
+
+	var alerts, all []*EventRecord
+	listener.Handle(AlertEventType, func(event *Event, record *EventRecord) {
+		alerts = append(alerts, record)
+	})
+	listener.HandleAll(func(event *Event, record *EventRecord) {
+		all = append(all, record)
+	})
+
+	ts := httptest.NewServer(listener)
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", strings.NewReader(eventPayloadBody)) //nolint:noctx
+	if err != nil {
+		t.Fatalf("Error posting event: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected status code: %d", resp.StatusCode)
+	}
+
+	if len(alerts) != 1 || alerts[0].EventID != "4593" {
+		t.Errorf("Expected one alert record, got: %#v", alerts)
+	}
+
+	if len(all) != 2 {
+		t.Errorf("Expected two records, got: %d", len(all))
+	}
+
+	if alerts[0].OriginOfCondition != "/redfish/v1/Systems/1/EthernetInterfaces/1" {
+		t.Errorf("Invalid OriginOfCondition: %s", alerts[0].OriginOfCondition)
+	}
+}
+
+// TestEventListenerContextMismatch tests rejecting events for another
+// subscription.
+func TestEventListenerContextMismatch(t *testing.T) {
+	listener := NewEventListener("other-context")
+
+	var handled, errored bool
+	listener.HandleAll(func(event *Event, record *EventRecord) {
+		handled = true
+	})
+	listener.ErrorHandler = func(err error) {
+		errored = true
+	}
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(eventPayloadBody))
+	listener.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("Unexpected status code: %d", recorder.Code)
+	}
+
+	if handled || !errored {
+		t.Errorf("Event should have been rejected, handled: %t errored: %t", handled, errored)
+	}
+}
+
+// TestEventListenerMetricReport tests receiving a metric report.
+func TestEventListenerMetricReport(t *testing.T) {
+	listener := NewEventListener("")
+
+	var reports []*MetricReport
+	listener.HandleMetricReports(func(report *MetricReport) {
+		reports = append(reports, report)
+	})
+
+	body := `{"@odata.type": "#MetricReport.v1_3_0.MetricReport", "Id": "Power",
+		"MetricValues": [{"MetricId": "PowerConsumedWatts", "MetricValue": "100"}]}`
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	listener.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("Unexpected status code: %d", recorder.Code)
+	}
+
+	if len(reports) != 1 || reports[0].ID != "Power" {
+		t.Errorf("Expected one metric report, got: %#v", reports)
+	}
+}
+
+// TestEventListenerRun tests the subscription lifetime handling.
+func TestEventListenerRun(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	subscriptionURI := "/redfish/v1/EventService/Subscriptions/1"
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusCreated, "", http.Header{"Location": []string{subscriptionURI}}),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	listener := NewEventListener("gofish-listener")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err = listener.Run(ctx, &result, &EventListenerConfig{
+		Address:     "127.0.0.1:0",
+		Destination: "https://listener.example.com:8443/events",
+		EventTypes:  []EventType{AlertEventType},
+	})
+	if err != nil {
+		t.Errorf("Error running listener: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected a POST and a DELETE, got: %#v", calls)
+	}
+
+	if calls[0].Action != http.MethodPost || !strings.Contains(calls[0].Payload, "Context:gofish-listener") {
+		t.Errorf("Unexpected subscription request: %#v", calls[0])
+	}
+
+	if calls[1].Action != http.MethodDelete || calls[1].URL != subscriptionURI {
+		t.Errorf("Unexpected cleanup request: %#v", calls[1])
+	}
+}
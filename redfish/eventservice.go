@@ -217,6 +217,14 @@ func (eventservice *EventService) Update() error {
 	readWriteFields := []string{
 		"DeliveryRetryAttempts",
 		"DeliveryRetryIntervalSeconds",
+		"SMTP.Authentication",
+		"SMTP.ConnectionProtocol",
+		"SMTP.FromAddress",
+		"SMTP.Password",
+		"SMTP.Port",
+		"SMTP.ServerAddress",
+		"SMTP.ServiceEnabled",
+		"SMTP.Username",
 		"ServiceEnabled",
 	}
 
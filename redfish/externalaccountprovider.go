@@ -0,0 +1,122 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// AdditionalExternalAccountProvider is an external account provider listed
+// in the AdditionalExternalAccountProviders collection of the account
+// service.
+type AdditionalExternalAccountProvider struct {
+	common.Entity
+	ExternalAccountProvider
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AccountProviderType shall contain the type of external account
+	// provider to which this Service connects.
+	AccountProviderType AccountProviderTypes
+	// Description provides a description of this resource.
+	Description string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals an AdditionalExternalAccountProvider object from
+// the raw JSON.
+func (provider *AdditionalExternalAccountProvider) UnmarshalJSON(b []byte) error {
+	type temp AdditionalExternalAccountProvider
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*provider = AdditionalExternalAccountProvider(t.temp)
+
+	// This is a read/write object, so we need to save the raw object data for later
+	provider.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (provider *AdditionalExternalAccountProvider) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(AdditionalExternalAccountProvider)
+	err := original.UnmarshalJSON(provider.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := externalAccountProviderFields("")
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(provider).Elem()
+
+	return provider.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetAdditionalExternalAccountProvider will get an
+// AdditionalExternalAccountProvider instance from the service.
+func GetAdditionalExternalAccountProvider(c common.Client, uri string) (*AdditionalExternalAccountProvider, error) {
+	var provider AdditionalExternalAccountProvider
+	return &provider, provider.Get(c, uri, &provider)
+}
+
+// ListReferencedAdditionalExternalAccountProviders gets the collection of
+// AdditionalExternalAccountProvider from a provided reference.
+func ListReferencedAdditionalExternalAccountProviders(c common.Client, link string) ([]*AdditionalExternalAccountProvider, error) { //nolint:dupl
+	var result []*AdditionalExternalAccountProvider
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *AdditionalExternalAccountProvider
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		provider, err := GetAdditionalExternalAccountProvider(c, link)
+		ch <- GetResult{Item: provider, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
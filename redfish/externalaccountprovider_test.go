@@ -0,0 +1,123 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var additionalExternalAccountProviderBody = `{
+		"@odata.id": "/redfish/v1/AccountService/ExternalAccountProviders/TACACS",
+		"@odata.type": "#ExternalAccountProvider.v1_0_0.ExternalAccountProvider",
+		"Id": "TACACS",
+		"Name": "TACACS+ Provider",
+		"AccountProviderType": "OEM",
+		"Authentication": {
+			"AuthenticationType": "UsernameAndPassword",
+			"Username": "gofish"
+		},
+		"ServiceAddresses": ["tacacs.example.com"],
+		"ServiceEnabled": false
+	}`
+
+// TestAdditionalExternalAccountProvider tests the parsing of
+// AdditionalExternalAccountProvider objects.
+func TestAdditionalExternalAccountProvider(t *testing.T) {
+	var result AdditionalExternalAccountProvider
+	err := json.NewDecoder(strings.NewReader(additionalExternalAccountProviderBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "TACACS" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.AccountProviderType != OEMAccountProviderTypes {
+		t.Errorf("Received invalid account provider type: %s", result.AccountProviderType)
+	}
+
+	if result.Authentication.Username != "gofish" {
+		t.Errorf("Received invalid user name: %s", result.Authentication.Username)
+	}
+
+	if len(result.ServiceAddresses) != 1 || result.ServiceAddresses[0] != "tacacs.example.com" {
+		t.Errorf("Received invalid service addresses: %v", result.ServiceAddresses)
+	}
+}
+
+// TestAdditionalExternalAccountProviderUpdate tests updating an external
+// account provider of the account service.
+func TestAdditionalExternalAccountProviderUpdate(t *testing.T) {
+	var service AccountService
+	err := json.NewDecoder(strings.NewReader(`{
+		"@odata.id": "/redfish/v1/AccountService",
+		"Id": "AccountService",
+		"AdditionalExternalAccountProviders": {
+			"@odata.id": "/redfish/v1/AccountService/ExternalAccountProviders"
+		}
+	}`)).Decode(&service)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{
+					"Members@odata.count": 1,
+					"Members": [
+						{"@odata.id": "/redfish/v1/AccountService/ExternalAccountProviders/TACACS"}
+					]
+				}`, nil),
+				testResponse(http.StatusOK, additionalExternalAccountProviderBody, nil),
+			},
+		},
+	}
+	service.SetClient(testClient)
+
+	providers, err := service.AdditionalExternalAccountProviders()
+	if err != nil {
+		t.Fatalf("Error getting external account providers: %s", err)
+	}
+
+	if len(providers) != 1 {
+		t.Fatalf("Expected 1 external account provider, got %d", len(providers))
+	}
+
+	provider := providers[0]
+	provider.ServiceEnabled = true
+	provider.Authentication.Password = "secret"
+	err = provider.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	patch := calls[len(calls)-1]
+
+	if patch.Action != http.MethodPatch || patch.URL != "/redfish/v1/AccountService/ExternalAccountProviders/TACACS" {
+		t.Errorf("Unexpected update request: %#v", patch)
+	}
+
+	if patch.Payload != "map[Authentication:map[Password:secret] ServiceEnabled:true]" {
+		t.Errorf("Unexpected update payload: %s", patch.Payload)
+	}
+
+	provider.AccountProviderType = LDAPServiceAccountProviderTypes
+	err = provider.Update()
+
+	if err == nil || err.Error() != "AccountProviderType field is read only" {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+}
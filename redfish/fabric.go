@@ -0,0 +1,177 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// Fabric represents a simple fabric consisting of one or more switches,
+// zero or more endpoints, and zero or more zones.
+type Fabric struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FabricType shall contain the type of fabric being represented by this
+	// simple fabric.
+	FabricType common.Protocol
+	// MaxZones shall contain the maximum number of zones the switch can
+	// currently configure.
+	MaxZones int
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// addressPools is the link to the collection of address pools.
+	addressPools string
+	// endpoints is the link to the collection of endpoints.
+	endpoints string
+	// switches is the link to the collection of switches.
+	switches string
+	// zones is the link to the collection of zones.
+	zones string
+}
+
+// UnmarshalJSON unmarshals a Fabric object from the raw JSON.
+func (fabric *Fabric) UnmarshalJSON(b []byte) error {
+	type temp Fabric
+	var t struct {
+		temp
+		AddressPools common.Link
+		Endpoints    common.Link
+		Switches     common.Link
+		Zones        common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*fabric = Fabric(t.temp)
+
+	// Extract the links to other entities for later
+	fabric.addressPools = t.AddressPools.String()
+	fabric.endpoints = t.Endpoints.String()
+	fabric.switches = t.Switches.String()
+	fabric.zones = t.Zones.String()
+
+	return nil
+}
+
+// AddressPools gets the address pools of this fabric.
+func (fabric *Fabric) AddressPools() ([]*AddressPool, error) {
+	return ListReferencedAddressPools(fabric.Client, fabric.addressPools)
+}
+
+// Endpoints gets the endpoints of this fabric.
+func (fabric *Fabric) Endpoints() ([]*Endpoint, error) {
+	return ListReferencedEndpoints(fabric.Client, fabric.endpoints)
+}
+
+// Switches gets the switches of this fabric.
+func (fabric *Fabric) Switches() ([]*Switch, error) {
+	return ListReferencedSwitches(fabric.Client, fabric.switches)
+}
+
+// Zones gets the zones of this fabric.
+func (fabric *Fabric) Zones() ([]*Zone, error) {
+	return ListReferencedZones(fabric.Client, fabric.zones)
+}
+
+// CreateZone creates a zone in this fabric. It returns the link to the new
+// zone.
+func (fabric *Fabric) CreateZone(parameters *ZoneParameters) (string, error) {
+	if strings.TrimSpace(fabric.zones) == "" {
+		return "", fmt.Errorf("empty zones link in the fabric")
+	}
+
+	return CreateZone(fabric.Client, fabric.zones, parameters)
+}
+
+// DeleteZone deletes a zone of this fabric.
+func (fabric *Fabric) DeleteZone(uri string) error {
+	return DeleteZone(fabric.Client, uri)
+}
+
+// fabricLink returns the link to the fabric containing a fabric resource,
+// based on its location.
+func fabricLink(uri string) string {
+	const fabrics = "/Fabrics/"
+
+	index := strings.Index(uri, fabrics)
+	if index < 0 {
+		return ""
+	}
+
+	rest := uri[index+len(fabrics):]
+	if end := strings.Index(rest, "/"); end >= 0 {
+		rest = rest[:end]
+	}
+	if rest == "" {
+		return ""
+	}
+
+	return uri[:index+len(fabrics)] + rest
+}
+
+// GetFabric will get a Fabric instance from the service.
+func GetFabric(c common.Client, uri string) (*Fabric, error) {
+	var fabric Fabric
+	return &fabric, fabric.Get(c, uri, &fabric)
+}
+
+// ListReferencedFabrics gets the collection of Fabric from
+// a provided reference.
+func ListReferencedFabrics(c common.Client, link string) ([]*Fabric, error) { //nolint:dupl
+	var result []*Fabric
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Fabric
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		fabric, err := GetFabric(c, link)
+		ch <- GetResult{Item: fabric, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,128 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var fabricBody = `{
+		"@odata.id": "/redfish/v1/Fabrics/NVMeoF",
+		"@odata.type": "#Fabric.v1_3_0.Fabric",
+		"Id": "NVMeoF",
+		"Name": "NVMe-oF Fabric",
+		"FabricType": "NVMeOverFabrics",
+		"MaxZones": 32,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Zones": {
+			"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Zones"
+		},
+		"Endpoints": {
+			"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Endpoints"
+		},
+		"Switches": {
+			"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Switches"
+		},
+		"AddressPools": {
+			"@odata.id": "/redfish/v1/Fabrics/NVMeoF/AddressPools"
+		}
+	}`
+
+// TestFabric tests the parsing of Fabric objects.
+func TestFabric(t *testing.T) {
+	var result Fabric
+	err := json.NewDecoder(strings.NewReader(fabricBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "NVMeoF" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.FabricType != common.NVMeOverFabricsProtocol {
+		t.Errorf("Invalid FabricType: %s", result.FabricType)
+	}
+
+	if result.MaxZones != 32 {
+		t.Errorf("Invalid MaxZones: %d", result.MaxZones)
+	}
+
+	if result.zones != "/redfish/v1/Fabrics/NVMeoF/Zones" {
+		t.Errorf("Invalid Zones link: %s", result.zones)
+	}
+
+	if result.addressPools != "/redfish/v1/Fabrics/NVMeoF/AddressPools" {
+		t.Errorf("Invalid AddressPools link: %s", result.addressPools)
+	}
+}
+
+// TestFabricCreateZone tests creating and deleting a zone.
+func TestFabricCreateZone(t *testing.T) {
+	var result Fabric
+	err := json.NewDecoder(strings.NewReader(fabricBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	resp := testResponse(http.StatusOK, "", nil)
+	resp.StatusCode = http.StatusCreated
+	resp.Header = http.Header{}
+	resp.Header.Set("Location", "/redfish/v1/Fabrics/NVMeoF/Zones/3")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {resp},
+		},
+	}
+	result.SetClient(testClient)
+
+	link, err := result.CreateZone(&ZoneParameters{
+		Name:      "Host 3",
+		ZoneType:  ZoneOfEndpointsZoneType,
+		Endpoints: []string{"/redfish/v1/Fabrics/NVMeoF/Endpoints/Host3", "/redfish/v1/Fabrics/NVMeoF/Endpoints/Target1"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating zone: %s", err)
+	}
+
+	if link != "/redfish/v1/Fabrics/NVMeoF/Zones/3" {
+		t.Errorf("Invalid link to the new zone: %s", link)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/Fabrics/NVMeoF/Zones" {
+		t.Errorf("Unexpected create URL: %s", calls[0].URL)
+	}
+
+	expected := "Links:map[Endpoints:[map[@odata.id:/redfish/v1/Fabrics/NVMeoF/Endpoints/Host3] " +
+		"map[@odata.id:/redfish/v1/Fabrics/NVMeoF/Endpoints/Target1]]]"
+	if !strings.Contains(calls[0].Payload, expected) {
+		t.Errorf("Unexpected create payload: %s", calls[0].Payload)
+	}
+
+	if strings.Contains(calls[0].Payload, "AddressPools") {
+		t.Errorf("Unset links should not be sent: %s", calls[0].Payload)
+	}
+
+	err = result.DeleteZone(link)
+	if err != nil {
+		t.Errorf("Error deleting zone: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if calls[1].Action != http.MethodDelete || calls[1].URL != link {
+		t.Errorf("Unexpected delete call: %v", calls[1])
+	}
+}
@@ -0,0 +1,169 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// FanUnit shall represent a cooling fan for a Redfish implementation, as
+// found in the fan collection of a ThermalSubsystem. It is named FanUnit to
+// tell it apart from the Fan objects embedded in the deprecated Thermal
+// resource.
+type FanUnit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// Location shall contain location information of this fan.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the fan.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this fan.
+	Model string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this fan.
+	PartNumber string
+	// PhysicalContext shall contain a description of the affected device or
+	// region within the chassis with which this fan is associated.
+	PhysicalContext common.PhysicalContext
+	// PowerWatts shall contain the total power, in watts, consumed by this
+	// fan.
+	PowerWatts SensorPowerExcerpt
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this fan.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this fan.
+	SparePartNumber string
+	// SpeedPercent shall contain the fan speed, in percent units, for this
+	// resource.
+	SpeedPercent SensorFanExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a FanUnit object from the raw JSON.
+func (fanunit *FanUnit) UnmarshalJSON(b []byte) error {
+	type temp FanUnit
+	var t struct {
+		temp
+		Assembly common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*fanunit = FanUnit(t.temp)
+	fanunit.assembly = t.Assembly.String()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	fanunit.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (fanunit *FanUnit) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(FanUnit)
+	err := original.UnmarshalJSON(fanunit.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(fanunit).Elem()
+
+	return fanunit.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Assembly gets the assembly data of this fan.
+func (fanunit *FanUnit) Assembly() (*Assembly, error) {
+	if fanunit.assembly == "" {
+		return nil, nil
+	}
+	return GetAssembly(fanunit.Client, fanunit.assembly)
+}
+
+// GetFanUnit will get a FanUnit instance from the service.
+func GetFanUnit(c common.Client, uri string) (*FanUnit, error) {
+	var fanUnit FanUnit
+	return &fanUnit, fanUnit.Get(c, uri, &fanUnit)
+}
+
+// ListReferencedFanUnits gets the collection of FanUnit from
+// a provided reference.
+func ListReferencedFanUnits(c common.Client, link string) ([]*FanUnit, error) { //nolint:dupl
+	var result []*FanUnit
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *FanUnit
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		fanunit, err := GetFanUnit(c, link)
+		ch <- GetResult{Item: fanunit, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
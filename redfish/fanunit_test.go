@@ -0,0 +1,95 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var fanUnitBody = `{
+		"@odata.type": "#Fan.v1_3_0.Fan",
+		"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1",
+		"Id": "Bay1",
+		"Name": "Fan Bay 1",
+		"PhysicalContext": "CPU",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"SpeedPercent": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/FanBay1",
+			"Reading": 45,
+			"SpeedRPM": 2200
+		},
+		"PowerWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/FanBay1Power",
+			"Reading": 12.5
+		},
+		"LocationIndicatorActive": false,
+		"HotPluggable": true,
+		"Assembly": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1/Assembly"
+		}
+	}`
+
+// TestFanUnit tests the parsing of FanUnit objects.
+func TestFanUnit(t *testing.T) {
+	var result FanUnit
+	err := json.NewDecoder(strings.NewReader(fanUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Bay1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.SpeedPercent.Reading != 45 || result.SpeedPercent.SpeedRPM != 2200 {
+		t.Errorf("Invalid SpeedPercent: %v", result.SpeedPercent)
+	}
+
+	if result.SpeedPercent.DataSourceURI != "/redfish/v1/Chassis/1U/Sensors/FanBay1" {
+		t.Errorf("Invalid SpeedPercent data source: %s", result.SpeedPercent.DataSourceURI)
+	}
+
+	if result.PowerWatts.Reading != 12.5 {
+		t.Errorf("Invalid PowerWatts: %v", result.PowerWatts)
+	}
+
+	if result.assembly != "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1/Assembly" {
+		t.Errorf("Invalid Assembly link: %s", result.assembly)
+	}
+}
+
+// TestFanUnitUpdate tests the Update call.
+func TestFanUnitUpdate(t *testing.T) {
+	var result FanUnit
+	err := json.NewDecoder(strings.NewReader(fanUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LocationIndicatorActive = true
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+}
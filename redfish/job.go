@@ -0,0 +1,387 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// DefaultJobPollInterval is the delay between two polls of a job.
+const DefaultJobPollInterval = 5 * time.Second
+
+// JobState is the state of a job.
+type JobState string
+
+const (
+	// NewJobState shall represent that this job is newly created, but the
+	// operation has not yet started.
+	NewJobState JobState = "New"
+	// StartingJobState shall represent that the operation is starting.
+	StartingJobState JobState = "Starting"
+	// RunningJobState shall represent that the operation is executing.
+	RunningJobState JobState = "Running"
+	// SuspendedJobState shall represent that the operation has been
+	// suspended but is expected to restart and is therefore not complete.
+	SuspendedJobState JobState = "Suspended"
+	// InterruptedJobState shall represent that the operation has been
+	// interrupted but is expected to restart and is therefore not complete.
+	InterruptedJobState JobState = "Interrupted"
+	// PendingJobState shall represent that the operation is pending some
+	// condition and has not yet begun to execute.
+	PendingJobState JobState = "Pending"
+	// StoppingJobState shall represent that the operation is stopping but is
+	// not yet complete.
+	StoppingJobState JobState = "Stopping"
+	// CompletedJobState shall represent that the operation is complete and
+	// completed successfully or with warnings.
+	CompletedJobState JobState = "Completed"
+	// CancelledJobState shall represent that the operation is complete
+	// because the job was cancelled by an operator.
+	CancelledJobState JobState = "Cancelled"
+	// ExceptionJobState shall represent that the operation is complete and
+	// completed with errors.
+	ExceptionJobState JobState = "Exception"
+	// ServiceJobState shall represent that the operation is now running as
+	// a service and expected to continue operation until stopped or killed.
+	ServiceJobState JobState = "Service"
+	// UserInterventionJobState shall represent that the operation is waiting
+	// for a user to intervene and needs to be manually continued, stopped,
+	// or cancelled.
+	UserInterventionJobState JobState = "UserIntervention"
+	// ContinueJobState shall represent that the operation has been resumed
+	// from a paused condition and should return to a Running state.
+	ContinueJobState JobState = "Continue"
+)
+
+// IsTerminal returns true if the job will not make any further progress on
+// its own.
+func (jobState JobState) IsTerminal() bool {
+	switch jobState {
+	case CompletedJobState, CancelledJobState, ExceptionJobState:
+		return true
+	}
+	return false
+}
+
+// IsSuccessful returns true if the job completed successfully or with
+// warnings.
+func (jobState JobState) IsSuccessful() bool {
+	return jobState == CompletedJobState
+}
+
+// Job shall contain a job in a Redfish implementation.
+type Job struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CreatedBy shall contain the username, software program name, or other
+	// identifier indicating the creator of this job.
+	CreatedBy string
+	// Description provides a description of this resource.
+	Description string
+	// EndTime shall contain the date and time when the job was completed.
+	EndTime string
+	// EstimatedDuration shall contain the estimated total time needed to
+	// complete the job, in ISO 8601 duration format.
+	EstimatedDuration string
+	// HidePayload shall indicate whether the contents of the payload should
+	// be hidden from view after the job has been created.
+	HidePayload bool
+	// JobState shall contain the state of the job.
+	JobState JobState
+	// JobStatus shall contain the health status of the job.
+	JobStatus common.Health
+	// MaxExecutionTime shall be an ISO 8601 conformant duration describing
+	// the maximum duration the job is allowed to execute before being
+	// stopped by the service.
+	MaxExecutionTime string
+	// Messages shall be an array of messages associated with the job.
+	Messages []common.Message
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Payload shall contain the HTTP and JSON payload information for
+	// executing this job.
+	Payload Payload
+	// PercentComplete shall indicate the completion progress of the job,
+	// reported in percent of completion.
+	PercentComplete int
+	// Schedule shall contain the scheduling details for this job and the
+	// recurrence frequency for future instances of this job.
+	Schedule common.Schedule
+	// StartTime shall contain the date and time when the job was last
+	// started or is scheduled to start.
+	StartTime string
+	// StepOrder shall contain an array of IDs for the job steps in the order
+	// that they shall be executed.
+	StepOrder []string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+	// steps is the link to the collection of steps of this job.
+	steps string
+}
+
+// UnmarshalJSON unmarshals a Job object from the raw JSON.
+func (job *Job) UnmarshalJSON(b []byte) error {
+	type temp Job
+	var t struct {
+		temp
+		Steps common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*job = Job(t.temp)
+
+	// Extract the links to other entities for later
+	job.steps = t.Steps.String()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	job.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (job *Job) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Job)
+	err := original.UnmarshalJSON(job.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"JobState",
+		"MaxExecutionTime",
+		"StartTime",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(job).Elem()
+
+	return job.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Steps gets the steps of this job, in the order they are executed if the
+// service provides one.
+func (job *Job) Steps() ([]*Job, error) {
+	steps, err := ListReferencedJobs(job.Client, job.steps)
+	if len(job.StepOrder) == 0 {
+		return steps, err
+	}
+
+	order := make(map[string]int, len(job.StepOrder))
+	for i, id := range job.StepOrder {
+		order[id] = i + 1
+	}
+
+	// Steps that are not listed in the order go last
+	position := func(step *Job) int {
+		if i, ok := order[step.ID]; ok {
+			return i
+		}
+		return len(order) + 1
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return position(steps[i]) < position(steps[j])
+	})
+
+	return steps, err
+}
+
+// Refresh gets the current state of the job from the service.
+func (job *Job) Refresh() error {
+	current, err := GetJob(job.Client, job.ODataID)
+	if err != nil {
+		return err
+	}
+
+	*job = *current
+	return nil
+}
+
+// Wait polls the job until it reaches a terminal state or ctx is done. The
+// optional progress function is called each time the job is refreshed. An
+// error is returned if the job did not complete successfully.
+//
+// Jobs running as a service or waiting for user intervention never complete
+// on their own, ctx should be used to limit the wait.
+func (job *Job) Wait(ctx context.Context, interval time.Duration, progress func(job *Job)) error {
+	if interval <= 0 {
+		interval = DefaultJobPollInterval
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := job.Refresh(); err != nil {
+			return err
+		}
+
+		if progress != nil {
+			progress(job)
+		}
+
+		if job.JobState.IsTerminal() {
+			return job.jobError()
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// jobError reports jobs that did not complete successfully.
+func (job *Job) jobError() error {
+	if job.JobState.IsSuccessful() {
+		return nil
+	}
+
+	message := ""
+	if len(job.Messages) > 0 {
+		message = ": " + job.Messages[len(job.Messages)-1].Message
+	}
+	return fmt.Errorf("job %s ended in state %s%s", job.ID, job.JobState, message)
+}
+
+// JobParameters contains the properties of a new job.
+type JobParameters struct {
+	// Name is the name of the new job.
+	Name string `json:",omitempty"`
+	// Description is the description of the new job.
+	Description string `json:",omitempty"`
+	// Payload is the HTTP operation the job executes.
+	Payload *Payload `json:",omitempty"`
+	// Schedule is when the job runs and how it recurs.
+	Schedule *common.Schedule `json:",omitempty"`
+	// StartTime is the date and time when the job is scheduled to start.
+	StartTime string `json:",omitempty"`
+	// MaxExecutionTime is the maximum duration the job is allowed to
+	// execute, in ISO 8601 duration format.
+	MaxExecutionTime string `json:",omitempty"`
+	// HidePayload hides the payload once the job has been created.
+	HidePayload bool `json:",omitempty"`
+}
+
+// CreateJob creates a job in the collection at uri. It returns the link to
+// the new job.
+func CreateJob(c common.Client, uri string, parameters *JobParameters) (string, error) {
+	if strings.TrimSpace(uri) == "" {
+		return "", fmt.Errorf("uri should not be empty")
+	}
+	if parameters == nil {
+		return "", fmt.Errorf("job parameters must be supplied")
+	}
+
+	payload := struct {
+		*JobParameters
+		Schedule *scheduleParameters `json:",omitempty"`
+	}{
+		JobParameters: parameters,
+		Schedule:      newScheduleParameters(parameters.Schedule),
+	}
+
+	resp, err := c.Post(uri, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// return the job link from returned location
+	jobLink := resp.Header.Get("Location")
+	if urlParser, err := url.ParseRequestURI(jobLink); err == nil {
+		jobLink = urlParser.RequestURI()
+	}
+
+	return jobLink, nil
+}
+
+// DeleteJob will delete a Job.
+func DeleteJob(c common.Client, uri string) error {
+	// validate uri
+	if strings.TrimSpace(uri) == "" {
+		return fmt.Errorf("uri should not be empty")
+	}
+
+	resp, err := c.Delete(uri)
+	if err == nil {
+		defer resp.Body.Close()
+	}
+
+	return err
+}
+
+// GetJob will get a Job instance from the service.
+func GetJob(c common.Client, uri string) (*Job, error) {
+	var job Job
+	return &job, job.Get(c, uri, &job)
+}
+
+// ListReferencedJobs gets the collection of Job from
+// a provided reference.
+func ListReferencedJobs(c common.Client, link string) ([]*Job, error) { //nolint:dupl
+	var result []*Job
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Job
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		job, err := GetJob(c, link)
+		ch <- GetResult{Item: job, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,172 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var jobBody = `{
+		"@odata.id": "/redfish/v1/JobService/Jobs/Check",
+		"@odata.type": "#Job.v1_2_1.Job",
+		"Id": "Check",
+		"Name": "Weekly consistency check",
+		"JobState": "Running",
+		"JobStatus": "OK",
+		"PercentComplete": 40,
+		"StartTime": "2023-04-08T02:00:00Z",
+		"CreatedBy": "admin",
+		"Schedule": {
+			"RecurrenceInterval": "P7D",
+			"EnabledDaysOfWeek": ["Saturday"]
+		},
+		"Payload": {
+			"TargetUri": "/redfish/v1/Systems/1/Storage/1/Volumes/1/Actions/Volume.CheckConsistency",
+			"HttpOperation": "POST",
+			"JsonBody": "{}"
+		},
+		"StepOrder": ["Verify", "Scan"],
+		"Steps": {
+			"@odata.id": "/redfish/v1/JobService/Jobs/Check/Steps"
+		}
+	}`
+
+// TestJob tests the parsing of Job objects.
+func TestJob(t *testing.T) {
+	var result Job
+	err := json.NewDecoder(strings.NewReader(jobBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Check" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.JobState != RunningJobState {
+		t.Errorf("Invalid JobState: %s", result.JobState)
+	}
+
+	if result.Schedule.EnabledDaysOfWeek[0] != common.SaturdayDayOfWeek {
+		t.Errorf("Invalid Schedule: %v", result.Schedule)
+	}
+
+	if result.Payload.HTTPOperation != "POST" {
+		t.Errorf("Invalid Payload: %v", result.Payload)
+	}
+
+	if result.steps != "/redfish/v1/JobService/Jobs/Check/Steps" {
+		t.Errorf("Invalid Steps link: %s", result.steps)
+	}
+}
+
+// TestJobUpdate tests the Update call.
+func TestJobUpdate(t *testing.T) {
+	var result Job
+	err := json.NewDecoder(strings.NewReader(jobBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.JobState = SuspendedJobState
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "JobState:Suspended") {
+		t.Errorf("Unexpected JobState update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestJobSteps tests getting the steps of a job in order.
+func TestJobSteps(t *testing.T) {
+	var result Job
+	err := json.NewDecoder(strings.NewReader(jobBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{
+					"Members": [
+						{"@odata.id": "/redfish/v1/JobService/Jobs/Check/Steps/Scan"}
+					],
+					"Members@odata.count": 1
+				}`, nil),
+				testResponse(http.StatusOK, `{"@odata.id": "/redfish/v1/JobService/Jobs/Check/Steps/Scan", "Id": "Scan"}`, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	steps, err := result.Steps()
+	if err != nil {
+		t.Fatalf("Error getting steps: %s", err)
+	}
+
+	if len(steps) != 1 || steps[0].ID != "Scan" {
+		t.Errorf("Unexpected steps: %v", steps)
+	}
+}
+
+// TestJobWait tests polling a job until it completes.
+func TestJobWait(t *testing.T) {
+	var result Job
+	err := json.NewDecoder(strings.NewReader(jobBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, strings.Replace(jobBody, `"PercentComplete": 40`, `"PercentComplete": 80`, 1), nil),
+				testResponse(http.StatusOK, strings.Replace(jobBody, `"JobState": "Running"`, `"JobState": "Exception"`, 1), nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	var progress []int
+	err = result.Wait(context.Background(), time.Millisecond, func(job *Job) {
+		progress = append(progress, job.PercentComplete)
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "Exception") {
+		t.Errorf("Jobs ending in exception should be reported: %v", err)
+	}
+
+	if len(progress) != 2 || progress[0] != 80 {
+		t.Errorf("Unexpected progress: %v", progress)
+	}
+
+	if result.JobState != ExceptionJobState {
+		t.Errorf("Job should hold its final state: %s", result.JobState)
+	}
+
+	if result.Client != testClient {
+		t.Error("Job should keep its client after refreshing")
+	}
+}
@@ -0,0 +1,140 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// JobServiceCapabilities shall contain properties that describe the
+// capabilities or settings of the job service.
+type JobServiceCapabilities struct {
+	// MaxJobs shall contain the maximum number of jobs supported by the
+	// implementation.
+	MaxJobs int
+	// MaxSteps shall contain the maximum number of steps supported by a
+	// single job instance.
+	MaxSteps int
+	// Scheduling shall indicate whether the Schedule property within the job
+	// supports scheduling of jobs.
+	Scheduling bool
+}
+
+// JobService shall represent a job service for a Redfish implementation.
+type JobService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// DateTime shall contain the current date and time setting for the job
+	// service.
+	DateTime string
+	// Description provides a description of this resource.
+	Description string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// ServiceCapabilities shall contain properties that describe the
+	// capabilities or settings of the job service.
+	ServiceCapabilities JobServiceCapabilities
+	// ServiceEnabled shall indicate whether this service is enabled.
+	ServiceEnabled bool
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+	// jobs is the link to the collection of jobs.
+	jobs string
+	// log is the link to the log service of the job service.
+	log string
+}
+
+// UnmarshalJSON unmarshals a JobService object from the raw JSON.
+func (jobservice *JobService) UnmarshalJSON(b []byte) error {
+	type temp JobService
+	var t struct {
+		temp
+		Jobs common.Link
+		Log  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*jobservice = JobService(t.temp)
+
+	// Extract the links to other entities for later
+	jobservice.jobs = t.Jobs.String()
+	jobservice.log = t.Log.String()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	jobservice.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (jobservice *JobService) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(JobService)
+	err := original.UnmarshalJSON(jobservice.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"ServiceEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(jobservice).Elem()
+
+	return jobservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetJobService will get a JobService instance from the service.
+func GetJobService(c common.Client, uri string) (*JobService, error) {
+	var jobService JobService
+	return &jobService, jobService.Get(c, uri, &jobService)
+}
+
+// Jobs gets the jobs of the job service.
+func (jobservice *JobService) Jobs() ([]*Job, error) {
+	return ListReferencedJobs(jobservice.Client, jobservice.jobs)
+}
+
+// Log gets the log service of the job service.
+func (jobservice *JobService) Log() (*LogService, error) {
+	if jobservice.log == "" {
+		return nil, nil
+	}
+	return GetLogService(jobservice.Client, jobservice.log)
+}
+
+// CreateJob creates a job. It returns the link to the new job.
+func (jobservice *JobService) CreateJob(parameters *JobParameters) (string, error) {
+	if strings.TrimSpace(jobservice.jobs) == "" {
+		return "", fmt.Errorf("empty jobs link in the job service")
+	}
+	if parameters != nil && parameters.Schedule != nil && !jobservice.ServiceCapabilities.Scheduling {
+		return "", fmt.Errorf("job service does not support scheduling jobs")
+	}
+
+	return CreateJob(jobservice.Client, jobservice.jobs, parameters)
+}
+
+// DeleteJob deletes a job.
+func (jobservice *JobService) DeleteJob(uri string) error {
+	return DeleteJob(jobservice.Client, uri)
+}
@@ -0,0 +1,129 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var jobServiceBody = `{
+		"@odata.id": "/redfish/v1/JobService",
+		"@odata.type": "#JobService.v1_0_5.JobService",
+		"Id": "JobService",
+		"Name": "Job Service",
+		"DateTime": "2023-04-07T13:22:05Z",
+		"ServiceEnabled": true,
+		"ServiceCapabilities": {
+			"MaxJobs": 100,
+			"MaxSteps": 50,
+			"Scheduling": true
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Jobs": {
+			"@odata.id": "/redfish/v1/JobService/Jobs"
+		},
+		"Log": {
+			"@odata.id": "/redfish/v1/JobService/Log"
+		}
+	}`
+
+// TestJobService tests the parsing of JobService objects.
+func TestJobService(t *testing.T) {
+	var result JobService
+	err := json.NewDecoder(strings.NewReader(jobServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "JobService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if !result.ServiceCapabilities.Scheduling || result.ServiceCapabilities.MaxJobs != 100 {
+		t.Errorf("Invalid ServiceCapabilities: %v", result.ServiceCapabilities)
+	}
+
+	if result.jobs != "/redfish/v1/JobService/Jobs" {
+		t.Errorf("Invalid Jobs link: %s", result.jobs)
+	}
+
+	if result.log != "/redfish/v1/JobService/Log" {
+		t.Errorf("Invalid Log link: %s", result.log)
+	}
+}
+
+// TestJobServiceCreateJob tests creating and deleting a scheduled job.
+func TestJobServiceCreateJob(t *testing.T) {
+	var result JobService
+	err := json.NewDecoder(strings.NewReader(jobServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	resp := testResponse(http.StatusOK, "", nil)
+	resp.StatusCode = http.StatusCreated
+	resp.Header = http.Header{}
+	resp.Header.Set("Location", "/redfish/v1/JobService/Jobs/Check")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {resp},
+		},
+	}
+	result.SetClient(testClient)
+
+	link, err := result.CreateJob(&JobParameters{
+		Name: "Weekly consistency check",
+		Payload: &Payload{
+			TargetURI:     "/redfish/v1/Systems/1/Storage/1/Volumes/1/Actions/Volume.CheckConsistency",
+			HTTPOperation: http.MethodPost,
+		},
+		Schedule: &common.Schedule{
+			RecurrenceInterval: "P7D",
+			EnabledDaysOfWeek:  []common.DayOfWeek{common.SaturdayDayOfWeek},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error creating job: %s", err)
+	}
+
+	if link != "/redfish/v1/JobService/Jobs/Check" {
+		t.Errorf("Invalid link to the new job: %s", link)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/JobService/Jobs" {
+		t.Errorf("Unexpected create URL: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "Schedule:map[EnabledDaysOfWeek:[Saturday] RecurrenceInterval:P7D]") {
+		t.Errorf("Unexpected create payload: %s", calls[0].Payload)
+	}
+
+	err = result.DeleteJob(link)
+	if err != nil {
+		t.Errorf("Error deleting job: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if calls[1].Action != http.MethodDelete || calls[1].URL != link {
+		t.Errorf("Unexpected delete call: %v", calls[1])
+	}
+
+	result.ServiceCapabilities.Scheduling = false
+	_, err = result.CreateJob(&JobParameters{Schedule: &common.Schedule{RecurrenceInterval: "P1D"}})
+	if err == nil {
+		t.Error("Scheduled jobs should be rejected when scheduling is not supported")
+	}
+}
@@ -7,6 +7,7 @@ package redfish
 import (
 	"encoding/json"
 	"reflect"
+	"sync"
 
 	"github.com/bcohee/gofish/common"
 )
@@ -194,6 +195,59 @@ func (logservice *LogService) Entries() ([]*LogEntry, error) {
 	return ListReferencedLogEntrys(logservice.Client, logservice.entries)
 }
 
+// WalkEntries calls fn for each log entry of this service, in the order the
+// service lists them, until fn returns an error. Entries are retrieved one
+// collection page at a time so that large logs are not held in memory.
+// Entries that cannot be retrieved are reported in a CollectionError once
+// the walk completes.
+func (logservice *LogService) WalkEntries(fn func(entry *LogEntry) error) error {
+	if logservice.entries == "" {
+		return nil
+	}
+
+	collectionError := common.NewCollectionError()
+	pager := common.NewCollectionPager(logservice.Client, logservice.entries)
+	for pager.Next() {
+		links := pager.Page().ItemLinks
+		entries := make([]*LogEntry, len(links))
+		index := make(map[string]int, len(links))
+		for i, link := range links {
+			index[link] = i
+		}
+
+		var mu sync.Mutex
+		get := func(link string) {
+			entry, err := GetLogEntry(logservice.Client, link)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				collectionError.Failures[link] = err
+				return
+			}
+			entries[index[link]] = entry
+		}
+		common.CollectCollection(get, logservice.Client, links)
+
+		for _, entry := range entries {
+			if entry == nil {
+				continue
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	if collectionError.Empty() {
+		return nil
+	}
+	return collectionError
+}
+
 // ClearLog shall delete all entries found in the Entries collection for this
 // Log Service.
 func (logservice *LogService) ClearLog() error {
@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -105,3 +106,54 @@ func TestLogServiceUpdate(t *testing.T) { //nolint:dupl
 		t.Errorf("Unexpected ServiceEnabled update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestLogServiceWalkEntries tests walking a paged log.
+func TestLogServiceWalkEntries(t *testing.T) {
+	var result LogService
+	err := json.NewDecoder(strings.NewReader(logServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	page := func(member, next string) *http.Response {
+		body := `{"Members@odata.count": 2, "Members": [{"@odata.id": "` + member + `"}]`
+		if next != "" {
+			body += `, "Members@odata.nextLink": "` + next + `"`
+		}
+		return testResponse(http.StatusOK, body+"}", nil)
+	}
+	entry := func(id string) *http.Response {
+		return testResponse(http.StatusOK, `{"Id": "`+id+`"}`, nil)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				page("/redfish/v1/LogEntryCollection/1", "/redfish/v1/LogEntryCollection?$skip=1"),
+				entry("1"),
+				page("/redfish/v1/LogEntryCollection/2", ""),
+				entry("2"),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	var walked []string
+	err = result.WalkEntries(func(entry *LogEntry) error {
+		walked = append(walked, entry.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Error walking entries: %s", err)
+	}
+
+	if len(walked) != 2 || walked[0] != "1" || walked[1] != "2" {
+		t.Errorf("Unexpected entries: %v", walked)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[2].URL != "/redfish/v1/LogEntryCollection?$skip=1" {
+		t.Errorf("Next link should have been followed, got: %s", calls[2].URL)
+	}
+}
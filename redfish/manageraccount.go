@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/bcohee/gofish/common"
@@ -145,6 +146,72 @@ func (manageraccount *ManagerAccount) Update() error {
 	return manageraccount.Entity.Update(originalElement, currentElement, readWriteFields)
 }
 
+// ChangePassword sets a new password for this account.
+func (manageraccount *ManagerAccount) ChangePassword(password string) error {
+	if password == "" {
+		return fmt.Errorf("new password must be supplied")
+	}
+
+	t := struct {
+		Password string
+	}{Password: password}
+	return manageraccount.Patch(manageraccount.ODataID, t)
+}
+
+// SetRole sets the role of this account.
+func (manageraccount *ManagerAccount) SetRole(roleID string) error {
+	t := struct {
+		RoleID string `json:"RoleId"`
+	}{RoleID: roleID}
+
+	err := manageraccount.Patch(manageraccount.ODataID, t)
+	if err == nil {
+		manageraccount.RoleID = roleID
+	}
+	return err
+}
+
+// Enable allows the account to log in.
+func (manageraccount *ManagerAccount) Enable() error {
+	return manageraccount.setEnabled(true)
+}
+
+// Disable prevents the account from logging in.
+func (manageraccount *ManagerAccount) Disable() error {
+	return manageraccount.setEnabled(false)
+}
+
+func (manageraccount *ManagerAccount) setEnabled(enabled bool) error {
+	t := struct {
+		Enabled bool
+	}{Enabled: enabled}
+
+	err := manageraccount.Patch(manageraccount.ODataID, t)
+	if err == nil {
+		manageraccount.Enabled = enabled
+	}
+	return err
+}
+
+// Unlock clears the lockout of an account locked after too many failed
+// login attempts.
+func (manageraccount *ManagerAccount) Unlock() error {
+	t := struct {
+		Locked bool
+	}{Locked: false}
+
+	err := manageraccount.Patch(manageraccount.ODataID, t)
+	if err == nil {
+		manageraccount.Locked = false
+	}
+	return err
+}
+
+// Certificates gets the user identity certificates for this account.
+func (manageraccount *ManagerAccount) Certificates() ([]*Certificate, error) {
+	return ListReferencedCertificates(manageraccount.Client, manageraccount.certificates)
+}
+
 // GetManagerAccount will get a ManagerAccount instance from the service.
 func GetManagerAccount(c common.Client, uri string) (*ManagerAccount, error) {
 	var managerAccount ManagerAccount
@@ -98,3 +98,41 @@ func TestManagerAccountUpdate(t *testing.T) {
 		t.Errorf("Unexpected Role ID update payload: %s", calls[0].Payload)
 	}
 }
+
+// TestManagerAccountActions tests the account helper calls.
+func TestManagerAccountActions(t *testing.T) {
+	var result ManagerAccount
+	err := json.NewDecoder(strings.NewReader(managerAccountBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	if err := result.ChangePassword(""); err == nil {
+		t.Error("Empty password should be rejected")
+	}
+
+	_ = result.ChangePassword("new-password")
+	_ = result.SetRole("ReadOnly")
+	_ = result.Disable()
+	_ = result.Unlock()
+
+	calls := testClient.CapturedCalls()
+	expected := []string{"map[Password:new-password]", "map[RoleId:ReadOnly]", "map[Enabled:false]", "map[Locked:false]"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Unexpected calls: %#v", calls)
+	}
+
+	for i, payload := range expected {
+		if calls[i].Action != "PATCH" || calls[i].URL != "/redfish/v1/AccountService/Accounts/1" || calls[i].Payload != payload {
+			t.Errorf("Unexpected call %d: %#v", i, calls[i])
+		}
+	}
+
+	if result.Enabled || result.RoleID != "ReadOnly" {
+		t.Errorf("Account should reflect the changes: %#v", result)
+	}
+}
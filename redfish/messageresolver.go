@@ -0,0 +1,367 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// MessageID is a parsed message identifier, in the
+// RegistryPrefix.MajorVersion.MinorVersion.MessageKey format.
+type MessageID struct {
+	// RegistryPrefix is the prefix of the registry defining the message, for
+	// example Base.
+	RegistryPrefix string
+	// MajorVersion is the major version of the registry.
+	MajorVersion int
+	// MinorVersion is the minor version of the registry.
+	MinorVersion int
+	// MessageKey is the key of the message in the registry.
+	MessageKey string
+}
+
+// ParseMessageID parses a message identifier such as
+// Base.1.8.PropertyValueNotInList. Identifiers including the errata version
+// of the registry, such as Base.1.8.1.PropertyValueNotInList, are accepted
+// too.
+func ParseMessageID(messageID string) (*MessageID, error) {
+	sections := strings.Split(strings.TrimSpace(messageID), ".")
+	if len(sections) < MessageIDSectionLength || len(sections) > MessageIDSectionLength+1 {
+		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+	}
+
+	major, err := strconv.Atoi(sections[1])
+	if err != nil {
+		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+	}
+	minor, err := strconv.Atoi(sections[2])
+	if err != nil {
+		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+	}
+
+	result := &MessageID{
+		RegistryPrefix: sections[0],
+		MajorVersion:   major,
+		MinorVersion:   minor,
+		MessageKey:     sections[len(sections)-1],
+	}
+	if result.RegistryPrefix == "" || result.MessageKey == "" {
+		return nil, fmt.Errorf("received invalid messageID %s", messageID)
+	}
+
+	return result, nil
+}
+
+// Registry returns the registry name and version, as found in the Registry
+// property of message registry files, for example Base.1.8.
+func (id *MessageID) Registry() string {
+	return fmt.Sprintf("%s.%d.%d", id.RegistryPrefix, id.MajorVersion, id.MinorVersion)
+}
+
+// String returns the message identifier.
+func (id *MessageID) String() string {
+	return id.Registry() + "." + id.MessageKey
+}
+
+// ResolvedMessage is a message completed with the information of the
+// message registry defining it.
+type ResolvedMessage struct {
+	// MessageID is the identifier of the message.
+	MessageID string
+	// Message is the human readable message, with its arguments substituted.
+	Message string
+	// Severity is the severity of the message.
+	Severity string
+	// Resolution describes the recommended actions to take to resolve the
+	// condition the message reports.
+	Resolution string
+}
+
+// String returns the message followed by its resolution, if any.
+func (message *ResolvedMessage) String() string {
+	if message.Resolution == "" {
+		return message.Message
+	}
+	return message.Message + " " + message.Resolution
+}
+
+// MessageResolver completes messages using the message registries of a
+// service. The registries are fetched once and cached by the resolver, so
+// it should be kept around for as long as the client it uses.
+type MessageResolver struct {
+	client     common.Client
+	registries string
+	language   string
+
+	mu sync.Mutex
+	// files holds the message registry files of the service once fetched.
+	files []*MessageRegistryFile
+	// cache holds the registries by registry name and version. A nil entry
+	// records that the registry could not be found.
+	cache map[string]*MessageRegistry
+}
+
+// NewMessageResolver creates a resolver for the messages of a service.
+// registries is the link to the message registry file collection of the
+// service and language the preferred language of the messages, for example
+// en.
+func NewMessageResolver(c common.Client, registries, language string) *MessageResolver {
+	return &MessageResolver{
+		client:     c,
+		registries: registries,
+		language:   strings.TrimSpace(language),
+		cache:      make(map[string]*MessageRegistry),
+	}
+}
+
+// Resolve builds the message identified by messageID from its registry,
+// substituting the given arguments.
+func (resolver *MessageResolver) Resolve(messageID string, args []string) (*ResolvedMessage, error) {
+	id, err := ParseMessageID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := resolver.Registry(id)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, ok := registry.Messages[id.MessageKey]
+	if !ok {
+		return nil, fmt.Errorf("message %s not found in registry %s", id.MessageKey, registry.ID)
+	}
+
+	text, err := substituteMessageArgs(&definition, args)
+	if err != nil {
+		return nil, fmt.Errorf("message %s: %w", messageID, err)
+	}
+
+	severity := definition.MessageSeverity
+	if severity == "" {
+		severity = definition.Severity
+	}
+
+	return &ResolvedMessage{
+		MessageID:  messageID,
+		Message:    text,
+		Severity:   severity,
+		Resolution: definition.Resolution,
+	}, nil
+}
+
+// complete resolves a message, preferring the values the service already
+// provided. An error is only returned if the service did not provide the
+// message text and it could not be resolved either.
+func (resolver *MessageResolver) complete(messageID, message string, args []string, severity, resolution string) (*ResolvedMessage, error) {
+	result := &ResolvedMessage{
+		MessageID:  messageID,
+		Message:    message,
+		Severity:   severity,
+		Resolution: resolution,
+	}
+
+	if messageID == "" || (message != "" && severity != "" && resolution != "") {
+		return result, nil
+	}
+
+	resolved, err := resolver.Resolve(messageID, args)
+	if err != nil {
+		if message != "" {
+			return result, nil
+		}
+		return result, err
+	}
+
+	if result.Message == "" {
+		result.Message = resolved.Message
+	}
+	if result.Severity == "" {
+		result.Severity = resolved.Severity
+	}
+	if result.Resolution == "" {
+		result.Resolution = resolved.Resolution
+	}
+
+	return result, nil
+}
+
+// ResolveMessage completes a message, such as the messages of a task.
+func (resolver *MessageResolver) ResolveMessage(message *common.Message) (*ResolvedMessage, error) {
+	return resolver.complete(message.MessageID, message.Message, message.MessageArgs, message.Severity, message.Resolution)
+}
+
+// ResolveExtendedInfo completes a message returned in an error response.
+func (resolver *MessageResolver) ResolveExtendedInfo(info *common.ErrExtendedInfo) (*ResolvedMessage, error) {
+	severity := string(info.MessageSeverity)
+	if severity == "" {
+		severity = info.Severity
+	}
+	return resolver.complete(info.MessageID, info.Message, info.MessageArgs, severity, info.Resolution)
+}
+
+// ResolveLogEntry completes the message of a log entry. Only entries of the
+// Event type carry registry messages, the message of SEL and OEM entries is
+// returned as is.
+func (resolver *MessageResolver) ResolveLogEntry(entry *LogEntry) (*ResolvedMessage, error) {
+	if entry.EntryType != "" && entry.EntryType != EventLogEntryType {
+		return &ResolvedMessage{
+			MessageID: entry.MessageID,
+			Message:   entry.Message,
+			Severity:  string(entry.Severity),
+		}, nil
+	}
+	return resolver.complete(entry.MessageID, entry.Message, entry.MessageArgs, string(entry.Severity), "")
+}
+
+// ResolveError completes the messages of an error returned by the service.
+// The extended information messages are returned if there are any,
+// otherwise the main message of the error.
+func (resolver *MessageResolver) ResolveError(err error) ([]*ResolvedMessage, error) {
+	var redfishError *common.Error
+	if !errors.As(err, &redfishError) {
+		return nil, fmt.Errorf("not an error response of the service: %w", err)
+	}
+
+	if len(redfishError.ExtendedInfos) == 0 {
+		message, err := resolver.complete(redfishError.Code, redfishError.Message, nil, "", "")
+		return []*ResolvedMessage{message}, err
+	}
+
+	var result []*ResolvedMessage
+	var firstErr error
+	for i := range redfishError.ExtendedInfos {
+		message, err := resolver.ResolveExtendedInfo(&redfishError.ExtendedInfos[i])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		result = append(result, message)
+	}
+
+	return result, firstErr
+}
+
+// Registry gets the registry defining the messages of the given identifier.
+// The registry with the same version is preferred, otherwise the one with
+// the same major version and the highest minor version is used, since
+// minor versions only add messages.
+func (resolver *MessageResolver) Registry(id *MessageID) (*MessageRegistry, error) {
+	resolver.mu.Lock()
+	defer resolver.mu.Unlock()
+
+	key := id.Registry()
+	if registry, ok := resolver.cache[key]; ok {
+		if registry == nil {
+			return nil, fmt.Errorf("message registry %s not found", key)
+		}
+		return registry, nil
+	}
+
+	if resolver.files == nil {
+		files, err := ListReferencedMessageRegistryFiles(resolver.client, resolver.registries)
+		if err != nil {
+			return nil, err
+		}
+		resolver.files = files
+	}
+
+	file := resolver.registryFile(id)
+	if file == nil {
+		resolver.cache[key] = nil
+		return nil, fmt.Errorf("message registry %s not found", key)
+	}
+
+	uri := resolver.location(file)
+	if uri == "" {
+		resolver.cache[key] = nil
+		return nil, fmt.Errorf("message registry %s is not hosted by the service", key)
+	}
+
+	registry, err := GetMessageRegistry(resolver.client, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.cache[key] = registry
+	return registry, nil
+}
+
+// registryFile finds the registry file for the given message identifier.
+func (resolver *MessageResolver) registryFile(id *MessageID) *MessageRegistryFile {
+	var result *MessageRegistryFile
+	bestMinor := -1
+	for _, file := range resolver.files {
+		fileID, err := ParseMessageID(file.Registry + ".Key")
+		if err != nil || fileID.RegistryPrefix != id.RegistryPrefix || fileID.MajorVersion != id.MajorVersion {
+			continue
+		}
+		if fileID.MinorVersion == id.MinorVersion {
+			return file
+		}
+		if fileID.MinorVersion > bestMinor {
+			result = file
+			bestMinor = fileID.MinorVersion
+		}
+	}
+	return result
+}
+
+// location returns the URI of the registry in the preferred language,
+// falling back to a related language and then to any language.
+func (resolver *MessageResolver) location(file *MessageRegistryFile) string {
+	var related, other string
+	for _, location := range file.Location {
+		if location.URI == "" {
+			continue
+		}
+		switch {
+		case strings.EqualFold(location.Language, resolver.language):
+			return location.URI
+		case related == "" && strings.HasPrefix(location.Language, resolver.language+"-"):
+			related = location.URI
+		case other == "":
+			other = location.URI
+		}
+	}
+
+	if related != "" {
+		return related
+	}
+	return other
+}
+
+// messageArgPattern matches the %1..%n argument placeholders of messages.
+var messageArgPattern = regexp.MustCompile(`%(\d+)`)
+
+// substituteMessageArgs checks the arguments against the parameter types of
+// a message and substitutes them in its text.
+func substituteMessageArgs(definition *MessageRegistryMessage, args []string) (string, error) {
+	if len(args) != definition.NumberOfArgs {
+		return "", fmt.Errorf("expected %d arguments, got %d", definition.NumberOfArgs, len(args))
+	}
+
+	for i, arg := range args {
+		if i < len(definition.ParamTypes) && definition.ParamTypes[i] == "number" {
+			if _, err := strconv.ParseFloat(arg, 64); err != nil {
+				return "", fmt.Errorf("argument %d should be a number, got %q", i+1, arg)
+			}
+		}
+	}
+
+	return messageArgPattern.ReplaceAllStringFunc(definition.Message, func(placeholder string) string {
+		index, _ := strconv.Atoi(placeholder[1:])
+		if index < 1 || index > len(args) {
+			return placeholder
+		}
+		return args[index-1]
+	}), nil
+}
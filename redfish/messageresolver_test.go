@@ -0,0 +1,220 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var registryFileCollectionBody = `{
+		"@odata.id": "/redfish/v1/Registries",
+		"Name": "Registry File Collection",
+		"Members@odata.count": 1,
+		"Members": [
+			{
+				"@odata.id": "/redfish/v1/Registries/MyRegistry"
+			}
+		]
+	}`
+
+var registryFileBody = `{
+		"@odata.type": "#MessageRegistryFile.v1_1_0.MessageRegistryFile",
+		"@odata.id": "/redfish/v1/Registries/MyRegistry",
+		"Id": "MyRegistry",
+		"Name": "MyRegistry Message Registry File",
+		"Languages": ["fr", "en"],
+		"Registry": "MyRegistry.2.2",
+		"Location": [
+			{
+				"Language": "fr",
+				"Uri": "/redfish/v1/Registries/MyRegistry/MyRegistry.fr.json"
+			},
+			{
+				"Language": "en",
+				"Uri": "/redfish/v1/Registries/MyRegistry/MyRegistry.json"
+			}
+		]
+	}`
+
+// TestParseMessageID tests parsing message identifiers.
+func TestParseMessageID(t *testing.T) {
+	id, err := ParseMessageID("Base.1.8.PropertyValueNotInList")
+	if err != nil {
+		t.Fatalf("Error parsing messageID: %s", err)
+	}
+
+	if id.RegistryPrefix != "Base" || id.MajorVersion != 1 || id.MinorVersion != 8 || id.MessageKey != "PropertyValueNotInList" {
+		t.Errorf("Unexpected parsed messageID: %v", id)
+	}
+
+	if id.Registry() != "Base.1.8" {
+		t.Errorf("Unexpected registry: %s", id.Registry())
+	}
+
+	id, err = ParseMessageID("Base.1.8.1.Success")
+	if err != nil {
+		t.Fatalf("Error parsing messageID with errata version: %s", err)
+	}
+
+	if id.String() != "Base.1.8.Success" {
+		t.Errorf("Unexpected parsed messageID: %s", id)
+	}
+
+	for _, invalid := range []string{"", "Base.Success", "Base.x.0.Success", "0x0F1234", "Base.1.0."} {
+		if _, err := ParseMessageID(invalid); err == nil {
+			t.Errorf("Invalid messageID %q should not be parsed", invalid)
+		}
+	}
+}
+
+// TestMessageResolverResolve tests resolving messages and caching their
+// registries.
+func TestMessageResolverResolve(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, registryFileCollectionBody, nil),
+				testResponse(http.StatusOK, registryFileBody, nil),
+				testResponse(http.StatusOK, messageRegistryBody, nil),
+			},
+		},
+	}
+	resolver := NewMessageResolver(testClient, "/redfish/v1/Registries", "en")
+
+	message, err := resolver.Resolve("MyRegistry.2.2.ThirdMessage", []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("Error resolving message: %s", err)
+	}
+
+	if message.Message != "This message has two args: first and second" {
+		t.Errorf("Unexpected message: %s", message.Message)
+	}
+
+	if message.Severity != "Warning" || message.Resolution != "The resolution for the third message." {
+		t.Errorf("Unexpected severity or resolution: %v", message)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[2].URL != "/redfish/v1/Registries/MyRegistry/MyRegistry.json" {
+		t.Errorf("Registry should be fetched in the preferred language: %s", calls[2].URL)
+	}
+
+	_, err = resolver.Resolve("MyRegistry.2.2.FirstMessage", []string{"again"})
+	if err != nil {
+		t.Errorf("Error resolving message: %s", err)
+	}
+
+	_, err = resolver.Resolve("Unknown.1.0.Message", nil)
+	if err == nil {
+		t.Error("Messages of unknown registries should not be resolved")
+	}
+
+	_, err = resolver.Resolve("Unknown.1.0.Message", nil)
+	if err == nil {
+		t.Error("Messages of unknown registries should not be resolved")
+	}
+
+	if len(testClient.CapturedCalls()) != 3 {
+		t.Errorf("Registries should be cached: %v", testClient.CapturedCalls())
+	}
+
+	_, err = resolver.Resolve("MyRegistry.2.2.ThirdMessage", []string{"first"})
+	if err == nil {
+		t.Error("Messages with missing arguments should not be resolved")
+	}
+}
+
+// TestSubstituteMessageArgs tests checking and substituting arguments.
+func TestSubstituteMessageArgs(t *testing.T) {
+	definition := &MessageRegistryMessage{
+		Message:      "The value %2 for %1 is out of range, %10 is not used.",
+		NumberOfArgs: 2,
+		ParamTypes:   []string{"string", "number"},
+	}
+
+	text, err := substituteMessageArgs(definition, []string{"Speed", "42"})
+	if err != nil {
+		t.Fatalf("Error substituting arguments: %s", err)
+	}
+
+	if text != "The value 42 for Speed is out of range, %10 is not used." {
+		t.Errorf("Unexpected message: %s", text)
+	}
+
+	_, err = substituteMessageArgs(definition, []string{"Speed", "fast"})
+	if err == nil {
+		t.Error("Arguments not matching their number type should be rejected")
+	}
+}
+
+// TestMessageResolverResolveError tests completing the messages of an error
+// response missing their text.
+func TestMessageResolverResolveError(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, registryFileCollectionBody, nil),
+				testResponse(http.StatusOK, registryFileBody, nil),
+				testResponse(http.StatusOK, messageRegistryBody, nil),
+			},
+		},
+	}
+	resolver := NewMessageResolver(testClient, "/redfish/v1/Registries", "en-US")
+
+	err := common.ConstructError(http.StatusBadRequest, []byte(`{
+		"error": {
+			"code": "Base.1.0.GeneralError",
+			"message": "A general error has occurred.",
+			"@Message.ExtendedInfo": [
+				{
+					"MessageId": "MyRegistry.2.2.FirstMessage",
+					"MessageArgs": ["Speed"]
+				},
+				{
+					"MessageId": "MyRegistry.2.2.SecondMessage",
+					"Message": "Provided by the service.",
+					"Severity": "Warning"
+				}
+			]
+		}
+	}`))
+
+	messages, err := resolver.ResolveError(err)
+	if err != nil {
+		t.Fatalf("Error resolving error messages: %s", err)
+	}
+
+	if messages[0].Message != "This message has only one arg: Speed" || messages[0].Severity != "OK" {
+		t.Errorf("Unexpected first message: %v", messages[0])
+	}
+
+	if messages[1].Message != "Provided by the service." || messages[1].Severity != "Warning" ||
+		messages[1].Resolution != "The resolution for the second message." {
+		t.Errorf("Unexpected second message: %v", messages[1])
+	}
+}
+
+// TestMessageResolverResolveLogEntry tests completing a log entry message.
+func TestMessageResolverResolveLogEntry(t *testing.T) {
+	resolver := NewMessageResolver(&common.TestClient{}, "", "en")
+
+	entry := &LogEntry{
+		EntryType: SELLogEntryType,
+		Message:   "Fan 1 lower critical going low",
+		MessageID: "0x010203",
+	}
+
+	message, err := resolver.ResolveLogEntry(entry)
+	if err != nil {
+		t.Fatalf("Error resolving log entry: %s", err)
+	}
+
+	if message.Message != entry.Message {
+		t.Errorf("Unexpected message: %s", message.Message)
+	}
+}
@@ -0,0 +1,211 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// MetricDataType is the data type of a metric.
+type MetricDataType string
+
+const (
+	// BooleanMetricDataType shall be a boolean value.
+	BooleanMetricDataType MetricDataType = "Boolean"
+	// DateTimeMetricDataType shall be an ISO 8601 extended format date-time
+	// string.
+	DateTimeMetricDataType MetricDataType = "DateTime"
+	// DecimalMetricDataType shall be a decimal value.
+	DecimalMetricDataType MetricDataType = "Decimal"
+	// IntegerMetricDataType shall be an integer value.
+	IntegerMetricDataType MetricDataType = "Integer"
+	// StringMetricDataType shall be a string value.
+	StringMetricDataType MetricDataType = "String"
+	// EnumerationMetricDataType shall be one of the DiscreteValues of the
+	// metric definition.
+	EnumerationMetricDataType MetricDataType = "Enumeration"
+)
+
+// MetricType is the type of a metric.
+type MetricType string
+
+const (
+	// NumericMetricType shall be a numeric metric. The metric value is any
+	// real number.
+	NumericMetricType MetricType = "Numeric"
+	// DiscreteMetricType shall be a discrete metric, whose value is one of
+	// the DiscreteValues of the metric definition.
+	DiscreteMetricType MetricType = "Discrete"
+	// GaugeMetricType shall be a gauge metric. The metric value is a real
+	// number that can increase or decrease.
+	GaugeMetricType MetricType = "Gauge"
+	// CounterMetricType shall be a counter metric. The metric value is a
+	// non-negative integer that only increases, apart from resets.
+	CounterMetricType MetricType = "Counter"
+	// CountdownMetricType shall be a countdown metric. The metric value is a
+	// non-negative integer that decreases until it reaches zero.
+	CountdownMetricType MetricType = "Countdown"
+	// StringMetricType shall be a non-discrete string metric.
+	StringMetricType MetricType = "String"
+)
+
+// ImplementationType is how a metric is implemented.
+type ImplementationType string
+
+const (
+	// PhysicalSensorImplementationType The metric is implemented as a
+	// physical sensor.
+	PhysicalSensorImplementationType ImplementationType = "PhysicalSensor"
+	// CalculatedImplementationType The metric is implemented by applying a
+	// calculation on another metric property.
+	CalculatedImplementationType ImplementationType = "Calculated"
+	// SynthesizedImplementationType The metric is implemented by applying a
+	// calculation on one or more properties.
+	SynthesizedImplementationType ImplementationType = "Synthesized"
+	// DigitalMeterImplementationType The metric is implemented as a digital
+	// meter.
+	DigitalMeterImplementationType ImplementationType = "DigitalMeter"
+)
+
+// Wildcard shall contain a wildcard and its substitution values.
+type Wildcard struct {
+	// Name shall contain the string used as a wildcard.
+	Name string
+	// Values shall contain the list of values to substitute for the wildcard.
+	Values []string
+}
+
+// MetricDefinition shall contain the metadata information for a metric in a
+// Redfish implementation.
+type MetricDefinition struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Accuracy shall contain the percent error +/- of the measured versus
+	// actual values of the property.
+	Accuracy float32
+	// Calibration shall contain the calibration offset added to the property
+	// value to increase its accuracy.
+	Calibration float32
+	// CalculationAlgorithm shall contain the calculation performed to
+	// obtain the metric value.
+	CalculationAlgorithm string
+	// CalculationTimeInterval shall specify the time interval over which
+	// the metric calculation is performed, in ISO 8601 duration format.
+	CalculationTimeInterval string
+	// Description provides a description of this resource.
+	Description string
+	// DiscreteValues shall specify the possible values of the discrete
+	// metric.
+	DiscreteValues []string
+	// Implementation shall specify the implementation of the metric.
+	Implementation ImplementationType
+	// IsLinear shall indicate whether the metric values are linear versus
+	// non-linear.
+	IsLinear bool
+	// MaxReadingRange shall indicate the highest possible value of the
+	// metric.
+	MaxReadingRange float32
+	// MetricDataType shall specify the data-type of the metric.
+	MetricDataType MetricDataType
+	// MetricProperties shall list the URIs, with wildcards, of the properties
+	// the metric definition applies to.
+	MetricProperties []string
+	// MetricType shall specify the type of metric.
+	MetricType MetricType
+	// MinReadingRange shall contain the lowest possible value of the metric.
+	MinReadingRange float32
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PhysicalContext shall contain the physical context of the metric.
+	PhysicalContext common.PhysicalContext
+	// Precision shall specify the number of significant digits in the metric
+	// value.
+	Precision int
+	// SensingInterval shall specify the time interval between when a metric
+	// is updated, in ISO 8601 duration format.
+	SensingInterval string
+	// TimestampAccuracy shall specify the expected + or - variation of the
+	// timestamp of the metric value, in ISO 8601 duration format.
+	TimestampAccuracy string
+	// Units shall specify the units of the metric, following the Unified
+	// Code for Units of Measure.
+	Units string
+	// Wildcards shall contain the wildcards and their substitution values
+	// for the entries in the MetricProperties array property.
+	Wildcards []Wildcard
+}
+
+// UnmarshalJSON unmarshals a MetricDefinition object from the raw JSON.
+func (metricdefinition *MetricDefinition) UnmarshalJSON(b []byte) error {
+	type temp MetricDefinition
+	var t struct {
+		temp
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*metricdefinition = MetricDefinition(t.temp)
+
+	return nil
+}
+
+// GetMetricDefinition will get a MetricDefinition instance from the service.
+func GetMetricDefinition(c common.Client, uri string) (*MetricDefinition, error) {
+	var metricDefinition MetricDefinition
+	return &metricDefinition, metricDefinition.Get(c, uri, &metricDefinition)
+}
+
+// ListReferencedMetricDefinitions gets the collection of MetricDefinition from
+// a provided reference.
+func ListReferencedMetricDefinitions(c common.Client, link string) ([]*MetricDefinition, error) { //nolint:dupl
+	var result []*MetricDefinition
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *MetricDefinition
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		metricdefinition, err := GetMetricDefinition(c, link)
+		ch <- GetResult{Item: metricdefinition, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,73 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var metricDefinitionBody = `{
+		"@odata.id": "/redfish/v1/TelemetryService/MetricDefinitions/PowerConsumedWatts",
+		"@odata.type": "#MetricDefinition.v1_3_3.MetricDefinition",
+		"Id": "PowerConsumedWatts",
+		"Name": "Power Consumed Watts Metric Definition",
+		"MetricType": "Numeric",
+		"Implementation": "PhysicalSensor",
+		"PhysicalContext": "PowerSupply",
+		"MetricDataType": "Decimal",
+		"Units": "W",
+		"Precision": 4,
+		"Accuracy": 1.5,
+		"Calibration": 2,
+		"MinReadingRange": 0,
+		"MaxReadingRange": 50,
+		"SensingInterval": "PT1S",
+		"TimestampAccuracy": "PT1S",
+		"Wildcards": [
+			{
+				"Name": "ChassisID",
+				"Values": ["1"]
+			}
+		],
+		"MetricProperties": [
+			"/redfish/v1/Chassis/{ChassisID}/Power#/PowerControl/0/PowerConsumedWatts"
+		]
+	}`
+
+// TestMetricDefinition tests the parsing of MetricDefinition objects.
+func TestMetricDefinition(t *testing.T) {
+	var result MetricDefinition
+	err := json.NewDecoder(strings.NewReader(metricDefinitionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PowerConsumedWatts" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.MetricType != NumericMetricType {
+		t.Errorf("Invalid MetricType: %s", result.MetricType)
+	}
+
+	if result.MetricDataType != DecimalMetricDataType {
+		t.Errorf("Invalid MetricDataType: %s", result.MetricDataType)
+	}
+
+	if result.Implementation != PhysicalSensorImplementationType {
+		t.Errorf("Invalid Implementation: %s", result.Implementation)
+	}
+
+	if result.Units != "W" {
+		t.Errorf("Invalid Units: %s", result.Units)
+	}
+
+	if result.Wildcards[0].Name != "ChassisID" {
+		t.Errorf("Invalid wildcard: %v", result.Wildcards)
+	}
+}
@@ -0,0 +1,237 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// MetricValue shall contain properties that capture a metric value and other
+// associated information.
+type MetricValue struct {
+	// MetricDefinition shall contain a link to a resource of type
+	// MetricDefinition that describes what this metric value captures.
+	MetricDefinition string
+	// MetricID shall contain the same value as the ID property of the source
+	// metric within the MetricReportDefinition.
+	MetricID string `json:"MetricId"`
+	// MetricProperty shall contain a URI following RFC6901-specified JSON
+	// pointer notation to the property from which this metric is derived.
+	MetricProperty string
+	// MetricValue shall contain the metric value, as a string.
+	MetricValue string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Timestamp shall time when the metric value was obtained. Note that this
+	// may be different from the time when this instance is created.
+	Timestamp string
+}
+
+// UnmarshalJSON unmarshals a MetricValue object from the raw JSON.
+func (metricvalue *MetricValue) UnmarshalJSON(b []byte) error {
+	type temp MetricValue
+	var t struct {
+		temp
+		MetricDefinition common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*metricvalue = MetricValue(t.temp)
+	metricvalue.MetricDefinition = t.MetricDefinition.String()
+
+	return nil
+}
+
+// MetricSample is a metric value with its parsed timestamp.
+type MetricSample struct {
+	// MetricID is the ID of the source metric within the
+	// MetricReportDefinition.
+	MetricID string
+	// MetricProperty is the URI of the property the metric is derived from.
+	MetricProperty string
+	// Timestamp is the time when the value was obtained.
+	Timestamp time.Time
+	// Value is the metric value, as reported by the service.
+	Value string
+}
+
+// Float64 returns the metric value as a number.
+func (sample MetricSample) Float64() (float64, error) {
+	return strconv.ParseFloat(sample.Value, 64)
+}
+
+// Key identifies the metric a sample belongs to: the metric property if the
+// service reported one, the metric ID otherwise.
+func (sample MetricSample) Key() string {
+	if sample.MetricProperty != "" {
+		return sample.MetricProperty
+	}
+	return sample.MetricID
+}
+
+// MetricReport shall represent a metric report in a Redfish implementation.
+// When a metric report is deleted, the historic metric data used to generate
+// the report shall be deleted as well.
+type MetricReport struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Context shall contain a client supplied context for the event
+	// destination to which this metric report is being sent.
+	Context string
+	// Description provides a description of this resource.
+	Description string
+	// MetricReportDefinition shall be a link to the metric report definition
+	// that generated this report.
+	MetricReportDefinition string
+	// MetricValues shall be metric values for this metric report.
+	MetricValues []MetricValue
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// ReportSequence shall contain a sequence identifier for the report.
+	ReportSequence string
+	// Timestamp shall be the time when the metric report was produced.
+	Timestamp string
+}
+
+// UnmarshalJSON unmarshals a MetricReport object from the raw JSON.
+func (metricreport *MetricReport) UnmarshalJSON(b []byte) error {
+	type temp MetricReport
+	var t struct {
+		temp
+		MetricReportDefinition common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*metricreport = MetricReport(t.temp)
+	metricreport.MetricReportDefinition = t.MetricReportDefinition.String()
+
+	return nil
+}
+
+// Samples decodes the metric values of the report. Values without a
+// timestamp of their own are given the timestamp of the report.
+func (metricreport *MetricReport) Samples() ([]MetricSample, error) {
+	var reportTime time.Time
+	if metricreport.Timestamp != "" {
+		var err error
+		reportTime, err = time.Parse(time.RFC3339, metricreport.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	samples := make([]MetricSample, 0, len(metricreport.MetricValues))
+	for i := range metricreport.MetricValues {
+		value := &metricreport.MetricValues[i]
+		sample := MetricSample{
+			MetricID:       value.MetricID,
+			MetricProperty: value.MetricProperty,
+			Timestamp:      reportTime,
+			Value:          value.MetricValue,
+		}
+
+		if value.Timestamp != "" {
+			timestamp, err := time.Parse(time.RFC3339, value.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			sample.Timestamp = timestamp
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
+
+// Series groups the samples of the report by metric, in chronological order.
+// The series are keyed by the metric property if the service reported one,
+// by the metric ID otherwise.
+func (metricreport *MetricReport) Series() (map[string][]MetricSample, error) {
+	samples, err := metricreport.Samples()
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[string][]MetricSample)
+	for _, sample := range samples {
+		series[sample.Key()] = append(series[sample.Key()], sample)
+	}
+
+	for _, values := range series {
+		sort.SliceStable(values, func(i, j int) bool {
+			return values[i].Timestamp.Before(values[j].Timestamp)
+		})
+	}
+
+	return series, nil
+}
+
+// GetMetricReport will get a MetricReport instance from the service.
+func GetMetricReport(c common.Client, uri string) (*MetricReport, error) {
+	var metricReport MetricReport
+	return &metricReport, metricReport.Get(c, uri, &metricReport)
+}
+
+// ListReferencedMetricReports gets the collection of MetricReport from
+// a provided reference.
+func ListReferencedMetricReports(c common.Client, link string) ([]*MetricReport, error) { //nolint:dupl
+	var result []*MetricReport
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *MetricReport
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		metricreport, err := GetMetricReport(c, link)
+		ch <- GetResult{Item: metricreport, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,105 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var metricReportBody = `{
+		"@odata.id": "/redfish/v1/TelemetryService/MetricReports/PowerMetrics",
+		"@odata.type": "#MetricReport.v1_4_2.MetricReport",
+		"Id": "PowerMetrics",
+		"Name": "Power Metrics Report",
+		"MetricReportDefinition": {
+			"@odata.id": "/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics"
+		},
+		"Timestamp": "2023-04-12T10:00:30Z",
+		"MetricValues": [
+			{
+				"MetricId": "AverageConsumedWatts",
+				"MetricValue": "102",
+				"Timestamp": "2023-04-12T10:00:20Z",
+				"MetricProperty": "/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"
+			},
+			{
+				"MetricId": "AverageConsumedWatts",
+				"MetricValue": "100",
+				"Timestamp": "2023-04-12T10:00:10Z",
+				"MetricProperty": "/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"
+			},
+			{
+				"MetricId": "InletTemp",
+				"MetricValue": "22.5"
+			}
+		]
+	}`
+
+// TestMetricReport tests the parsing of MetricReport objects.
+func TestMetricReport(t *testing.T) {
+	var result MetricReport
+	err := json.NewDecoder(strings.NewReader(metricReportBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PowerMetrics" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.MetricReportDefinition != "/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics" {
+		t.Errorf("Invalid MetricReportDefinition: %s", result.MetricReportDefinition)
+	}
+
+	if len(result.MetricValues) != 3 {
+		t.Errorf("Expected 3 metric values, got %d", len(result.MetricValues))
+	}
+}
+
+// TestMetricReportSeries tests decoding metric values into samples.
+func TestMetricReportSeries(t *testing.T) {
+	var result MetricReport
+	err := json.NewDecoder(strings.NewReader(metricReportBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	series, err := result.Series()
+	if err != nil {
+		t.Fatalf("Error decoding samples: %s", err)
+	}
+
+	power := series["/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"]
+	if len(power) != 2 {
+		t.Fatalf("Expected 2 power samples, got %d", len(power))
+	}
+
+	if !power[0].Timestamp.Equal(time.Date(2023, 4, 12, 10, 0, 10, 0, time.UTC)) {
+		t.Errorf("Samples should be in chronological order: %s", power[0].Timestamp)
+	}
+
+	if value, err := power[1].Float64(); err != nil || value != 102 {
+		t.Errorf("Invalid sample value: %v (%v)", value, err)
+	}
+
+	inlet := series["InletTemp"]
+	if len(inlet) != 1 {
+		t.Fatalf("Expected 1 inlet sample, got %d", len(inlet))
+	}
+
+	if !inlet[0].Timestamp.Equal(time.Date(2023, 4, 12, 10, 0, 30, 0, time.UTC)) {
+		t.Errorf("Samples without timestamp should use the report time: %s", inlet[0].Timestamp)
+	}
+
+	result.MetricValues[0].Timestamp = "yesterday"
+	if _, err := result.Samples(); err == nil {
+		t.Error("Invalid timestamps should be reported")
+	}
+}
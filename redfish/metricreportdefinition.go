@@ -0,0 +1,381 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// MetricReportDefinitionType is when a metric report is generated.
+type MetricReportDefinitionType string
+
+const (
+	// PeriodicMetricReportDefinitionType The metric report is generated at a
+	// periodic time interval, specified in the Schedule property.
+	PeriodicMetricReportDefinitionType MetricReportDefinitionType = "Periodic"
+	// OnChangeMetricReportDefinitionType The metric report is generated when
+	// any of the metric values change.
+	OnChangeMetricReportDefinitionType MetricReportDefinitionType = "OnChange"
+	// OnRequestMetricReportDefinitionType The metric report is generated
+	// when an HTTP GET is performed on the specified metric report.
+	OnRequestMetricReportDefinitionType MetricReportDefinitionType = "OnRequest"
+)
+
+// ReportActionsEnum is the action to perform when a metric report is
+// generated.
+type ReportActionsEnum string
+
+const (
+	// LogToMetricReportsCollectionReportActionsEnum shall record the
+	// occurrence to the metric report collection.
+	LogToMetricReportsCollectionReportActionsEnum ReportActionsEnum = "LogToMetricReportsCollection"
+	// RedfishEventReportActionsEnum shall send a Redfish event message
+	// containing the metric report.
+	RedfishEventReportActionsEnum ReportActionsEnum = "RedfishEvent"
+)
+
+// ReportUpdatesEnum is how subsequent metric reports are handled when a
+// metric report exists.
+type ReportUpdatesEnum string
+
+const (
+	// OverwriteReportUpdatesEnum shall overwrite the metric report.
+	OverwriteReportUpdatesEnum ReportUpdatesEnum = "Overwrite"
+	// AppendWrapsWhenFullReportUpdatesEnum shall append new information to
+	// the metric report and wrap when the metric report buffer is full.
+	AppendWrapsWhenFullReportUpdatesEnum ReportUpdatesEnum = "AppendWrapsWhenFull"
+	// AppendStopsWhenFullReportUpdatesEnum shall append new information to
+	// the metric report and stop adding when the metric report buffer is
+	// full.
+	AppendStopsWhenFullReportUpdatesEnum ReportUpdatesEnum = "AppendStopsWhenFull"
+	// NewReportReportUpdatesEnum shall create a new metric report, named
+	// after the metric report definition with an appended timestamp.
+	NewReportReportUpdatesEnum ReportUpdatesEnum = "NewReport"
+)
+
+// CalculationAlgorithmEnum is the calculation applied to a metric over its
+// collection duration.
+type CalculationAlgorithmEnum string
+
+const (
+	// AverageCalculationAlgorithmEnum The metric shall be calculated as the
+	// average metric reading over a duration.
+	AverageCalculationAlgorithmEnum CalculationAlgorithmEnum = "Average"
+	// MaximumCalculationAlgorithmEnum The metric shall be calculated as the
+	// maximum metric reading over a duration.
+	MaximumCalculationAlgorithmEnum CalculationAlgorithmEnum = "Maximum"
+	// MinimumCalculationAlgorithmEnum The metric shall be calculated as the
+	// minimum metric reading over a duration.
+	MinimumCalculationAlgorithmEnum CalculationAlgorithmEnum = "Minimum"
+	// SummationCalculationAlgorithmEnum The metric shall be calculated as the
+	// sum of the values over a duration.
+	SummationCalculationAlgorithmEnum CalculationAlgorithmEnum = "Summation"
+)
+
+// Metric shall specify a set of metrics to include in the metric report.
+type Metric struct {
+	// CollectionDuration shall specify the duration over which the function
+	// is computed, in ISO 8601 duration format.
+	CollectionDuration string `json:",omitempty"`
+	// CollectionFunction shall specify the function to perform on each of
+	// the metric properties listed in the MetricProperties property.
+	CollectionFunction CalculationAlgorithmEnum `json:",omitempty"`
+	// CollectionTimeScope shall specify the scope of time over which the
+	// function is applied.
+	CollectionTimeScope string `json:",omitempty"`
+	// MetricID shall contain the ID of the metric.
+	MetricID string `json:"MetricId,omitempty"`
+	// MetricProperties shall list the URIs, with wildcards, of the properties
+	// to capture in the metric report.
+	MetricProperties []string `json:",omitempty"`
+}
+
+// MetricReportDefinition shall specify a set of metrics that shall be
+// collected into a metric report in a Redfish implementation.
+type MetricReportDefinition struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AppendLimit shall contain the maximum number of entries that can be
+	// appended to a metric report.
+	AppendLimit int
+	// Description provides a description of this resource.
+	Description string
+	// MetricProperties shall list the URIs, with wildcards, of the properties
+	// to capture in the metric report.
+	MetricProperties []string
+	// MetricReportDefinitionEnabled shall indicate whether the generation of
+	// new metric reports is enabled.
+	MetricReportDefinitionEnabled bool
+	// MetricReportDefinitionType shall specify when the metric report is
+	// generated.
+	MetricReportDefinitionType MetricReportDefinitionType
+	// MetricReportHeartbeatInterval shall contain the interval after which
+	// a metric report is generated even if no metric value changed, in ISO
+	// 8601 duration format.
+	MetricReportHeartbeatInterval string
+	// Metrics shall specify a list of metrics to include in the metric
+	// report.
+	Metrics []Metric
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// ReportActions shall specify the actions to perform when a metric
+	// report is generated.
+	ReportActions []ReportActionsEnum
+	// ReportTimespan shall specify the maximum timespan that a metric report
+	// can cover, in ISO 8601 duration format.
+	ReportTimespan string
+	// ReportUpdates shall specify what is done when a metric report is
+	// generated and a report already exists.
+	ReportUpdates ReportUpdatesEnum
+	// Schedule shall specify the schedule for generating the metric report,
+	// if MetricReportDefinitionType is Periodic.
+	Schedule common.Schedule
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// SuppressRepeatedMetricValue shall indicate whether any metrics are
+	// suppressed from the generated metric report when their value did not
+	// change.
+	SuppressRepeatedMetricValue bool
+	// Wildcards shall contain the wildcards and their substitution values
+	// for the entries in the MetricProperties array property.
+	Wildcards []Wildcard
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+	// metricReport is the link to the most recent metric report generated
+	// by this definition.
+	metricReport string
+	// triggers are the triggers that cause this definition to generate a
+	// metric report.
+	triggers []string
+}
+
+// UnmarshalJSON unmarshals a MetricReportDefinition object from the raw JSON.
+func (metricreportdefinition *MetricReportDefinition) UnmarshalJSON(b []byte) error {
+	type temp MetricReportDefinition
+	var t struct {
+		temp
+		MetricReport common.Link
+		Links        struct {
+			Triggers common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*metricreportdefinition = MetricReportDefinition(t.temp)
+
+	// Extract the links to other entities for later
+	metricreportdefinition.metricReport = t.MetricReport.String()
+	metricreportdefinition.triggers = t.Links.Triggers.ToStrings()
+
+	// This is a read/write object, so we need to save the raw object data for later
+	metricreportdefinition.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (metricreportdefinition *MetricReportDefinition) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(MetricReportDefinition)
+	err := original.UnmarshalJSON(metricreportdefinition.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"MetricReportDefinitionEnabled",
+		"MetricReportDefinitionType",
+		"MetricReportHeartbeatInterval",
+		"ReportTimespan",
+		"ReportUpdates",
+		"SuppressRepeatedMetricValue",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(metricreportdefinition).Elem()
+
+	return metricreportdefinition.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// MetricReport gets the most recent metric report generated by this
+// definition.
+func (metricreportdefinition *MetricReportDefinition) MetricReport() (*MetricReport, error) {
+	if metricreportdefinition.metricReport == "" {
+		return nil, nil
+	}
+	return GetMetricReport(metricreportdefinition.Client, metricreportdefinition.metricReport)
+}
+
+// Triggers gets the triggers that cause this definition to generate a metric
+// report.
+func (metricreportdefinition *MetricReportDefinition) Triggers() ([]*Triggers, error) {
+	var result []*Triggers
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range metricreportdefinition.triggers {
+		trigger, err := GetTriggers(metricreportdefinition.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, trigger)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// MetricReportDefinitionParameters contains the properties of a new metric
+// report definition.
+type MetricReportDefinitionParameters struct {
+	// ID is the identifier of the new definition, which also names the metric
+	// reports it generates. The service assigns one if it is empty.
+	ID string `json:"Id,omitempty"`
+	// Name is the name of the new definition.
+	Name string `json:",omitempty"`
+	// MetricReportDefinitionType specifies when the metric report is
+	// generated.
+	MetricReportDefinitionType MetricReportDefinitionType
+	// Schedule specifies when periodic metric reports are generated.
+	Schedule *common.Schedule `json:",omitempty"`
+	// ReportActions specifies the actions to perform when a metric report is
+	// generated.
+	ReportActions []ReportActionsEnum `json:",omitempty"`
+	// ReportUpdates specifies what is done when a metric report is generated
+	// and a report already exists.
+	ReportUpdates ReportUpdatesEnum `json:",omitempty"`
+	// AppendLimit is the maximum number of entries that can be appended to a
+	// metric report.
+	AppendLimit int `json:",omitempty"`
+	// ReportTimespan is the maximum timespan that a metric report can cover.
+	ReportTimespan string `json:",omitempty"`
+	// MetricProperties lists the URIs of the properties to capture.
+	MetricProperties []string `json:",omitempty"`
+	// Metrics specifies the metrics to include in the metric report.
+	Metrics []Metric `json:",omitempty"`
+	// Wildcards contains the substitution values for wildcards used in the
+	// metric properties.
+	Wildcards []Wildcard `json:",omitempty"`
+}
+
+// CreateMetricReportDefinition creates a metric report definition in the
+// collection at uri. It returns the link to the new definition.
+func CreateMetricReportDefinition(c common.Client, uri string, parameters *MetricReportDefinitionParameters) (string, error) {
+	if strings.TrimSpace(uri) == "" {
+		return "", fmt.Errorf("uri should not be empty")
+	}
+	if parameters == nil {
+		return "", fmt.Errorf("metric report definition parameters must be supplied")
+	}
+	if parameters.MetricReportDefinitionType == PeriodicMetricReportDefinitionType &&
+		(parameters.Schedule == nil || parameters.Schedule.RecurrenceInterval == "") {
+		return "", fmt.Errorf("periodic metric report definitions require a schedule recurrence interval")
+	}
+
+	payload := struct {
+		*MetricReportDefinitionParameters
+		Schedule *scheduleParameters `json:",omitempty"`
+	}{
+		MetricReportDefinitionParameters: parameters,
+		Schedule:                         newScheduleParameters(parameters.Schedule),
+	}
+
+	resp, err := c.Post(uri, payload)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// return the definition link from returned location
+	definitionLink := resp.Header.Get("Location")
+	if urlParser, err := url.ParseRequestURI(definitionLink); err == nil {
+		definitionLink = urlParser.RequestURI()
+	}
+
+	return definitionLink, nil
+}
+
+// DeleteMetricReportDefinition will delete a MetricReportDefinition.
+func DeleteMetricReportDefinition(c common.Client, uri string) error {
+	// validate uri
+	if strings.TrimSpace(uri) == "" {
+		return fmt.Errorf("uri should not be empty")
+	}
+
+	resp, err := c.Delete(uri)
+	if err == nil {
+		defer resp.Body.Close()
+	}
+
+	return err
+}
+
+// GetMetricReportDefinition will get a MetricReportDefinition instance from the service.
+func GetMetricReportDefinition(c common.Client, uri string) (*MetricReportDefinition, error) {
+	var metricReportDefinition MetricReportDefinition
+	return &metricReportDefinition, metricReportDefinition.Get(c, uri, &metricReportDefinition)
+}
+
+// ListReferencedMetricReportDefinitions gets the collection of MetricReportDefinition from
+// a provided reference.
+func ListReferencedMetricReportDefinitions(c common.Client, link string) ([]*MetricReportDefinition, error) { //nolint:dupl
+	var result []*MetricReportDefinition
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *MetricReportDefinition
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		metricreportdefinition, err := GetMetricReportDefinition(c, link)
+		ch <- GetResult{Item: metricreportdefinition, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
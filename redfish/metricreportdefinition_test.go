@@ -0,0 +1,179 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var metricReportDefinitionBody = `{
+		"@odata.id": "/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics",
+		"@odata.type": "#MetricReportDefinition.v1_4_2.MetricReportDefinition",
+		"Id": "PowerMetrics",
+		"Name": "Transmit and Log Power Metrics",
+		"MetricReportDefinitionType": "Periodic",
+		"MetricReportDefinitionEnabled": true,
+		"Schedule": {
+			"RecurrenceInterval": "PT1M"
+		},
+		"ReportActions": ["RedfishEvent", "LogToMetricReportsCollection"],
+		"ReportUpdates": "AppendWrapsWhenFull",
+		"AppendLimit": 256,
+		"Status": {
+			"State": "Enabled"
+		},
+		"Metrics": [
+			{
+				"MetricId": "AverageConsumedWatts",
+				"CollectionFunction": "Average",
+				"CollectionDuration": "PT1M",
+				"MetricProperties": [
+					"/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"
+				]
+			}
+		],
+		"MetricReport": {
+			"@odata.id": "/redfish/v1/TelemetryService/MetricReports/PowerMetrics"
+		},
+		"Links": {
+			"Triggers": [
+				{
+					"@odata.id": "/redfish/v1/TelemetryService/Triggers/PlatformPowerCapTriggers"
+				}
+			]
+		}
+	}`
+
+// TestMetricReportDefinition tests the parsing of MetricReportDefinition objects.
+func TestMetricReportDefinition(t *testing.T) {
+	var result MetricReportDefinition
+	err := json.NewDecoder(strings.NewReader(metricReportDefinitionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PowerMetrics" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.MetricReportDefinitionType != PeriodicMetricReportDefinitionType {
+		t.Errorf("Invalid MetricReportDefinitionType: %s", result.MetricReportDefinitionType)
+	}
+
+	if result.Schedule.RecurrenceInterval != "PT1M" {
+		t.Errorf("Invalid RecurrenceInterval: %s", result.Schedule.RecurrenceInterval)
+	}
+
+	if result.Metrics[0].CollectionFunction != AverageCalculationAlgorithmEnum {
+		t.Errorf("Invalid CollectionFunction: %s", result.Metrics[0].CollectionFunction)
+	}
+
+	if result.metricReport != "/redfish/v1/TelemetryService/MetricReports/PowerMetrics" {
+		t.Errorf("Invalid MetricReport link: %s", result.metricReport)
+	}
+
+	if result.triggers[0] != "/redfish/v1/TelemetryService/Triggers/PlatformPowerCapTriggers" {
+		t.Errorf("Invalid Triggers link: %v", result.triggers)
+	}
+}
+
+// TestMetricReportDefinitionUpdate tests the Update call.
+func TestMetricReportDefinitionUpdate(t *testing.T) {
+	var result MetricReportDefinition
+	err := json.NewDecoder(strings.NewReader(metricReportDefinitionBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.MetricReportDefinitionEnabled = false
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "MetricReportDefinitionEnabled:false") {
+		t.Errorf("Unexpected MetricReportDefinitionEnabled update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestCreateMetricReportDefinition tests creating a metric report definition.
+func TestCreateMetricReportDefinition(t *testing.T) {
+	resp := testResponse(http.StatusOK, "", nil)
+	resp.StatusCode = http.StatusCreated
+	resp.Header = http.Header{}
+	resp.Header.Set("Location", "https://bmc/redfish/v1/TelemetryService/MetricReportDefinitions/Thermal")
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {resp},
+		},
+	}
+
+	_, err := CreateMetricReportDefinition(testClient, "/redfish/v1/TelemetryService/MetricReportDefinitions",
+		&MetricReportDefinitionParameters{
+			ID:                         "Thermal",
+			MetricReportDefinitionType: PeriodicMetricReportDefinitionType,
+		})
+	if err == nil {
+		t.Error("Periodic definitions without a schedule should be rejected")
+	}
+
+	link, err := CreateMetricReportDefinition(testClient, "/redfish/v1/TelemetryService/MetricReportDefinitions",
+		&MetricReportDefinitionParameters{
+			ID:                         "Thermal",
+			MetricReportDefinitionType: PeriodicMetricReportDefinitionType,
+			Schedule:                   &common.Schedule{RecurrenceInterval: "PT10S"},
+			ReportActions:              []ReportActionsEnum{LogToMetricReportsCollectionReportActionsEnum},
+			MetricProperties:           []string{"/redfish/v1/Chassis/1/Thermal#/Temperatures/0/ReadingCelsius"},
+		})
+	if err != nil {
+		t.Fatalf("Error creating metric report definition: %s", err)
+	}
+
+	if link != "/redfish/v1/TelemetryService/MetricReportDefinitions/Thermal" {
+		t.Errorf("Invalid link to the new definition: %s", link)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(calls))
+	}
+
+	for _, expected := range []string{
+		"Id:Thermal",
+		"Schedule:map[RecurrenceInterval:PT10S]",
+		"ReportActions:[LogToMetricReportsCollection]",
+	} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Unexpected create payload: %s", calls[0].Payload)
+		}
+	}
+
+	if strings.Contains(calls[0].Payload, "AppendLimit") {
+		t.Errorf("Unset properties should not be sent: %s", calls[0].Payload)
+	}
+
+	err = DeleteMetricReportDefinition(testClient, link)
+	if err != nil {
+		t.Errorf("Error deleting metric report definition: %s", err)
+	}
+
+	calls = testClient.CapturedCalls()
+	if calls[1].Action != http.MethodDelete || calls[1].URL != link {
+		t.Errorf("Unexpected delete call: %v", calls[1])
+	}
+}
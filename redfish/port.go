@@ -0,0 +1,322 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// PortType is the type of a port.
+type PortType string
+
+const (
+	// UpstreamPortPortType This port connects to a host device.
+	UpstreamPortPortType PortType = "UpstreamPort"
+	// DownstreamPortPortType This port connects to a target device.
+	DownstreamPortPortType PortType = "DownstreamPort"
+	// InterswitchPortPortType This port connects to another switch.
+	InterswitchPortPortType PortType = "InterswitchPort"
+	// ManagementPortPortType This port connects to a switch manager.
+	ManagementPortPortType PortType = "ManagementPort"
+	// BidirectionalPortPortType This port connects to any type of device.
+	BidirectionalPortPortType PortType = "BidirectionalPort"
+	// UnconfiguredPortPortType This port has not yet been configured.
+	UnconfiguredPortPortType PortType = "UnconfiguredPort"
+)
+
+// PortMedium is the physical transport medium of a port.
+type PortMedium string
+
+const (
+	// ElectricalPortMedium This port has an electrical cable connection.
+	ElectricalPortMedium PortMedium = "Electrical"
+	// OpticalPortMedium This port has an optical cable connection.
+	OpticalPortMedium PortMedium = "Optical"
+)
+
+// PortLinkState is the desired link state of a port.
+type PortLinkState string
+
+const (
+	// EnabledPortLinkState The link is enabled and operational.
+	EnabledPortLinkState PortLinkState = "Enabled"
+	// DisabledPortLinkState The link is disabled and not operational.
+	DisabledPortLinkState PortLinkState = "Disabled"
+)
+
+const (
+	// StartingLinkStatus This link on this interface is starting. A physical
+	// link has been established, but the port is not able to transfer data.
+	StartingLinkStatus LinkStatus = "Starting"
+	// TrainingLinkStatus This physical link on this interface is training.
+	TrainingLinkStatus LinkStatus = "Training"
+)
+
+// Port is used to represent a simple port for a Redfish implementation.
+type Port struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CurrentSpeedGbps shall contain the unidirectional speed of this port
+	// currently negotiated and running.
+	CurrentSpeedGbps float32
+	// Description provides a description of this resource.
+	Description string
+	// Enabled shall indicate if this port is enabled.
+	Enabled bool
+	// InterfaceEnabled shall indicate whether the interface is enabled.
+	InterfaceEnabled bool
+	// LinkNetworkTechnology shall contain a network technology that is
+	// currently negotiated on this port.
+	LinkNetworkTechnology LinkNetworkTechnology
+	// LinkState shall contain the desired link state for this interface.
+	LinkState PortLinkState
+	// LinkStatus shall contain the link status for this interface.
+	LinkStatus LinkStatus
+	// Location shall contain location information of the associated port.
+	Location common.Location
+	// MaxSpeedGbps shall contain the maximum frequency at which this port is
+	// capable of sustaining data transfer.
+	MaxSpeedGbps float32
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PortID shall contain the name of the port as indicated on the device
+	// containing the port.
+	PortID string `json:"PortId"`
+	// PortMedium shall contain the physical transport medium for this port.
+	PortMedium PortMedium
+	// PortProtocol shall contain the protocol being sent over this port.
+	PortProtocol common.Protocol
+	// PortType shall contain the port type for this port.
+	PortType PortType
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Width shall contain the number of physical transport links that this
+	// port contains.
+	Width int
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+	// associatedEndpoints are the endpoints to which this port is connected.
+	associatedEndpoints []string
+	// connectedPorts are the remote device ports connected to this port.
+	connectedPorts []string
+	// connectedSwitches are the switches at the other end of the link.
+	connectedSwitches []string
+	// connectedSwitchPorts are the ports at the other end of the link.
+	connectedSwitchPorts []string
+	// resetTarget is the URL to send Reset requests.
+	resetTarget string
+}
+
+// UnmarshalJSON unmarshals a Port object from the raw JSON.
+func (port *Port) UnmarshalJSON(b []byte) error {
+	type temp Port
+	type actions struct {
+		Reset struct {
+			Target string
+		} `json:"#Port.Reset"`
+	}
+	type links struct {
+		AssociatedEndpoints  common.Links
+		ConnectedPorts       common.Links
+		ConnectedSwitches    common.Links
+		ConnectedSwitchPorts common.Links
+	}
+	var t struct {
+		temp
+		Actions actions
+		Links   links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*port = Port(t.temp)
+
+	// Extract the links to other entities for later
+	port.associatedEndpoints = t.Links.AssociatedEndpoints.ToStrings()
+	port.connectedPorts = t.Links.ConnectedPorts.ToStrings()
+	port.connectedSwitches = t.Links.ConnectedSwitches.ToStrings()
+	port.connectedSwitchPorts = t.Links.ConnectedSwitchPorts.ToStrings()
+	port.resetTarget = t.Actions.Reset.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	port.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (port *Port) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Port)
+	err := original.UnmarshalJSON(port.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"Enabled",
+		"InterfaceEnabled",
+		"LinkState",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(port).Elem()
+
+	return port.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Reset shall reset this port.
+func (port *Port) Reset(resetType ResetType) error {
+	t := struct {
+		ResetType ResetType
+	}{ResetType: resetType}
+
+	return port.Post(port.resetTarget, t)
+}
+
+// AssociatedEndpoints gets the endpoints to which this port is connected.
+func (port *Port) AssociatedEndpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range port.associatedEndpoints {
+		item, err := GetEndpoint(port.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ConnectedPorts gets the remote device ports connected to this port.
+func (port *Port) ConnectedPorts() ([]*Port, error) {
+	var result []*Port
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range port.connectedPorts {
+		item, err := GetPort(port.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ConnectedSwitches gets the switches at the other end of the link.
+func (port *Port) ConnectedSwitches() ([]*Switch, error) {
+	var result []*Switch
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range port.connectedSwitches {
+		item, err := GetSwitch(port.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ConnectedSwitchPorts gets the switch ports at the other end of the link.
+func (port *Port) ConnectedSwitchPorts() ([]*Port, error) {
+	var result []*Port
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range port.connectedSwitchPorts {
+		item, err := GetPort(port.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// GetPort will get a Port instance from the service.
+func GetPort(c common.Client, uri string) (*Port, error) {
+	var port Port
+	return &port, port.Get(c, uri, &port)
+}
+
+// ListReferencedPorts gets the collection of Port from
+// a provided reference.
+func ListReferencedPorts(c common.Client, link string) ([]*Port, error) { //nolint:dupl
+	var result []*Port
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Port
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		port, err := GetPort(c, link)
+		ch <- GetResult{Item: port, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
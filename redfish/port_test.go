@@ -0,0 +1,115 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var portBody = `{
+		"@odata.id": "/redfish/v1/Fabrics/PCIe/Switches/1/Ports/Up1",
+		"@odata.type": "#Port.v1_7_0.Port",
+		"Id": "Up1",
+		"Name": "PCIe Upstream Port 1",
+		"PortId": "1",
+		"PortProtocol": "PCIe",
+		"PortType": "UpstreamPort",
+		"PortMedium": "Electrical",
+		"CurrentSpeedGbps": 128,
+		"MaxSpeedGbps": 128,
+		"Width": 16,
+		"Enabled": true,
+		"LinkState": "Enabled",
+		"LinkStatus": "Training",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"AssociatedEndpoints": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/PCIe/Endpoints/HostRootComplex1"
+				}
+			],
+			"ConnectedSwitches": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/PCIe/Switches/2"
+				}
+			]
+		},
+		"Actions": {
+			"#Port.Reset": {
+				"target": "/redfish/v1/Fabrics/PCIe/Switches/1/Ports/Up1/Actions/Port.Reset"
+			}
+		}
+	}`
+
+// TestPort tests the parsing of Port objects.
+func TestPort(t *testing.T) {
+	var result Port
+	err := json.NewDecoder(strings.NewReader(portBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Up1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.PortType != UpstreamPortPortType {
+		t.Errorf("Invalid PortType: %s", result.PortType)
+	}
+
+	if result.PortProtocol != common.PCIeProtocol {
+		t.Errorf("Invalid PortProtocol: %s", result.PortProtocol)
+	}
+
+	if result.LinkStatus != TrainingLinkStatus {
+		t.Errorf("Invalid LinkStatus: %s", result.LinkStatus)
+	}
+
+	if result.Width != 16 {
+		t.Errorf("Invalid Width: %d", result.Width)
+	}
+
+	if result.associatedEndpoints[0] != "/redfish/v1/Fabrics/PCIe/Endpoints/HostRootComplex1" {
+		t.Errorf("Invalid AssociatedEndpoints links: %v", result.associatedEndpoints)
+	}
+
+	if result.connectedSwitches[0] != "/redfish/v1/Fabrics/PCIe/Switches/2" {
+		t.Errorf("Invalid ConnectedSwitches links: %v", result.connectedSwitches)
+	}
+}
+
+// TestPortUpdate tests the Update call.
+func TestPortUpdate(t *testing.T) {
+	var result Port
+	err := json.NewDecoder(strings.NewReader(portBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LinkState = DisabledPortLinkState
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LinkState:Disabled") {
+		t.Errorf("Unexpected LinkState update payload: %s", calls[0].Payload)
+	}
+}
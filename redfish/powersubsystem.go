@@ -0,0 +1,82 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// PowerAllocation shall contain the set of properties describing the
+// allocation of power for a subsystem.
+type PowerAllocation struct {
+	// AllocatedWatts shall contain the total amount of power, in watts,
+	// currently allocated or budgeted to this subsystem.
+	AllocatedWatts float32
+	// RequestedWatts shall contain the amount of power, in watts, that the
+	// subsystem currently requests to be budgeted for future use.
+	RequestedWatts float32
+}
+
+// PowerSubsystem shall describe the power subsystem of a chassis. It
+// replaces the deprecated Power resource.
+type PowerSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Allocation shall contain the set of properties describing the
+	// allocation of power for this subsystem.
+	Allocation PowerAllocation
+	// CapacityWatts shall contain the total power capacity that can be
+	// allocated to this subsystem.
+	CapacityWatts float32
+	// Description provides a description of this resource.
+	Description string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PowerSupplyRedundancy shall contain redundancy information for the set
+	// of power supplies in this subsystem.
+	PowerSupplyRedundancy []RedundantGroup
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// powerSupplies shall contain a link to a resource collection of type
+	// PowerSupplyCollection.
+	powerSupplies string
+}
+
+// UnmarshalJSON unmarshals a PowerSubsystem object from the raw JSON.
+func (powersubsystem *PowerSubsystem) UnmarshalJSON(b []byte) error {
+	type temp PowerSubsystem
+	var t struct {
+		temp
+		PowerSupplies common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*powersubsystem = PowerSubsystem(t.temp)
+	powersubsystem.powerSupplies = t.PowerSupplies.String()
+
+	return nil
+}
+
+// PowerSupplies gets the power supplies of this subsystem.
+func (powersubsystem *PowerSubsystem) PowerSupplies() ([]*PowerSupplyUnit, error) {
+	return ListReferencedPowerSupplyUnits(powersubsystem.Client, powersubsystem.powerSupplies)
+}
+
+// GetPowerSubsystem will get a PowerSubsystem instance from the service.
+func GetPowerSubsystem(c common.Client, uri string) (*PowerSubsystem, error) {
+	var powerSubsystem PowerSubsystem
+	return &powerSubsystem, powerSubsystem.Get(c, uri, &powerSubsystem)
+}
@@ -0,0 +1,84 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var powerSubsystemBody = `{
+		"@odata.type": "#PowerSubsystem.v1_1_0.PowerSubsystem",
+		"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem",
+		"Id": "PowerSubsystem",
+		"Name": "Power Subsystem for Chassis",
+		"CapacityWatts": 1000,
+		"Allocation": {
+			"RequestedWatts": 800,
+			"AllocatedWatts": 750
+		},
+		"PowerSupplyRedundancy": [
+			{
+				"RedundancyType": "Failover",
+				"MaxSupportedInGroup": 2,
+				"MinNeededInGroup": 1,
+				"RedundancyGroup": [
+					{
+						"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1"
+					},
+					{
+						"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay2"
+					}
+				],
+				"Status": {
+					"State": "Enabled",
+					"Health": "OK"
+				}
+			}
+		],
+		"PowerSupplies": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies"
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestPowerSubsystem tests the parsing of PowerSubsystem objects.
+func TestPowerSubsystem(t *testing.T) {
+	var result PowerSubsystem
+	err := json.NewDecoder(strings.NewReader(powerSubsystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PowerSubsystem" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.CapacityWatts != 1000 {
+		t.Errorf("Invalid CapacityWatts: %f", result.CapacityWatts)
+	}
+
+	if result.Allocation.AllocatedWatts != 750 {
+		t.Errorf("Invalid AllocatedWatts: %f", result.Allocation.AllocatedWatts)
+	}
+
+	redundancy := result.PowerSupplyRedundancy[0]
+	if redundancy.RedundancyType != FailoverRedundancyType {
+		t.Errorf("Invalid RedundancyType: %s", redundancy.RedundancyType)
+	}
+
+	if len(redundancy.RedundancyGroupLinks()) != 2 {
+		t.Errorf("Invalid RedundancyGroup links: %v", redundancy.RedundancyGroupLinks())
+	}
+
+	if result.powerSupplies != "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies" {
+		t.Errorf("Invalid PowerSupplies link: %s", result.powerSupplies)
+	}
+}
@@ -0,0 +1,43 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// PowerSupplyMetrics shall contain the metrics of a power supply.
+type PowerSupplyMetrics struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// EnergykWh shall contain the total energy, in kilowatt-hour units,
+	// consumed by this power supply.
+	EnergykWh SensorEnergykWhExcerpt
+	// InputPowerWatts shall contain the total power, in watt units, drawn by
+	// this power supply.
+	InputPowerWatts SensorPowerExcerpt
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// OutputPowerWatts shall contain the total power, in watt units,
+	// delivered by this power supply.
+	OutputPowerWatts SensorPowerExcerpt
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+}
+
+// GetPowerSupplyMetrics will get a PowerSupplyMetrics instance from the
+// service.
+func GetPowerSupplyMetrics(c common.Client, uri string) (*PowerSupplyMetrics, error) {
+	var powerSupplyMetrics PowerSupplyMetrics
+	return &powerSupplyMetrics, powerSupplyMetrics.Get(c, uri, &powerSupplyMetrics)
+}
@@ -0,0 +1,59 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var powerSupplyMetricsBody = `{
+		"@odata.type": "#PowerSupplyMetrics.v1_0_1.PowerSupplyMetrics",
+		"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Metrics",
+		"Id": "Metrics",
+		"Name": "Metrics for Power Supply 1",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"InputPowerWatts": {
+			"DataSourceUri": "/redfish/v1/Chassis/1U/Sensors/PS1InputPower",
+			"Reading": 374
+		},
+		"OutputPowerWatts": {
+			"Reading": 337
+		},
+		"EnergykWh": {
+			"Reading": 325675
+		}
+	}`
+
+// TestPowerSupplyMetrics tests the parsing of PowerSupplyMetrics objects.
+func TestPowerSupplyMetrics(t *testing.T) {
+	var result PowerSupplyMetrics
+	err := json.NewDecoder(strings.NewReader(powerSupplyMetricsBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Metrics" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.InputPowerWatts.Reading != 374 ||
+		result.InputPowerWatts.DataSourceURI != "/redfish/v1/Chassis/1U/Sensors/PS1InputPower" {
+		t.Errorf("Invalid InputPowerWatts: %v", result.InputPowerWatts)
+	}
+
+	if result.OutputPowerWatts.Reading != 337 {
+		t.Errorf("Invalid OutputPowerWatts: %v", result.OutputPowerWatts)
+	}
+
+	if result.EnergykWh.Reading != 325675 {
+		t.Errorf("Invalid EnergykWh: %v", result.EnergykWh)
+	}
+}
@@ -0,0 +1,233 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// LineStatus is the status of a power supply input line.
+type LineStatus string
+
+const (
+	// NormalLineStatus Line input is within normal operating range.
+	NormalLineStatus LineStatus = "Normal"
+	// LossOfInputLineStatus No power detected at line input.
+	LossOfInputLineStatus LineStatus = "LossOfInput"
+	// OutOfRangeLineStatus Line input voltage or current is outside of
+	// normal operating range.
+	OutOfRangeLineStatus LineStatus = "OutOfRange"
+)
+
+// PowerSupplyEfficiencyRating shall describe an efficiency rating of a
+// power supply.
+type PowerSupplyEfficiencyRating struct {
+	// EfficiencyPercent shall contain the rated efficiency, as a percentage,
+	// of this power supply at the specified load.
+	EfficiencyPercent float32
+	// LoadPercent shall contain the load, as a percentage, of this power
+	// supply at which this efficiency rating is valid.
+	LoadPercent float32
+}
+
+// PowerSupplyUnit shall represent a power supply unit for a Redfish
+// implementation, as found in the power supply collection of a
+// PowerSubsystem. It is named PowerSupplyUnit to tell it apart from the
+// PowerSupply objects embedded in the deprecated Power resource.
+type PowerSupplyUnit struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// EfficiencyRatings shall contain an array of efficiency ratings for
+	// this power supply.
+	EfficiencyRatings []PowerSupplyEfficiencyRating
+	// FirmwareVersion shall contain the firmware version as defined by the
+	// manufacturer for this power supply.
+	FirmwareVersion string
+	// HotPluggable shall indicate whether the device can be inserted or
+	// removed while the underlying equipment otherwise remains in its
+	// current operational state.
+	HotPluggable bool
+	// LineInputStatus shall contain the status of the power line input for
+	// this power supply.
+	LineInputStatus LineStatus
+	// Location shall contain location information of this power supply.
+	Location common.Location
+	// LocationIndicatorActive shall contain the state of the indicator used
+	// to physically identify or locate this resource.
+	LocationIndicatorActive bool
+	// Manufacturer shall contain the name of the organization responsible
+	// for producing the power supply.
+	Manufacturer string
+	// Model shall contain the model information as defined by the
+	// manufacturer for this power supply.
+	Model string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PartNumber shall contain the part number as defined by the
+	// manufacturer for this power supply.
+	PartNumber string
+	// PowerCapacityWatts shall contain the maximum amount of power, in
+	// watts, that this power supply is rated to deliver.
+	PowerCapacityWatts float32
+	// PowerSupplyType shall contain the input power type (AC or DC) of this
+	// power supply.
+	PowerSupplyType PowerSupplyType
+	// SerialNumber shall contain the serial number as defined by the
+	// manufacturer for this power supply.
+	SerialNumber string
+	// SparePartNumber shall contain the spare or replacement part number as
+	// defined by the manufacturer for this power supply.
+	SparePartNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// assembly shall be a link to a resource of type Assembly.
+	assembly string
+	// metrics shall be a link to a resource of type PowerSupplyMetrics.
+	metrics string
+	// resetTarget is the URL to send Reset requests.
+	resetTarget string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+}
+
+// UnmarshalJSON unmarshals a PowerSupplyUnit object from the raw JSON.
+func (powersupplyunit *PowerSupplyUnit) UnmarshalJSON(b []byte) error {
+	type temp PowerSupplyUnit
+	type actions struct {
+		Reset struct {
+			Target string
+		} `json:"#PowerSupply.Reset"`
+	}
+	var t struct {
+		temp
+		Actions  actions
+		Assembly common.Link
+		Metrics  common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*powersupplyunit = PowerSupplyUnit(t.temp)
+	powersupplyunit.assembly = t.Assembly.String()
+	powersupplyunit.metrics = t.Metrics.String()
+	powersupplyunit.resetTarget = t.Actions.Reset.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	powersupplyunit.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (powersupplyunit *PowerSupplyUnit) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(PowerSupplyUnit)
+	err := original.UnmarshalJSON(powersupplyunit.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"LocationIndicatorActive",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(powersupplyunit).Elem()
+
+	return powersupplyunit.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Assembly gets the assembly data of this power supply.
+func (powersupplyunit *PowerSupplyUnit) Assembly() (*Assembly, error) {
+	if powersupplyunit.assembly == "" {
+		return nil, nil
+	}
+	return GetAssembly(powersupplyunit.Client, powersupplyunit.assembly)
+}
+
+// Metrics gets the metrics of this power supply.
+func (powersupplyunit *PowerSupplyUnit) Metrics() (*PowerSupplyMetrics, error) {
+	if powersupplyunit.metrics == "" {
+		return nil, nil
+	}
+	return GetPowerSupplyMetrics(powersupplyunit.Client, powersupplyunit.metrics)
+}
+
+// Reset shall reset the power supply.
+func (powersupplyunit *PowerSupplyUnit) Reset(resetType ResetType) error {
+	if powersupplyunit.resetTarget == "" {
+		return common.NewActionNotSupportedError("Reset is not supported by this power supply")
+	}
+
+	t := struct {
+		ResetType ResetType `json:",omitempty"`
+	}{ResetType: resetType}
+
+	return powersupplyunit.Post(powersupplyunit.resetTarget, t)
+}
+
+// GetPowerSupplyUnit will get a PowerSupplyUnit instance from the service.
+func GetPowerSupplyUnit(c common.Client, uri string) (*PowerSupplyUnit, error) {
+	var powerSupplyUnit PowerSupplyUnit
+	return &powerSupplyUnit, powerSupplyUnit.Get(c, uri, &powerSupplyUnit)
+}
+
+// ListReferencedPowerSupplyUnits gets the collection of PowerSupplyUnit from
+// a provided reference.
+func ListReferencedPowerSupplyUnits(c common.Client, link string) ([]*PowerSupplyUnit, error) { //nolint:dupl
+	var result []*PowerSupplyUnit
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *PowerSupplyUnit
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		powersupplyunit, err := GetPowerSupplyUnit(c, link)
+		ch <- GetResult{Item: powersupplyunit, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
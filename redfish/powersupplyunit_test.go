@@ -0,0 +1,137 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var powerSupplyUnitBody = `{
+		"@odata.type": "#PowerSupply.v1_5_0.PowerSupply",
+		"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1",
+		"Id": "Bay1",
+		"Name": "Power Supply Bay 1",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"LineInputStatus": "Normal",
+		"Model": "RKS-440DC",
+		"Manufacturer": "Contoso Power",
+		"PowerCapacityWatts": 400,
+		"PowerSupplyType": "AC",
+		"HotPluggable": true,
+		"LocationIndicatorActive": false,
+		"EfficiencyRatings": [
+			{
+				"LoadPercent": 50,
+				"EfficiencyPercent": 94
+			}
+		],
+		"Assembly": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Assembly"
+		},
+		"Metrics": {
+			"@odata.id": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Metrics"
+		},
+		"Actions": {
+			"#PowerSupply.Reset": {
+				"target": "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Actions/PowerSupply.Reset"
+			}
+		}
+	}`
+
+// TestPowerSupplyUnit tests the parsing of PowerSupplyUnit objects.
+func TestPowerSupplyUnit(t *testing.T) {
+	var result PowerSupplyUnit
+	err := json.NewDecoder(strings.NewReader(powerSupplyUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "Bay1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.LineInputStatus != NormalLineStatus {
+		t.Errorf("Invalid LineInputStatus: %s", result.LineInputStatus)
+	}
+
+	if result.PowerSupplyType != ACPowerSupplyType {
+		t.Errorf("Invalid PowerSupplyType: %s", result.PowerSupplyType)
+	}
+
+	if result.EfficiencyRatings[0].EfficiencyPercent != 94 {
+		t.Errorf("Invalid EfficiencyRatings: %v", result.EfficiencyRatings)
+	}
+
+	if result.assembly != "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Assembly" {
+		t.Errorf("Invalid Assembly link: %s", result.assembly)
+	}
+
+	if result.metrics != "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Metrics" {
+		t.Errorf("Invalid Metrics link: %s", result.metrics)
+	}
+}
+
+// TestPowerSupplyUnitUpdate tests the Update call.
+func TestPowerSupplyUnitUpdate(t *testing.T) {
+	var result PowerSupplyUnit
+	err := json.NewDecoder(strings.NewReader(powerSupplyUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	result.LocationIndicatorActive = true
+	err = result.Update()
+
+	if err != nil {
+		t.Errorf("Error making Update call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "LocationIndicatorActive:true") {
+		t.Errorf("Unexpected LocationIndicatorActive update payload: %s", calls[0].Payload)
+	}
+}
+
+// TestPowerSupplyUnitReset tests the Reset call.
+func TestPowerSupplyUnitReset(t *testing.T) {
+	var result PowerSupplyUnit
+	err := json.NewDecoder(strings.NewReader(powerSupplyUnitBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(ForceRestartResetType)
+
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/Chassis/1U/PowerSubsystem/PowerSupplies/Bay1/Actions/PowerSupply.Reset" {
+		t.Errorf("Unexpected Reset URL: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "ResetType:ForceRestart") {
+		t.Errorf("Unexpected Reset payload: %s", calls[0].Payload)
+	}
+}
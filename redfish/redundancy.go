@@ -35,6 +35,77 @@ const (
 	NotRedundantRedundancyMode RedundancyMode = "NotRedundant"
 )
 
+// RedundancyType is the redundancy type of a redundant group.
+type RedundancyType string
+
+const (
+	// FailoverRedundancyType Failure of one unit automatically causes a
+	// standby or offline unit in the redundancy set to take over its
+	// functions.
+	FailoverRedundancyType RedundancyType = "Failover"
+	// NPlusMRedundancyType Multiple units are available and active such that
+	// normal operation will continue if one or more units fail.
+	NPlusMRedundancyType RedundancyType = "NPlusM"
+	// SharingRedundancyType Multiple units contribute or share such that
+	// operation will continue, but at a reduced capacity, if one or more
+	// units fail.
+	SharingRedundancyType RedundancyType = "Sharing"
+	// SparingRedundancyType One or more spare units are available to take
+	// over the function of a failed unit, but takeover is not automatic.
+	SparingRedundancyType RedundancyType = "Sparing"
+	// NotRedundantRedundancyType The subsystem is not configured in a
+	// redundancy mode, either due to configuration or the functionality has
+	// been disabled by the user.
+	NotRedundantRedundancyType RedundancyType = "NotRedundant"
+)
+
+// RedundantGroup shall contain redundancy information for a set of devices
+// in a subsystem, as used by the PowerSubsystem and ThermalSubsystem
+// resources.
+type RedundantGroup struct {
+	// MaxSupportedInGroup shall contain the maximum number of devices
+	// allowed in the redundancy group.
+	MaxSupportedInGroup int
+	// MinNeededInGroup shall contain the minimum number of functional
+	// devices needed in the redundancy group for the current redundancy mode
+	// to be fault tolerant.
+	MinNeededInGroup int
+	// RedundancyType shall contain the information about the redundancy
+	// mode of this redundancy group.
+	RedundancyType RedundancyType
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// redundancyGroup shall contain the links to the devices included in
+	// this redundancy group.
+	redundancyGroup []string
+}
+
+// UnmarshalJSON unmarshals a RedundantGroup object from the raw JSON.
+func (redundantgroup *RedundantGroup) UnmarshalJSON(b []byte) error {
+	type temp RedundantGroup
+	var t struct {
+		temp
+		RedundancyGroup common.Links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*redundantgroup = RedundantGroup(t.temp)
+	redundantgroup.redundancyGroup = t.RedundancyGroup.ToStrings()
+
+	return nil
+}
+
+// RedundancyGroupLinks returns the links to the devices included in the
+// redundancy group.
+func (redundantgroup *RedundantGroup) RedundancyGroupLinks() []string {
+	return redundantgroup.redundancyGroup
+}
+
 // Redundancy represents the Redundancy element property.
 // All values for resources described by this schema shall comply to the
 // requirements as described in the Redfish specification.  The value of
@@ -0,0 +1,416 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// CompositionState is the composition state of a resource block.
+type CompositionState string
+
+const (
+	// ComposingCompositionState Intermediate state indicating composition is
+	// in progress.
+	ComposingCompositionState CompositionState = "Composing"
+	// ComposedAndAvailableCompositionState Indicates the resource block is
+	// currently participating in one or more compositions, and is available
+	// to use in more compositions.
+	ComposedAndAvailableCompositionState CompositionState = "ComposedAndAvailable"
+	// ComposedCompositionState Final successful state of a resource block
+	// which has participated in composition.
+	ComposedCompositionState CompositionState = "Composed"
+	// UnusedCompositionState Indicates the resource block is free and can
+	// participate in composition.
+	UnusedCompositionState CompositionState = "Unused"
+	// FailedCompositionState The final composition resulted in failure and
+	// manual intervention is required to fix it.
+	FailedCompositionState CompositionState = "Failed"
+	// UnavailableCompositionState Indicates the resource block has been made
+	// unavailable by the service, such as due to maintenance being performed
+	// on the resource block.
+	UnavailableCompositionState CompositionState = "Unavailable"
+)
+
+// ResourceBlockType is the type of resources in a resource block.
+type ResourceBlockType string
+
+const (
+	// ComputeResourceBlockType This resource block contains resources of
+	// type Processor and Memory in a manner that creates a compute complex.
+	ComputeResourceBlockType ResourceBlockType = "Compute"
+	// ProcessorResourceBlockType This resource block contains resources of
+	// type Processor.
+	ProcessorResourceBlockType ResourceBlockType = "Processor"
+	// MemoryResourceBlockType This resource block contains resources of type
+	// Memory.
+	MemoryResourceBlockType ResourceBlockType = "Memory"
+	// NetworkResourceBlockType This resource block contains network
+	// resources, such as resources of type EthernetInterface and
+	// NetworkInterface.
+	NetworkResourceBlockType ResourceBlockType = "Network"
+	// StorageResourceBlockType This resource block contains storage
+	// resources, such as resources of type Storage and SimpleStorage.
+	StorageResourceBlockType ResourceBlockType = "Storage"
+	// ComputerSystemResourceBlockType This resource block contains resources
+	// of type ComputerSystem.
+	ComputerSystemResourceBlockType ResourceBlockType = "ComputerSystem"
+	// ExpansionResourceBlockType This resource block is capable of changing
+	// over time based on its configuration.
+	ExpansionResourceBlockType ResourceBlockType = "Expansion"
+	// IndependentResourceResourceBlockType This resource block is capable of
+	// being consumed as a standalone component.
+	IndependentResourceResourceBlockType ResourceBlockType = "IndependentResource"
+)
+
+// CompositionStatus shall contain properties that describe the high level
+// composition status of the resource block.
+type CompositionStatus struct {
+	// CompositionState shall be an enumerated value describing the
+	// composition state of the resource block.
+	CompositionState CompositionState
+	// MaxCompositions shall be a number indicating the maximum number of
+	// compositions in which this resource block is capable of participating
+	// simultaneously.
+	MaxCompositions int
+	// NumberOfCompositions shall be the number of compositions in which this
+	// resource block is currently participating.
+	NumberOfCompositions int
+	// Reserved shall be a boolean that is set by client once the resource
+	// block is identified to be composed. It shall provide multiple clients
+	// a way to negotiate its ownership.
+	Reserved bool
+	// SharingCapable shall be a boolean indicating whether this resource
+	// block is capable of participating in multiple compositions
+	// simultaneously.
+	SharingCapable bool
+	// SharingEnabled shall be a boolean indicating whether this resource
+	// block is allowed to participate in multiple compositions
+	// simultaneously.
+	SharingEnabled bool
+}
+
+// ResourceBlock is used to represent a Resource Block for a Redfish
+// implementation.
+type ResourceBlock struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// CompositionStatus shall contain composition status information about
+	// this resource block.
+	CompositionStatus CompositionStatus
+	// Description provides a description of this resource.
+	Description string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// ResourceBlockType shall contain an array of enumerated values
+	// describing the type of resources available.
+	ResourceBlockType []ResourceBlockType
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// computerSystems are the computer systems available in this block.
+	computerSystems []string
+	// memory are the memory devices available in this block.
+	memory []string
+	// processors are the processors available in this block.
+	processors []string
+	// storage are the storage subsystems available in this block.
+	storage []string
+	// chassis are the chassis containing this block.
+	chassis []string
+	// composedSystems are the systems composed from this block.
+	composedSystems []string
+	// zones are the zones this block belongs to.
+	zones []string
+}
+
+// UnmarshalJSON unmarshals a ResourceBlock object from the raw JSON.
+func (resourceblock *ResourceBlock) UnmarshalJSON(b []byte) error {
+	type temp ResourceBlock
+	type links struct {
+		Chassis         common.Links
+		ComputerSystems common.Links
+		Zones           common.Links
+	}
+	var t struct {
+		temp
+		ComputerSystems common.Links
+		Memory          common.Links
+		Processors      common.Links
+		Storage         common.Links
+		Links           links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*resourceblock = ResourceBlock(t.temp)
+
+	// Extract the links to other entities for later
+	resourceblock.computerSystems = t.ComputerSystems.ToStrings()
+	resourceblock.memory = t.Memory.ToStrings()
+	resourceblock.processors = t.Processors.ToStrings()
+	resourceblock.storage = t.Storage.ToStrings()
+	resourceblock.chassis = t.Links.Chassis.ToStrings()
+	resourceblock.composedSystems = t.Links.ComputerSystems.ToStrings()
+	resourceblock.zones = t.Links.Zones.ToStrings()
+
+	return nil
+}
+
+// IsAvailable returns true if the resource block can be used in a new
+// composition: it is not reserved by a client, and either unused or shared
+// with room for more compositions.
+func (resourceblock *ResourceBlock) IsAvailable() bool {
+	status := resourceblock.CompositionStatus
+	if status.Reserved {
+		return false
+	}
+
+	switch status.CompositionState {
+	case UnusedCompositionState:
+		return true
+	case ComposedAndAvailableCompositionState:
+		return status.MaxCompositions == 0 || status.NumberOfCompositions < status.MaxCompositions
+	}
+	return false
+}
+
+// ComposedSystemLinks returns the links to the computer systems composed
+// from this resource block.
+func (resourceblock *ResourceBlock) ComposedSystemLinks() []string {
+	return append([]string(nil), resourceblock.composedSystems...)
+}
+
+// ResourceBlocksUnavailableError is returned when a composition requests
+// resource blocks that cannot be used.
+type ResourceBlocksUnavailableError struct {
+	// Reserved lists the resource blocks reserved by a client.
+	Reserved []string
+	// Unavailable maps the resource blocks that cannot be composed to their
+	// composition state.
+	Unavailable map[string]CompositionState
+}
+
+// Error describes the resource blocks that cannot be used.
+func (e *ResourceBlocksUnavailableError) Error() string {
+	var blocks []string
+	for _, uri := range e.Reserved {
+		blocks = append(blocks, uri+" (Reserved)")
+	}
+	for uri, state := range e.Unavailable {
+		blocks = append(blocks, fmt.Sprintf("%s (%s)", uri, state))
+	}
+	sort.Strings(blocks)
+
+	return "resource blocks unavailable for composition: " + strings.Join(blocks, ", ")
+}
+
+// CheckResourceBlocksAvailable returns a ResourceBlocksUnavailableError
+// listing the blocks that are reserved or unavailable, or nil if they can
+// all be composed.
+func CheckResourceBlocksAvailable(blocks []*ResourceBlock) error {
+	result := &ResourceBlocksUnavailableError{Unavailable: make(map[string]CompositionState)}
+	for _, block := range blocks {
+		switch {
+		case block.IsAvailable():
+		case block.CompositionStatus.Reserved:
+			result.Reserved = append(result.Reserved, block.ODataID)
+		default:
+			result.Unavailable[block.ODataID] = block.CompositionStatus.CompositionState
+		}
+	}
+
+	if len(result.Reserved) == 0 && len(result.Unavailable) == 0 {
+		return nil
+	}
+	return result
+}
+
+// ComputerSystems gets the computer systems available in this resource block.
+func (resourceblock *ResourceBlock) ComputerSystems() ([]*ComputerSystem, error) {
+	var result []*ComputerSystem
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range resourceblock.computerSystems {
+		item, err := GetComputerSystem(resourceblock.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Memory gets the memory devices available in this resource block.
+func (resourceblock *ResourceBlock) Memory() ([]*Memory, error) {
+	var result []*Memory
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range resourceblock.memory {
+		item, err := GetMemory(resourceblock.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Processors gets the processors available in this resource block.
+func (resourceblock *ResourceBlock) Processors() ([]*Processor, error) {
+	var result []*Processor
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range resourceblock.processors {
+		item, err := GetProcessor(resourceblock.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Storage gets the storage subsystems available in this resource block.
+func (resourceblock *ResourceBlock) Storage() ([]*Storage, error) {
+	var result []*Storage
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range resourceblock.storage {
+		item, err := GetStorage(resourceblock.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Chassis gets the chassis containing this resource block.
+func (resourceblock *ResourceBlock) Chassis() ([]*Chassis, error) {
+	var result []*Chassis
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range resourceblock.chassis {
+		item, err := GetChassis(resourceblock.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Zones gets the resource zones this resource block belongs to.
+func (resourceblock *ResourceBlock) Zones() ([]*Zone, error) {
+	var result []*Zone
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range resourceblock.zones {
+		item, err := GetZone(resourceblock.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// GetResourceBlock will get a ResourceBlock instance from the service.
+func GetResourceBlock(c common.Client, uri string) (*ResourceBlock, error) {
+	var resourceBlock ResourceBlock
+	return &resourceBlock, resourceBlock.Get(c, uri, &resourceBlock)
+}
+
+// ListReferencedResourceBlocks gets the collection of ResourceBlock from
+// a provided reference.
+func ListReferencedResourceBlocks(c common.Client, link string) ([]*ResourceBlock, error) { //nolint:dupl
+	var result []*ResourceBlock
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *ResourceBlock
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		resourceblock, err := GetResourceBlock(c, link)
+		ch <- GetResult{Item: resourceblock, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
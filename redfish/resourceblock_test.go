@@ -0,0 +1,132 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var resourceBlockBody = `{
+		"@odata.id": "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1",
+		"@odata.type": "#ResourceBlock.v1_4_0.ResourceBlock",
+		"Id": "ComputeBlock1",
+		"Name": "Compute Block 1",
+		"ResourceBlockType": ["Compute"],
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"CompositionStatus": {
+			"Reserved": false,
+			"CompositionState": "Unused",
+			"SharingCapable": false
+		},
+		"Processors": [
+			{
+				"@odata.id": "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1/Processors/CPU1"
+			}
+		],
+		"Memory": [
+			{
+				"@odata.id": "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1/Memory/DIMM1"
+			}
+		],
+		"Links": {
+			"Chassis": [
+				{
+					"@odata.id": "/redfish/v1/Chassis/ComputeBlock1"
+				}
+			],
+			"Zones": [
+				{
+					"@odata.id": "/redfish/v1/CompositionService/ResourceZones/1"
+				}
+			]
+		}
+	}`
+
+// TestResourceBlock tests the parsing of ResourceBlock objects.
+func TestResourceBlock(t *testing.T) {
+	var result ResourceBlock
+	err := json.NewDecoder(strings.NewReader(resourceBlockBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "ComputeBlock1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ResourceBlockType[0] != ComputeResourceBlockType {
+		t.Errorf("Invalid ResourceBlockType: %v", result.ResourceBlockType)
+	}
+
+	if result.CompositionStatus.CompositionState != UnusedCompositionState {
+		t.Errorf("Invalid CompositionState: %s", result.CompositionStatus.CompositionState)
+	}
+
+	if result.processors[0] != "/redfish/v1/CompositionService/ResourceBlocks/ComputeBlock1/Processors/CPU1" {
+		t.Errorf("Invalid Processors links: %v", result.processors)
+	}
+
+	if result.zones[0] != "/redfish/v1/CompositionService/ResourceZones/1" {
+		t.Errorf("Invalid Zones links: %v", result.zones)
+	}
+
+	if !result.IsAvailable() {
+		t.Error("Unused resource blocks should be available")
+	}
+}
+
+// TestCheckResourceBlocksAvailable tests reporting the resource blocks that
+// cannot be composed.
+func TestCheckResourceBlocksAvailable(t *testing.T) {
+	block := func(id string, state CompositionState, reserved bool) *ResourceBlock {
+		result := &ResourceBlock{}
+		result.ODataID = "/redfish/v1/CompositionService/ResourceBlocks/" + id
+		result.CompositionStatus.CompositionState = state
+		result.CompositionStatus.Reserved = reserved
+		return result
+	}
+
+	shared := block("Shared", ComposedAndAvailableCompositionState, false)
+	shared.CompositionStatus.MaxCompositions = 2
+	shared.CompositionStatus.NumberOfCompositions = 1
+
+	if err := CheckResourceBlocksAvailable([]*ResourceBlock{
+		block("Free", UnusedCompositionState, false),
+		shared,
+	}); err != nil {
+		t.Errorf("Available blocks should not be reported: %s", err)
+	}
+
+	err := CheckResourceBlocksAvailable([]*ResourceBlock{
+		block("Free", UnusedCompositionState, false),
+		block("Taken", UnusedCompositionState, true),
+		block("Composed", ComposedCompositionState, false),
+		block("Maintenance", UnavailableCompositionState, false),
+	})
+
+	unavailable, ok := err.(*ResourceBlocksUnavailableError)
+	if !ok {
+		t.Fatalf("Expected a ResourceBlocksUnavailableError, got: %v", err)
+	}
+
+	if len(unavailable.Reserved) != 1 || unavailable.Reserved[0] != "/redfish/v1/CompositionService/ResourceBlocks/Taken" {
+		t.Errorf("Unexpected reserved blocks: %v", unavailable.Reserved)
+	}
+
+	if len(unavailable.Unavailable) != 2 ||
+		unavailable.Unavailable["/redfish/v1/CompositionService/ResourceBlocks/Maintenance"] != UnavailableCompositionState {
+		t.Errorf("Unexpected unavailable blocks: %v", unavailable.Unavailable)
+	}
+
+	if !strings.Contains(err.Error(), "ResourceBlocks/Composed (Composed)") {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+}
@@ -0,0 +1,30 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import "github.com/bcohee/gofish/common"
+
+// scheduleParameters is a schedule in request payloads, leaving out the
+// properties that are not set.
+type scheduleParameters struct {
+	EnabledDaysOfMonth  []int                `json:",omitempty"`
+	EnabledDaysOfWeek   []common.DayOfWeek   `json:",omitempty"`
+	EnabledIntervals    []string             `json:",omitempty"`
+	EnabledMonthsOfYear []common.MonthOfYear `json:",omitempty"`
+	InitialStartTime    string               `json:",omitempty"`
+	Lifetime            string               `json:",omitempty"`
+	MaxOccurrences      int                  `json:",omitempty"`
+	RecurrenceInterval  string               `json:",omitempty"`
+}
+
+// newScheduleParameters returns the payload of a schedule, nil if there is
+// none.
+func newScheduleParameters(schedule *common.Schedule) *scheduleParameters {
+	if schedule == nil {
+		return nil
+	}
+	result := scheduleParameters(*schedule)
+	return &result
+}
@@ -0,0 +1,331 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// ReadingType is the type of measurement a sensor reports.
+type ReadingType string
+
+const (
+	// TemperatureReadingType shall indicate a temperature measurement, in
+	// degrees Celsius.
+	TemperatureReadingType ReadingType = "Temperature"
+	// HumidityReadingType shall indicate a relative humidity measurement, in
+	// percent.
+	HumidityReadingType ReadingType = "Humidity"
+	// PowerReadingType shall indicate the arithmetic mean of product terms
+	// of instantaneous voltage and current values measured over integer
+	// number of line cycles for a circuit, in watts.
+	PowerReadingType ReadingType = "Power"
+	// EnergykWhReadingType shall indicate the energy, integral of real power
+	// over time, of the monitored item, in kilowatt-hours.
+	EnergykWhReadingType ReadingType = "EnergykWh"
+	// VoltageReadingType shall indicate a measurement of the root mean
+	// square (RMS) of instantaneous voltage, in volts.
+	VoltageReadingType ReadingType = "Voltage"
+	// CurrentReadingType shall indicate a measurement of the root mean
+	// square (RMS) of instantaneous current, in amperes.
+	CurrentReadingType ReadingType = "Current"
+	// FrequencyReadingType shall indicate a frequency measurement, in hertz.
+	FrequencyReadingType ReadingType = "Frequency"
+	// PressureReadingType shall indicate a measurement of force applied
+	// perpendicular to the surface of an object, in pascals.
+	PressureReadingType ReadingType = "Pressure"
+	// LiquidLevelReadingType shall indicate a measurement of fluid height
+	// relative to a specified vertical datum, in centimeters.
+	LiquidLevelReadingType ReadingType = "LiquidLevel"
+	// RotationalReadingType shall indicate a measurement of rotational
+	// frequency, in revolutions per minute.
+	RotationalReadingType ReadingType = "Rotational"
+	// AirFlowReadingType shall indicate a measurement of a volume of gas per
+	// unit of time, in cubic feet per minute.
+	AirFlowReadingType ReadingType = "AirFlow"
+	// LiquidFlowReadingType shall indicate a measurement of a volume of
+	// liquid per unit of time, in liters per second.
+	LiquidFlowReadingType ReadingType = "LiquidFlow"
+	// BarometricReadingType shall indicate a measurement of barometric
+	// pressure, in millimeters of mercury.
+	BarometricReadingType ReadingType = "Barometric"
+	// AltitudeReadingType shall indicate a measurement of altitude, in
+	// meters.
+	AltitudeReadingType ReadingType = "Altitude"
+	// PercentReadingType shall indicate a percentage measurement, for
+	// example the speed of a fan relative to its maximum speed.
+	PercentReadingType ReadingType = "Percent"
+	// AbsoluteHumidityReadingType shall indicate a measurement of absolute
+	// humidity, in grams per cubic meter.
+	AbsoluteHumidityReadingType ReadingType = "AbsoluteHumidity"
+)
+
+// SensorThresholds shall contain the set of thresholds that derive a
+// sensor's health and operational range.
+type SensorThresholds struct {
+	// LowerCaution shall contain the value at which the reading is below
+	// normal range.
+	LowerCaution Threshold
+	// LowerCritical shall contain the value at which the reading is below
+	// normal range but not yet fatal.
+	LowerCritical Threshold
+	// LowerFatal shall contain the value at which the reading is below
+	// normal range and fatal.
+	LowerFatal Threshold
+	// UpperCaution shall contain the value at which the reading is above
+	// normal range.
+	UpperCaution Threshold
+	// UpperCritical shall contain the value at which the reading is above
+	// normal range but not yet fatal.
+	UpperCritical Threshold
+	// UpperFatal shall contain the value at which the reading is above
+	// normal range and fatal.
+	UpperFatal Threshold
+}
+
+// SensorExcerpt shall contain the reading of a sensor, as found in the
+// resources summarizing several sensors.
+type SensorExcerpt struct {
+	// DataSourceURI shall contain a URI to the resource that provides the
+	// data for this sensor.
+	DataSourceURI string `json:"DataSourceUri"`
+	// Reading shall contain the value of the sensor.
+	Reading float32
+}
+
+// SensorArrayExcerpt shall contain the reading of one of a list of sensors,
+// identified by their physical context.
+type SensorArrayExcerpt struct {
+	SensorExcerpt
+	// DeviceName shall contain the name of the device associated with this
+	// sensor.
+	DeviceName string
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+	// PhysicalSubContext shall contain a description of the usage or
+	// sub-region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalSubContext string
+}
+
+// SensorFanExcerpt shall contain the reading of a fan speed sensor.
+type SensorFanExcerpt struct {
+	SensorExcerpt
+	// SpeedRPM shall contain a reading of the rotational speed of the
+	// device, in revolutions per minute.
+	SpeedRPM float32
+}
+
+// SensorFanArrayExcerpt shall contain the reading of one of a list of fan
+// speed sensors.
+type SensorFanArrayExcerpt struct {
+	SensorArrayExcerpt
+	// SpeedRPM shall contain a reading of the rotational speed of the
+	// device, in revolutions per minute.
+	SpeedRPM float32
+}
+
+// SensorPowerExcerpt shall contain the reading of a power sensor.
+type SensorPowerExcerpt struct {
+	SensorExcerpt
+	// ApparentVA shall contain the product of voltage (RMS) multiplied by
+	// current (RMS) for a circuit.
+	ApparentVA float32
+	// PowerFactor shall identify the quotient of real power (W) and apparent
+	// power (VA) for a circuit.
+	PowerFactor float32
+	// ReactiveVAR shall contain the arithmetic mean of product terms of
+	// instantaneous voltage and quadrature current measurements calculated
+	// over an integer number of line cycles for a circuit.
+	ReactiveVAR float32
+}
+
+// SensorEnergykWhExcerpt shall contain the reading of an energy sensor.
+type SensorEnergykWhExcerpt struct {
+	SensorExcerpt
+	// LifetimeReading shall contain the total accumulation of the Reading
+	// property over the sensor's lifetime.
+	LifetimeReading float32
+	// SensorResetTime shall contain the date and time when the time-based
+	// properties were last reset.
+	SensorResetTime string
+}
+
+// Sensor shall represent a sensor for a Redfish implementation.
+type Sensor struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Accuracy shall contain the percent error +/- of the measured versus
+	// actual values of the Reading property.
+	Accuracy float32
+	// ApparentVA shall contain the product of voltage (RMS) multiplied by
+	// current (RMS) for a circuit.
+	ApparentVA float32
+	// Description provides a description of this resource.
+	Description string
+	// ElectricalContext shall represent the combination of current-carrying
+	// conductors that distribute power.
+	ElectricalContext string
+	// LifetimeReading shall contain the total accumulation of the Reading
+	// property over the sensor's lifetime.
+	LifetimeReading float32
+	// Location shall indicate the location information for this sensor.
+	Location common.Location
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PeakReading shall contain the peak sensor value since the last
+	// ResetMetrics action was performed or the service last reset the
+	// time-based property values.
+	PeakReading float32
+	// PhysicalContext shall contain a description of the affected component
+	// or region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalContext common.PhysicalContext
+	// PhysicalSubContext shall contain a description of the usage or
+	// sub-region within the equipment to which this sensor measurement
+	// applies.
+	PhysicalSubContext string
+	// PowerFactor shall identify the quotient of real power (W) and apparent
+	// power (VA) for a circuit.
+	PowerFactor float32
+	// Precision shall contain the number of significant digits in the
+	// Reading property.
+	Precision float32
+	// ReactiveVAR shall contain the arithmetic mean of product terms of
+	// instantaneous voltage and quadrature current measurements calculated
+	// over an integer number of line cycles for a circuit.
+	ReactiveVAR float32
+	// Reading shall contain the sensor value.
+	Reading float32
+	// ReadingRangeMax shall indicate the maximum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMax float32
+	// ReadingRangeMin shall indicate the minimum possible value of the
+	// Reading property for this sensor.
+	ReadingRangeMin float32
+	// ReadingTime shall contain the date and time that the reading data was
+	// acquired from the sensor.
+	ReadingTime string
+	// ReadingType shall contain the type of the sensor.
+	ReadingType ReadingType
+	// ReadingUnits shall contain the units of the sensor's reading and
+	// thresholds, for example "Cel" or "W".
+	ReadingUnits string
+	// SensorResetTime shall contain the date and time when the time-based
+	// properties were last reset.
+	SensorResetTime string
+	// SpeedRPM shall contain a reading of the rotational speed of the
+	// device, in revolutions per minute.
+	SpeedRPM float32
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// Thresholds shall contain the set of thresholds that derive a sensor's
+	// health and operational range.
+	Thresholds SensorThresholds
+	// relatedItem shall contain an array of links to resources or objects
+	// that this sensor services.
+	relatedItem []string
+	// resetMetricsTarget is the URL to send ResetMetrics requests.
+	resetMetricsTarget string
+}
+
+// UnmarshalJSON unmarshals a Sensor object from the raw JSON.
+func (sensor *Sensor) UnmarshalJSON(b []byte) error {
+	type temp Sensor
+	type actions struct {
+		ResetMetrics struct {
+			Target string
+		} `json:"#Sensor.ResetMetrics"`
+	}
+	var t struct {
+		temp
+		Actions     actions
+		RelatedItem common.Links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*sensor = Sensor(t.temp)
+	sensor.relatedItem = t.RelatedItem.ToStrings()
+	sensor.resetMetricsTarget = t.Actions.ResetMetrics.Target
+
+	return nil
+}
+
+// RelatedItemLinks returns the links to the resources this sensor services.
+func (sensor *Sensor) RelatedItemLinks() []string {
+	return sensor.relatedItem
+}
+
+// ResetMetrics shall reset any time intervals or counted values for this
+// sensor.
+func (sensor *Sensor) ResetMetrics() error {
+	if sensor.resetMetricsTarget == "" {
+		return common.NewActionNotSupportedError("ResetMetrics is not supported by this sensor")
+	}
+	return sensor.Post(sensor.resetMetricsTarget, struct{}{})
+}
+
+// GetSensor will get a Sensor instance from the service.
+func GetSensor(c common.Client, uri string) (*Sensor, error) {
+	var sensor Sensor
+	return &sensor, sensor.Get(c, uri, &sensor)
+}
+
+// ListReferencedSensors gets the collection of Sensor from
+// a provided reference.
+func ListReferencedSensors(c common.Client, link string) ([]*Sensor, error) { //nolint:dupl
+	var result []*Sensor
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Sensor
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		sensor, err := GetSensor(c, link)
+		ch <- GetResult{Item: sensor, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,109 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var sensorBody = `{
+		"@odata.type": "#Sensor.v1_7_0.Sensor",
+		"@odata.id": "/redfish/v1/Chassis/1U/Sensors/CPU1Temp",
+		"Id": "CPU1Temp",
+		"Name": "CPU #1 Temperature",
+		"ReadingType": "Temperature",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Reading": 41.5,
+		"ReadingUnits": "Cel",
+		"ReadingRangeMin": 0,
+		"ReadingRangeMax": 70,
+		"PhysicalContext": "CPU",
+		"Thresholds": {
+			"UpperCaution": {
+				"Reading": 60
+			},
+			"UpperCritical": {
+				"Reading": 70,
+				"Activation": "Increasing"
+			}
+		},
+		"RelatedItem": [
+			{
+				"@odata.id": "/redfish/v1/Systems/437XR1138R2/Processors/CPU1"
+			}
+		],
+		"Actions": {
+			"#Sensor.ResetMetrics": {
+				"target": "/redfish/v1/Chassis/1U/Sensors/CPU1Temp/Actions/Sensor.ResetMetrics"
+			}
+		}
+	}`
+
+// TestSensor tests the parsing of Sensor objects.
+func TestSensor(t *testing.T) {
+	var result Sensor
+	err := json.NewDecoder(strings.NewReader(sensorBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "CPU1Temp" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ReadingType != TemperatureReadingType {
+		t.Errorf("Invalid ReadingType: %s", result.ReadingType)
+	}
+
+	if result.Reading != 41.5 {
+		t.Errorf("Invalid Reading: %f", result.Reading)
+	}
+
+	if result.PhysicalContext != common.CPUPhysicalContext {
+		t.Errorf("Invalid PhysicalContext: %s", result.PhysicalContext)
+	}
+
+	if result.Thresholds.UpperCritical.Reading != 70 ||
+		result.Thresholds.UpperCritical.Activation != IncreasingThresholdActivation {
+		t.Errorf("Invalid UpperCritical threshold: %v", result.Thresholds.UpperCritical)
+	}
+
+	if result.RelatedItemLinks()[0] != "/redfish/v1/Systems/437XR1138R2/Processors/CPU1" {
+		t.Errorf("Invalid RelatedItem links: %v", result.RelatedItemLinks())
+	}
+}
+
+// TestSensorResetMetrics tests the ResetMetrics call.
+func TestSensorResetMetrics(t *testing.T) {
+	var result Sensor
+	err := json.NewDecoder(strings.NewReader(sensorBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.ResetMetrics()
+
+	if err != nil {
+		t.Errorf("Error making ResetMetrics call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/Chassis/1U/Sensors/CPU1Temp/Actions/Sensor.ResetMetrics" {
+		t.Errorf("Unexpected ResetMetrics URL: %s", calls[0].URL)
+	}
+}
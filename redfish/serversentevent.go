@@ -0,0 +1,382 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// defaultSSERetryInterval is how long to wait before reconnecting to a
+// Server-Sent Event stream when the service has not sent a retry value.
+const defaultSSERetryInterval = 5 * time.Second
+
+// SSEFilter contains the properties that can be used to filter the events
+// sent over a Server-Sent Event stream. Only the properties advertised in the
+// SSEFilterPropertiesSupported of the event service may be set.
+type SSEFilter struct {
+	// EventFormatType limits the stream to either Event or MetricReport
+	// payloads.
+	EventFormatType EventFormatType
+	// MessageIDs limits the stream to events with one of these MessageIds.
+	MessageIDs []string
+	// MetricReportDefinitions limits the stream to metric reports generated by
+	// one of these metric report definitions.
+	MetricReportDefinitions []string
+	// OriginResources limits the stream to events originating from one of
+	// these resources.
+	OriginResources []string
+	// RegistryPrefixes limits the stream to events with MessageIds from one of
+	// these message registries.
+	RegistryPrefixes []string
+	// ResourceTypes limits the stream to events originating from one of these
+	// resource types.
+	ResourceTypes []string
+}
+
+// validate makes sure the filter only uses properties the service supports.
+func (filter *SSEFilter) validate(supported *SSEFilterPropertiesSupported) error {
+	checks := []struct {
+		name      string
+		used      bool
+		supported bool
+	}{
+		{"EventFormatType", filter.EventFormatType != "", supported.EventFormatType},
+		{"MessageId", len(filter.MessageIDs) > 0, supported.MessageID},
+		{"MetricReportDefinition", len(filter.MetricReportDefinitions) > 0, supported.MetricReportDefinition},
+		{"OriginResource", len(filter.OriginResources) > 0, supported.OriginResource},
+		{"RegistryPrefix", len(filter.RegistryPrefixes) > 0, supported.RegistryPrefix},
+		{"ResourceType", len(filter.ResourceTypes) > 0, supported.ResourceType},
+	}
+
+	for _, check := range checks {
+		if check.used && !check.supported {
+			return fmt.Errorf("filtering on %s is not supported by this service", check.name)
+		}
+	}
+
+	return nil
+}
+
+// String returns the filter as an expression suitable for the $filter query
+// parameter.
+func (filter *SSEFilter) String() string {
+	var clauses []string
+
+	if filter.EventFormatType != "" {
+		clauses = append(clauses, fmt.Sprintf("(EventFormatType eq %s)", filter.EventFormatType))
+	}
+
+	for _, group := range []struct {
+		name   string
+		values []string
+	}{
+		{"MessageId", filter.MessageIDs},
+		{"MetricReportDefinition", filter.MetricReportDefinitions},
+		{"OriginResource", filter.OriginResources},
+		{"RegistryPrefix", filter.RegistryPrefixes},
+		{"ResourceType", filter.ResourceTypes},
+	} {
+		if len(group.values) == 0 {
+			continue
+		}
+
+		var terms []string
+		for _, value := range group.values {
+			terms = append(terms, fmt.Sprintf("(%s eq '%s')", group.name, strings.ReplaceAll(value, "'", "''")))
+		}
+
+		clause := strings.Join(terms, " or ")
+		if len(terms) > 1 {
+			clause = "(" + clause + ")"
+		}
+		clauses = append(clauses, clause)
+	}
+
+	return strings.Join(clauses, " and ")
+}
+
+// SSEEvent is a single message received from a Server-Sent Event stream.
+// Exactly one of Event, MetricReport or Err is set.
+type SSEEvent struct {
+	// ID is the id field of the message, used to resume the stream.
+	ID string
+	// Type is the event field of the message, if the service sent one.
+	Type string
+	// Data is the raw payload of the message.
+	Data []byte
+	// Event is the decoded payload if the message contained an Event.
+	Event *Event
+	// MetricReport is the decoded payload if the message contained a
+	// MetricReport.
+	MetricReport *MetricReport
+	// Err reports a problem decoding the message or reconnecting to the
+	// stream. The subscription keeps running after an error is reported.
+	Err error
+}
+
+// Subscribe opens the Server-Sent Event stream of the event service and
+// delivers the received events on the returned channel. If the connection is
+// lost, the stream is reopened with the ID of the last received event so the
+// service can replay anything that was missed. The channel is closed once ctx
+// is done.
+func (eventservice *EventService) Subscribe(ctx context.Context, filter *SSEFilter) (<-chan *SSEEvent, error) {
+	if strings.TrimSpace(eventservice.ServerSentEventURI) == "" {
+		return nil, common.NewActionNotSupportedError("server-sent events are not supported by this service")
+	}
+
+	uri, err := eventservice.sseRequestURI(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := openSSEStream(ctx, eventservice.Client, uri, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *SSEEvent)
+	subscription := &sseSubscription{
+		client: eventservice.Client,
+		uri:    uri,
+		ch:     ch,
+		retry:  defaultSSERetryInterval,
+	}
+	go subscription.run(ctx, stream)
+
+	return ch, nil
+}
+
+// sseRequestURI builds the relative URI of the stream, including the filter.
+func (eventservice *EventService) sseRequestURI(filter *SSEFilter) (string, error) {
+	uri := eventservice.ServerSentEventURI
+	if parsed, err := url.Parse(uri); err == nil && parsed.IsAbs() {
+		uri = parsed.RequestURI()
+	}
+
+	if filter == nil {
+		return uri, nil
+	}
+
+	if err := filter.validate(&eventservice.SSEFilterPropertiesSupported); err != nil {
+		return "", err
+	}
+
+	expression := filter.String()
+	if expression == "" {
+		return uri, nil
+	}
+
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	escaped := strings.ReplaceAll(url.QueryEscape(expression), "+", "%20")
+
+	return uri + separator + "$filter=" + escaped, nil
+}
+
+// openSSEStream performs the GET request that opens the event stream. The
+// request is tied to ctx when the client supports it, so that cancelling the
+// subscription also aborts a pending reconnection.
+func openSSEStream(ctx context.Context, c common.Client, uri, lastEventID string) (io.ReadCloser, error) {
+	headers := map[string]string{
+		"Accept":        "text/event-stream",
+		"Cache-Control": "no-cache",
+	}
+	if lastEventID != "" {
+		headers["Last-Event-ID"] = lastEventID
+	}
+
+	var resp *http.Response
+	var err error
+	if cg, ok := c.(common.ContextGetter); ok {
+		resp, err = cg.GetWithHeadersContext(ctx, uri, headers)
+	} else {
+		resp, err = c.GetWithHeaders(uri, headers)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// sseSubscription holds the state of a running subscription.
+type sseSubscription struct {
+	client      common.Client
+	uri         string
+	ch          chan<- *SSEEvent
+	lastEventID string
+	retry       time.Duration
+}
+
+// run reads from the stream, reconnecting as needed, until ctx is done.
+func (s *sseSubscription) run(ctx context.Context, stream io.ReadCloser) {
+	defer close(s.ch)
+
+	for {
+		err := s.consume(ctx, stream)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && err != io.EOF {
+			if !s.deliver(ctx, &SSEEvent{Err: err}) {
+				return
+			}
+		}
+
+		// Keep trying to reconnect until it works or we are told to stop
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.retry):
+			}
+
+			stream, err = openSSEStream(ctx, s.client, s.uri, s.lastEventID)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if !s.deliver(ctx, &SSEEvent{Err: err}) {
+				return
+			}
+		}
+	}
+}
+
+// consume reads messages from one connection until it ends.
+func (s *sseSubscription) consume(ctx context.Context, stream io.ReadCloser) error {
+	// Closing the body is the only way to interrupt a blocked read
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		stream.Close()
+	}()
+
+	reader := newSSEReader(stream)
+	for {
+		message, err := reader.next()
+		if err != nil {
+			return err
+		}
+
+		if message.retry > 0 {
+			s.retry = message.retry
+		}
+		if message.hasID {
+			s.lastEventID = message.id
+		}
+		if len(message.data) == 0 {
+			continue
+		}
+
+		event := &SSEEvent{
+			ID:   s.lastEventID,
+			Type: message.event,
+			Data: message.data,
+		}
+		event.Event, event.MetricReport, event.Err = decodeEventPayload(message.data)
+
+		if !s.deliver(ctx, event) {
+			return nil
+		}
+	}
+}
+
+// deliver sends the event to the subscriber, returning false if the
+// subscription was cancelled first.
+func (s *sseSubscription) deliver(ctx context.Context, event *SSEEvent) bool {
+	select {
+	case s.ch <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sseMessage is a single dispatched message from a text/event-stream.
+type sseMessage struct {
+	id    string
+	hasID bool
+	event string
+	data  []byte
+	retry time.Duration
+}
+
+// sseReader parses the text/event-stream format.
+type sseReader struct {
+	reader *bufio.Reader
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	return &sseReader{reader: bufio.NewReader(r)}
+}
+
+// next returns the next message from the stream. A message is dispatched on
+// a blank line; fields of incomplete messages at the end of the stream are
+// discarded.
+func (r *sseReader) next() (*sseMessage, error) {
+	message := &sseMessage{}
+	var data []string
+	seenField := false
+
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if !seenField {
+				continue
+			}
+			message.data = []byte(strings.Join(data, "\n"))
+			return message, nil
+		}
+
+		// Lines starting with a colon are comments, typically keep-alives
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		seenField = true
+
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "event":
+			message.event = value
+		case "id":
+			message.id = value
+			message.hasID = true
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				message.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
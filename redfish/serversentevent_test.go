@@ -0,0 +1,228 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var sseStreamBody = `: keep-alive
+
+id: 1
+data: {"@odata.type": "#Event.v1_4_0.Event", "Id": "1", "Name": "Event Array",
+data:  "Context": "ctx", "Events": [{"EventType": "Alert", "EventId": "1",
+data:  "MessageId": "Alert.1.0.LanDisconnect", "OriginOfCondition": {"@odata.id": "/redfish/v1/Systems/1"}}]}
+
+retry: 1
+id: 2
+event: MetricReport
+data: {"@odata.type": "#MetricReport.v1_3_0.MetricReport", "Id": "PowerMetrics",
+data:  "MetricReportDefinition": {"@odata.id": "/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics"},
+data:  "MetricValues": [{"MetricId": "PowerConsumedWatts", "MetricValue": "230", "Timestamp": "2021-01-01T00:00:00Z"}]}
+
+`
+
+// testResponse returns an http.Response with the given status, body and
+// headers.
+func testResponse(statusCode int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Header:        header,
+	}
+}
+
+// TestSSEFilterString tests building the $filter expression.
+func TestSSEFilterString(t *testing.T) {
+	filter := SSEFilter{
+		EventFormatType: EventEventFormatType,
+		MessageIDs:      []string{"Alert.1.0.LanDisconnect", "Alert.1.0.LanConnect"},
+		OriginResources: []string{"/redfish/v1/Systems/1"},
+	}
+
+	expected := "(EventFormatType eq Event) and " +
+		"((MessageId eq 'Alert.1.0.LanDisconnect') or (MessageId eq 'Alert.1.0.LanConnect')) and " +
+		"(OriginResource eq '/redfish/v1/Systems/1')"
+	if filter.String() != expected {
+		t.Errorf("Unexpected filter expression: %s", filter.String())
+	}
+}
+
+// TestSubscribeUnsupportedFilter tests filtering on a property the service
+// does not advertise.
+func TestSubscribeUnsupportedFilter(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	_, err = result.Subscribe(context.Background(), &SSEFilter{
+		MetricReportDefinitions: []string{"/redfish/v1/TelemetryService/MetricReportDefinitions/1"},
+	})
+	if err == nil {
+		t.Error("Subscribe should fail for unsupported filter property")
+	}
+
+	if len(testClient.CapturedCalls()) != 0 {
+		t.Errorf("No request should have been made, got: %#v", testClient.CapturedCalls())
+	}
+}
+
+// TestSubscribe tests receiving and reconnecting to an SSE stream.
+func TestSubscribe(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, sseStreamBody, http.Header{"Content-Type": []string{"text/event-stream"}}),
+				testResponse(http.StatusOK, sseStreamBody, http.Header{"Content-Type": []string{"text/event-stream"}}),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := result.Subscribe(ctx, &SSEFilter{ResourceTypes: []string{"ComputerSystem"}})
+	if err != nil {
+		t.Fatalf("Error subscribing: %s", err)
+	}
+
+	var received []*SSEEvent
+	for event := range events {
+		received = append(received, event)
+		if len(received) == 3 {
+			cancel()
+		}
+	}
+
+	if len(received) < 3 {
+		t.Fatalf("Expected at least 3 events, got %d", len(received))
+	}
+
+	if received[0].Event == nil || received[0].ID != "1" {
+		t.Fatalf("First message should be an Event with ID 1: %#v", received[0])
+	}
+
+	if received[0].Event.Events[0].OriginOfCondition != "/redfish/v1/Systems/1" {
+		t.Errorf("Invalid OriginOfCondition: %s", received[0].Event.Events[0].OriginOfCondition)
+	}
+
+	if received[1].MetricReport == nil || received[1].Type != "MetricReport" {
+		t.Fatalf("Second message should be a MetricReport: %#v", received[1])
+	}
+
+	if received[1].MetricReport.MetricValues[0].MetricValue != "230" {
+		t.Errorf("Invalid metric value: %s", received[1].MetricReport.MetricValues[0].MetricValue)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/events?$filter=%28ResourceType%20eq%20%27ComputerSystem%27%29" {
+		t.Errorf("Unexpected stream URI: %s", calls[0].URL)
+	}
+
+	if calls[0].CustomHeaders["Accept"] != "text/event-stream" {
+		t.Errorf("Unexpected Accept header: %s", calls[0].CustomHeaders["Accept"])
+	}
+
+	if calls[1].CustomHeaders["Last-Event-ID"] != "2" {
+		t.Errorf("Reconnect should send Last-Event-ID 2, got: %s", calls[1].CustomHeaders["Last-Event-ID"])
+	}
+}
+
+// contextTestClient ties its GET requests to a context, blocking reconnections
+// until the context is done.
+type contextTestClient struct {
+	*common.TestClient
+	requests int
+}
+
+func (c *contextTestClient) GetWithHeadersContext(ctx context.Context, url string, customHeaders map[string]string) (*http.Response, error) {
+	c.requests++
+	if c.requests == 1 {
+		return c.GetWithHeaders(url, customHeaders)
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestSubscribeReconnectContext tests that a pending reconnection is aborted
+// when the subscription is cancelled.
+func TestSubscribeReconnectContext(t *testing.T) {
+	var result EventService
+	err := json.NewDecoder(strings.NewReader(eventServiceBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &contextTestClient{
+		TestClient: &common.TestClient{
+			CustomReturnForActions: map[string][]interface{}{
+				http.MethodGet: {
+					testResponse(http.StatusOK, sseStreamBody, http.Header{"Content-Type": []string{"text/event-stream"}}),
+				},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := result.Subscribe(ctx, nil)
+	if err != nil {
+		t.Fatalf("Error subscribing: %s", err)
+	}
+
+	received := 0
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if received != 2 {
+					t.Errorf("Expected 2 events, got %d", received)
+				}
+				return
+			}
+			if event.Err != nil {
+				t.Errorf("Unexpected error: %s", event.Err)
+			}
+			received++
+			if received == 2 {
+				cancel()
+			}
+		case <-timeout:
+			t.Fatal("Subscription was not closed after cancelling its context")
+		}
+	}
+}
@@ -0,0 +1,263 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// Switch shall be used to represent a simple switch for a Redfish
+// implementation.
+type Switch struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// AssetTag shall be a user-assigned value.
+	AssetTag string
+	// CurrentBandwidthGbps shall contain the internal unidirectional
+	// bandwidth of this switch currently negotiated and running.
+	CurrentBandwidthGbps float32
+	// Description provides a description of this resource.
+	Description string
+	// DomainID shall contain The Domain ID for this switch.
+	DomainID int `json:"DomainID"`
+	// Enabled shall indicate if this switch is enabled.
+	Enabled bool
+	// FirmwareVersion shall contain the firmware version as defined by the
+	// manufacturer for this switch.
+	FirmwareVersion string
+	// IsManaged shall indicate whether this switch is in a managed or
+	// unmanaged state.
+	IsManaged bool
+	// Location shall contain location information of the associated switch.
+	Location common.Location
+	// Manufacturer shall be the name of the organization responsible for
+	// producing the switch.
+	Manufacturer string
+	// MaxBandwidthGbps shall contain the maximum internal bandwidth this
+	// switch is capable of being configured.
+	MaxBandwidthGbps float32
+	// Model shall contain the manufacturer provided model information of
+	// this switch.
+	Model string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// PartNumber shall contain the manufacturer provided part number for the
+	// switch.
+	PartNumber string
+	// PowerState shall contain the power state of the switch.
+	PowerState PowerState
+	// SKU shall contain the stock-keeping unit number for this switch.
+	SKU string
+	// SerialNumber shall contain the manufacturer provided serial number for
+	// the switch.
+	SerialNumber string
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// SupportedProtocols shall contain an array of protocols this switch
+	// supports.
+	SupportedProtocols []common.Protocol
+	// SwitchType shall contain the protocol being sent over this switch.
+	SwitchType common.Protocol
+	// TotalSwitchWidth shall contain the number of physical transport lanes,
+	// phys, or other physical transport links that this switch contains.
+	TotalSwitchWidth int
+	// UUID shall contain a universal unique identifier number for the
+	// switch.
+	UUID string
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+	// ports is the link to the collection of ports of this switch.
+	ports string
+	// chassis is the chassis containing this switch.
+	chassis string
+	// endpoints are the endpoints for this switch.
+	endpoints []string
+	// managedBy are the managers of this switch.
+	managedBy []string
+	// resetTarget is the URL to send Reset requests.
+	resetTarget string
+}
+
+// UnmarshalJSON unmarshals a Switch object from the raw JSON.
+func (sw *Switch) UnmarshalJSON(b []byte) error {
+	type temp Switch
+	type actions struct {
+		Reset struct {
+			Target string
+		} `json:"#Switch.Reset"`
+	}
+	type links struct {
+		Chassis   common.Link
+		Endpoints common.Links
+		ManagedBy common.Links
+	}
+	var t struct {
+		temp
+		Actions actions
+		Links   links
+		Ports   common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*sw = Switch(t.temp)
+
+	// Extract the links to other entities for later
+	sw.ports = t.Ports.String()
+	sw.chassis = t.Links.Chassis.String()
+	sw.endpoints = t.Links.Endpoints.ToStrings()
+	sw.managedBy = t.Links.ManagedBy.ToStrings()
+	sw.resetTarget = t.Actions.Reset.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	sw.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (sw *Switch) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(Switch)
+	err := original.UnmarshalJSON(sw.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"AssetTag",
+		"Enabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(sw).Elem()
+
+	return sw.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// Reset shall reset this switch.
+func (sw *Switch) Reset(resetType ResetType) error {
+	t := struct {
+		ResetType ResetType
+	}{ResetType: resetType}
+
+	return sw.Post(sw.resetTarget, t)
+}
+
+// Ports gets the ports of this switch.
+func (sw *Switch) Ports() ([]*Port, error) {
+	return ListReferencedPorts(sw.Client, sw.ports)
+}
+
+// Chassis gets the chassis containing this switch.
+func (sw *Switch) Chassis() (*Chassis, error) {
+	if sw.chassis == "" {
+		return nil, nil
+	}
+	return GetChassis(sw.Client, sw.chassis)
+}
+
+// Endpoints gets the endpoints for this switch.
+func (sw *Switch) Endpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range sw.endpoints {
+		item, err := GetEndpoint(sw.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ManagedBy gets the managers of this switch.
+func (sw *Switch) ManagedBy() ([]*Manager, error) {
+	var result []*Manager
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range sw.managedBy {
+		item, err := GetManager(sw.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// GetSwitch will get a Switch instance from the service.
+func GetSwitch(c common.Client, uri string) (*Switch, error) {
+	var sw Switch
+	return &sw, sw.Get(c, uri, &sw)
+}
+
+// ListReferencedSwitches gets the collection of Switch from
+// a provided reference.
+func ListReferencedSwitches(c common.Client, link string) ([]*Switch, error) { //nolint:dupl
+	var result []*Switch
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Switch
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		sw, err := GetSwitch(c, link)
+		ch <- GetResult{Item: sw, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
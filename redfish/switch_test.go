@@ -0,0 +1,111 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var switchBody = `{
+		"@odata.id": "/redfish/v1/Fabrics/PCIe/Switches/1",
+		"@odata.type": "#Switch.v1_9_1.Switch",
+		"Id": "1",
+		"Name": "PCIe Switch",
+		"SwitchType": "PCIe",
+		"Manufacturer": "Contoso",
+		"Model": "PCIe Super Switch",
+		"SerialNumber": "2M220100SL",
+		"PowerState": "On",
+		"TotalSwitchWidth": 96,
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Ports": {
+			"@odata.id": "/redfish/v1/Fabrics/PCIe/Switches/1/Ports"
+		},
+		"Links": {
+			"Chassis": {
+				"@odata.id": "/redfish/v1/Chassis/PCIeEnclosure"
+			},
+			"ManagedBy": [
+				{
+					"@odata.id": "/redfish/v1/Managers/BMC"
+				}
+			]
+		},
+		"Actions": {
+			"#Switch.Reset": {
+				"target": "/redfish/v1/Fabrics/PCIe/Switches/1/Actions/Switch.Reset"
+			}
+		}
+	}`
+
+// TestSwitch tests the parsing of Switch objects.
+func TestSwitch(t *testing.T) {
+	var result Switch
+	err := json.NewDecoder(strings.NewReader(switchBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.SwitchType != common.PCIeProtocol {
+		t.Errorf("Invalid SwitchType: %s", result.SwitchType)
+	}
+
+	if result.TotalSwitchWidth != 96 {
+		t.Errorf("Invalid TotalSwitchWidth: %d", result.TotalSwitchWidth)
+	}
+
+	if result.ports != "/redfish/v1/Fabrics/PCIe/Switches/1/Ports" {
+		t.Errorf("Invalid Ports link: %s", result.ports)
+	}
+
+	if result.chassis != "/redfish/v1/Chassis/PCIeEnclosure" {
+		t.Errorf("Invalid Chassis link: %s", result.chassis)
+	}
+
+	if result.managedBy[0] != "/redfish/v1/Managers/BMC" {
+		t.Errorf("Invalid ManagedBy links: %v", result.managedBy)
+	}
+}
+
+// TestSwitchReset tests the Reset call.
+func TestSwitchReset(t *testing.T) {
+	var result Switch
+	err := json.NewDecoder(strings.NewReader(switchBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.Reset(ForceRestartResetType)
+
+	if err != nil {
+		t.Errorf("Error making Reset call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if calls[0].URL != "/redfish/v1/Fabrics/PCIe/Switches/1/Actions/Switch.Reset" {
+		t.Errorf("Unexpected Reset URL: %s", calls[0].URL)
+	}
+
+	if !strings.Contains(calls[0].Payload, "ResetType:ForceRestart") {
+		t.Errorf("Unexpected Reset payload: %s", calls[0].Payload)
+	}
+}
@@ -5,8 +5,6 @@
 package redfish
 
 import (
-	"encoding/json"
-
 	"github.com/bcohee/gofish/common"
 )
 
@@ -55,6 +53,21 @@ const (
 	CancelledTaskState TaskState = "Cancelled"
 )
 
+// IsTerminal returns true if the task will not make any further progress.
+func (taskState TaskState) IsTerminal() bool {
+	switch taskState {
+	case CompletedTaskState, KilledTaskState, ExceptionTaskState, CancelledTaskState:
+		return true
+	}
+	return false
+}
+
+// IsSuccessful returns true if the task completed. Warnings raised by a
+// completed task are reported in its TaskStatus and Messages.
+func (taskState TaskState) IsSuccessful() bool {
+	return taskState == CompletedTaskState
+}
+
 // Payload shall contain information detailing the HTTP
 // and JSON payload information for executing this Task.
 type Payload struct {
@@ -86,9 +99,8 @@ type Task struct {
 	// returned normally. If this property is not specified when the Task is
 	// created, the default value shall be False.
 	HidePayload bool
-	// messages shall be an array of messages associated with the task.
-	// TODO: Add processing of messages.
-	messages []string
+	// Messages shall be an array of messages associated with the task.
+	Messages []common.Message
 	// Payload shall contain information detailing the HTTP and JSON payload
 	// information for executing this task. This object shall not be included in
 	// the response if the HidePayload property is set to True.
@@ -131,26 +143,6 @@ type Task struct {
 	TaskStatus common.Health
 }
 
-// UnmarshalJSON unmarshals a Task object from the raw JSON.
-func (task *Task) UnmarshalJSON(b []byte) error {
-	type temp Task
-	var t struct {
-		temp
-		Messages common.Links
-	}
-
-	err := json.Unmarshal(b, &t)
-	if err != nil {
-		return err
-	}
-
-	// Extract the links to other entities for later
-	*task = Task(t.temp)
-	task.messages = t.Messages.ToStrings()
-
-	return nil
-}
-
 // GetTask will get a Task instance from the service.
 func GetTask(c common.Client, uri string) (*Task, error) {
 	var task Task
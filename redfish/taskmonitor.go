@@ -0,0 +1,264 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// DefaultTaskPollInterval is the delay between two polls of a task monitor
+// when the service does not send a Retry-After header.
+const DefaultTaskPollInterval = 5 * time.Second
+
+// TaskMonitor tracks an asynchronous operation started by a request the
+// service answered with 202 Accepted.
+type TaskMonitor struct {
+	// URI is the task monitor location returned by the service.
+	URI string
+	// TaskURI is the location of the Task resource for the operation, if the
+	// service provided one.
+	TaskURI string
+	// PollInterval is the delay between two polls when the service does not
+	// request one through the Retry-After header. Defaults to
+	// DefaultTaskPollInterval.
+	PollInterval time.Duration
+
+	client common.Client
+	// done is set when the operation completed synchronously or polling
+	// observed its completion.
+	done bool
+	// retryAfter is the delay requested by the last response.
+	retryAfter time.Duration
+	// task is the last known state of the task.
+	task *Task
+}
+
+// NewTaskMonitor creates a TaskMonitor from the response to a request that
+// may have started an asynchronous operation. The response body is consumed
+// and closed. If the service did not answer with 202 Accepted the operation
+// is considered complete.
+func NewTaskMonitor(c common.Client, resp *http.Response) (*TaskMonitor, error) {
+	monitor := &TaskMonitor{client: c, done: true}
+	if resp == nil {
+		return monitor, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return monitor, nil
+	}
+
+	monitor.done = false
+	monitor.URI = resp.Header.Get("Location")
+	monitor.retryAfter, _ = common.RetryAfter(resp)
+
+	task, err := decodeTask(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if task != nil {
+		monitor.setTask(task)
+	}
+
+	if monitor.URI == "" && monitor.TaskURI == "" {
+		return nil, fmt.Errorf("service accepted the request without providing a task monitor")
+	}
+
+	return monitor, nil
+}
+
+// Done returns true once the operation is known to be complete.
+func (monitor *TaskMonitor) Done() bool {
+	return monitor.done
+}
+
+// Task returns the last known state of the task. It is nil if the service
+// has not provided a Task representation yet.
+func (monitor *TaskMonitor) Task() *Task {
+	return monitor.task
+}
+
+// Poll checks the progress of the operation once. It returns true when the
+// operation is complete.
+func (monitor *TaskMonitor) Poll() (bool, error) {
+	if monitor.done {
+		return true, nil
+	}
+
+	uri := monitor.URI
+	if uri == "" {
+		uri = monitor.TaskURI
+	}
+
+	resp, err := monitor.client.Get(uri)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) && uri == monitor.URI && monitor.TaskURI != "" {
+			// Task monitors may be removed once the operation completed, the
+			// task resource remains available.
+			return monitor.refreshTask()
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	monitor.retryAfter, _ = common.RetryAfter(resp)
+
+	// Bodies that are not a Task are the result of a completed operation
+	task, err := decodeTask(resp.Body)
+	if err != nil && resp.StatusCode == http.StatusAccepted {
+		return false, err
+	}
+
+	if task != nil {
+		monitor.setTask(task)
+		if task.TaskState.IsTerminal() {
+			monitor.done = true
+			return true, nil
+		}
+		return false, nil
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		return false, nil
+	}
+
+	// The monitor answers with the result of the operation once it is done,
+	// get the final state of the task as well.
+	if monitor.TaskURI != "" {
+		return monitor.refreshTask()
+	}
+
+	monitor.done = true
+	return true, nil
+}
+
+// Wait polls the operation until it completes or ctx is done. The optional
+// progress function is called after each poll with the last known state of
+// the task, once the service provided one. The final state of the task is returned if the service provided
+// one, along with an error if the task did not complete successfully.
+func (monitor *TaskMonitor) Wait(ctx context.Context, progress func(task *Task)) (*Task, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return monitor.task, err
+		}
+
+		done, err := monitor.Poll()
+		if err != nil {
+			return monitor.task, err
+		}
+
+		if progress != nil && monitor.task != nil {
+			progress(monitor.task)
+		}
+
+		if done {
+			return monitor.task, monitor.taskError()
+		}
+
+		if err := monitor.sleep(ctx); err != nil {
+			return monitor.task, err
+		}
+	}
+}
+
+// refreshTask gets the Task resource.
+func (monitor *TaskMonitor) refreshTask() (bool, error) {
+	task, err := GetTask(monitor.client, monitor.TaskURI)
+	if err != nil {
+		return false, err
+	}
+
+	monitor.setTask(task)
+	monitor.done = task.TaskState.IsTerminal()
+	return monitor.done, nil
+}
+
+// setTask records the state of the task.
+func (monitor *TaskMonitor) setTask(task *Task) {
+	task.SetClient(monitor.client)
+	monitor.task = task
+	if task.ODataID != "" {
+		monitor.TaskURI = task.ODataID
+	}
+	if monitor.URI == "" && task.TaskMonitor != "" {
+		monitor.URI = task.TaskMonitor
+	}
+}
+
+// taskError reports tasks that did not complete successfully.
+func (monitor *TaskMonitor) taskError() error {
+	if monitor.task == nil || monitor.task.TaskState == "" || monitor.task.TaskState.IsSuccessful() {
+		return nil
+	}
+
+	message := ""
+	if len(monitor.task.Messages) > 0 {
+		message = ": " + monitor.task.Messages[len(monitor.task.Messages)-1].Message
+	}
+	return fmt.Errorf("task %s ended in state %s%s", monitor.task.ID, monitor.task.TaskState, message)
+}
+
+// sleep waits before the next poll.
+func (monitor *TaskMonitor) sleep(ctx context.Context) error {
+	delay := monitor.retryAfter
+	if delay <= 0 {
+		delay = monitor.PollInterval
+	}
+	if delay <= 0 {
+		delay = DefaultTaskPollInterval
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// decodeTask decodes a Task representation from a response body. A nil task
+// is returned if the body is empty or not a Task.
+func decodeTask(body io.Reader) (*Task, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	if task.TaskState == "" && !strings.HasPrefix(task.ODataType, "#Task.") {
+		return nil, nil
+	}
+
+	return &task, nil
+}
+
+// PostWithTask sends a POST request and returns a TaskMonitor tracking the
+// operation it started.
+func PostWithTask(c common.Client, uri string, payload interface{}) (*TaskMonitor, error) {
+	resp, err := c.Post(uri, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTaskMonitor(c, resp)
+}
@@ -0,0 +1,138 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+func taskStateBody(state TaskState, percent string) string {
+	return `{"@odata.type": "#Task.v1_4_3.Task", "@odata.id": "/redfish/v1/TaskService/Tasks/1",
+		"Id": "1", "TaskState": "` + string(state) + `", "PercentComplete": ` + percent + `,
+		"Messages": [{"MessageId": "Base.1.8.Success", "Message": "Done"}]}`
+}
+
+// TestTaskMonitorWait tests following a task until it completes.
+func TestTaskMonitorWait(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusAccepted, taskStateBody(NewTaskState, "0"),
+					http.Header{"Location": []string{"/redfish/v1/TaskService/TaskMonitors/1"}}),
+			},
+			http.MethodGet: {
+				testResponse(http.StatusAccepted, taskStateBody(RunningTaskState, "50"),
+					http.Header{"Retry-After": []string{"0"}}),
+				testResponse(http.StatusOK, `{}`, nil),
+				testResponse(http.StatusOK, taskStateBody(CompletedTaskState, "100"), nil),
+			},
+		},
+	}
+
+	result := ComputerSystem{resetTarget: "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"}
+	result.SetClient(testClient)
+
+	monitor, err := result.ResetWithTask(ForceRestartResetType)
+	if err != nil {
+		t.Fatalf("Error resetting system: %s", err)
+	}
+
+	if monitor.Done() || monitor.URI != "/redfish/v1/TaskService/TaskMonitors/1" {
+		t.Fatalf("Unexpected monitor: %#v", monitor)
+	}
+	monitor.PollInterval = time.Millisecond
+
+	var progress []int
+	task, err := monitor.Wait(context.Background(), func(task *Task) {
+		progress = append(progress, task.PercentComplete)
+	})
+	if err != nil {
+		t.Fatalf("Error waiting for task: %s", err)
+	}
+
+	if task.TaskState != CompletedTaskState || task.Messages[0].MessageID != "Base.1.8.Success" {
+		t.Errorf("Unexpected final task: %#v", task)
+	}
+
+	if len(progress) != 2 || progress[0] != 50 || progress[1] != 100 {
+		t.Errorf("Unexpected progress: %v", progress)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[3].URL != "/redfish/v1/TaskService/Tasks/1" {
+		t.Errorf("Task resource should have been fetched, got: %s", calls[3].URL)
+	}
+}
+
+// TestTaskMonitorSynchronous tests actions completed without a task.
+func TestTaskMonitorSynchronous(t *testing.T) {
+	monitor, err := NewTaskMonitor(&common.TestClient{}, testResponse(http.StatusNoContent, "", nil))
+	if err != nil {
+		t.Fatalf("Error creating monitor: %s", err)
+	}
+
+	task, err := monitor.Wait(context.Background(), nil)
+	if err != nil || task != nil || !monitor.Done() {
+		t.Errorf("Operation should be complete, task: %#v error: %v", task, err)
+	}
+}
+
+// TestTaskMonitorException tests reporting failed tasks.
+func TestTaskMonitorException(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, taskStateBody(ExceptionTaskState, "100"), nil),
+			},
+		},
+	}
+
+	monitor, err := NewTaskMonitor(testClient, testResponse(http.StatusAccepted, "",
+		http.Header{"Location": []string{"/redfish/v1/TaskService/TaskMonitors/1"}}))
+	if err != nil {
+		t.Fatalf("Error creating monitor: %s", err)
+	}
+
+	task, err := monitor.Wait(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "Exception") {
+		t.Errorf("Expected task failure, got: %v", err)
+	}
+
+	if task == nil || task.TaskState != ExceptionTaskState {
+		t.Errorf("Unexpected final task: %#v", task)
+	}
+}
+
+// TestTaskMonitorCancel tests giving up when the context is done.
+func TestTaskMonitorCancel(t *testing.T) {
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusAccepted, taskStateBody(RunningTaskState, "10"),
+					http.Header{"Retry-After": []string{"60"}}),
+			},
+		},
+	}
+
+	monitor, err := NewTaskMonitor(testClient, testResponse(http.StatusAccepted, "",
+		http.Header{"Location": []string{"/redfish/v1/TaskService/TaskMonitors/1"}}))
+	if err != nil {
+		t.Fatalf("Error creating monitor: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = monitor.Wait(ctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline error, got: %v", err)
+	}
+}
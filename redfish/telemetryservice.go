@@ -0,0 +1,188 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// TelemetryService shall be the entry point for the telemetry service,
+// which collects metrics into metric reports.
+type TelemetryService struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// MaxReports shall contain the maximum number of metric reports that
+	// this service supports.
+	MaxReports int
+	// MinCollectionInterval shall contain the minimum time interval between
+	// gathering metric data that this service allows, in ISO 8601 duration
+	// format.
+	MinCollectionInterval string
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// ServiceEnabled shall indicate whether this service is enabled.
+	ServiceEnabled bool
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// SupportedCollectionFunctions shall contain the function to apply over
+	// the collection duration.
+	SupportedCollectionFunctions []CalculationAlgorithmEnum
+	// rawData holds the original serialized JSON so we can compare updates.
+	rawData []byte
+	// metricDefinitions is the link to the collection of metric definitions.
+	metricDefinitions string
+	// metricReportDefinitions is the link to the collection of metric report
+	// definitions.
+	metricReportDefinitions string
+	// metricReports is the link to the collection of metric reports.
+	metricReports string
+	// triggers is the link to the collection of triggers.
+	triggers string
+	// submitTestMetricReportTarget is the URL to send SubmitTestMetricReport
+	// requests.
+	submitTestMetricReportTarget string
+}
+
+// UnmarshalJSON unmarshals a TelemetryService object from the raw JSON.
+func (telemetryservice *TelemetryService) UnmarshalJSON(b []byte) error {
+	type temp TelemetryService
+	type actions struct {
+		SubmitTestMetricReport struct {
+			Target string
+		} `json:"#TelemetryService.SubmitTestMetricReport"`
+	}
+	var t struct {
+		temp
+		Actions                 actions
+		MetricDefinitions       common.Link
+		MetricReportDefinitions common.Link
+		MetricReports           common.Link
+		Triggers                common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*telemetryservice = TelemetryService(t.temp)
+
+	// Extract the links to other entities for later
+	telemetryservice.metricDefinitions = t.MetricDefinitions.String()
+	telemetryservice.metricReportDefinitions = t.MetricReportDefinitions.String()
+	telemetryservice.metricReports = t.MetricReports.String()
+	telemetryservice.triggers = t.Triggers.String()
+	telemetryservice.submitTestMetricReportTarget = t.Actions.SubmitTestMetricReport.Target
+
+	// This is a read/write object, so we need to save the raw object data for later
+	telemetryservice.rawData = b
+
+	return nil
+}
+
+// Update commits updates to this object's properties to the running system.
+func (telemetryservice *TelemetryService) Update() error {
+	// Get a representation of the object's original state so we can find what
+	// to update.
+	original := new(TelemetryService)
+	err := original.UnmarshalJSON(telemetryservice.rawData)
+	if err != nil {
+		return err
+	}
+
+	readWriteFields := []string{
+		"ServiceEnabled",
+	}
+
+	originalElement := reflect.ValueOf(original).Elem()
+	currentElement := reflect.ValueOf(telemetryservice).Elem()
+
+	return telemetryservice.Entity.Update(originalElement, currentElement, readWriteFields)
+}
+
+// GetTelemetryService will get a TelemetryService instance from the service.
+func GetTelemetryService(c common.Client, uri string) (*TelemetryService, error) {
+	var telemetryService TelemetryService
+	return &telemetryService, telemetryService.Get(c, uri, &telemetryService)
+}
+
+// MetricDefinitions gets the metric definitions of the service.
+func (telemetryservice *TelemetryService) MetricDefinitions() ([]*MetricDefinition, error) {
+	return ListReferencedMetricDefinitions(telemetryservice.Client, telemetryservice.metricDefinitions)
+}
+
+// MetricReportDefinitions gets the metric report definitions of the service.
+func (telemetryservice *TelemetryService) MetricReportDefinitions() ([]*MetricReportDefinition, error) {
+	return ListReferencedMetricReportDefinitions(telemetryservice.Client, telemetryservice.metricReportDefinitions)
+}
+
+// MetricReports gets the metric reports of the service.
+func (telemetryservice *TelemetryService) MetricReports() ([]*MetricReport, error) {
+	return ListReferencedMetricReports(telemetryservice.Client, telemetryservice.metricReports)
+}
+
+// Triggers gets the triggers of the service.
+func (telemetryservice *TelemetryService) Triggers() ([]*Triggers, error) {
+	return ListReferencedTriggers(telemetryservice.Client, telemetryservice.triggers)
+}
+
+// CreateMetricReportDefinition creates a metric report definition. It
+// returns the link to the new definition.
+func (telemetryservice *TelemetryService) CreateMetricReportDefinition(parameters *MetricReportDefinitionParameters) (string, error) {
+	if telemetryservice.metricReportDefinitions == "" {
+		return "", fmt.Errorf("empty metric report definitions link in the telemetry service")
+	}
+
+	return CreateMetricReportDefinition(telemetryservice.Client, telemetryservice.metricReportDefinitions, parameters)
+}
+
+// DeleteMetricReportDefinition deletes a metric report definition, along
+// with the metric reports it generated.
+func (telemetryservice *TelemetryService) DeleteMetricReportDefinition(uri string) error {
+	return DeleteMetricReportDefinition(telemetryservice.Client, uri)
+}
+
+// SubmitTestMetricReport shall cause the event service to immediately
+// generate the metric report as an alert event, which is sent to subscribers
+// of metric reports.
+func (telemetryservice *TelemetryService) SubmitTestMetricReport(name string, values []MetricValue) error {
+	if telemetryservice.submitTestMetricReportTarget == "" {
+		return common.NewActionNotSupportedError("SubmitTestMetricReport is not supported by this service")
+	}
+
+	type generatedValue struct {
+		MetricID       string `json:"MetricId,omitempty"`
+		MetricProperty string `json:",omitempty"`
+		MetricValue    string
+		Timestamp      string `json:",omitempty"`
+	}
+	t := struct {
+		MetricReportName            string
+		GeneratedMetricReportValues []generatedValue
+	}{
+		MetricReportName:            name,
+		GeneratedMetricReportValues: make([]generatedValue, 0, len(values)),
+	}
+	for i := range values {
+		t.GeneratedMetricReportValues = append(t.GeneratedMetricReportValues, generatedValue{
+			MetricID:       values[i].MetricID,
+			MetricProperty: values[i].MetricProperty,
+			MetricValue:    values[i].MetricValue,
+			Timestamp:      values[i].Timestamp,
+		})
+	}
+
+	return telemetryservice.Post(telemetryservice.submitTestMetricReportTarget, t)
+}
@@ -0,0 +1,140 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var telemetryServiceBody = `{
+		"@odata.id": "/redfish/v1/TelemetryService",
+		"@odata.type": "#TelemetryService.v1_3_1.TelemetryService",
+		"Id": "TelemetryService",
+		"Name": "Telemetry Service",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"ServiceEnabled": true,
+		"MaxReports": 10,
+		"MinCollectionInterval": "PT10S",
+		"SupportedCollectionFunctions": ["Average", "Minimum", "Maximum"],
+		"MetricDefinitions": {
+			"@odata.id": "/redfish/v1/TelemetryService/MetricDefinitions"
+		},
+		"MetricReportDefinitions": {
+			"@odata.id": "/redfish/v1/TelemetryService/MetricReportDefinitions"
+		},
+		"MetricReports": {
+			"@odata.id": "/redfish/v1/TelemetryService/MetricReports"
+		},
+		"Triggers": {
+			"@odata.id": "/redfish/v1/TelemetryService/Triggers"
+		},
+		"Actions": {
+			"#TelemetryService.SubmitTestMetricReport": {
+				"target": "/redfish/v1/TelemetryService/Actions/TelemetryService.SubmitTestMetricReport"
+			}
+		}
+	}`
+
+// TestTelemetryService tests the parsing of TelemetryService objects.
+func TestTelemetryService(t *testing.T) {
+	var result TelemetryService
+	err := json.NewDecoder(strings.NewReader(telemetryServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "TelemetryService" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.MaxReports != 10 {
+		t.Errorf("Invalid MaxReports: %d", result.MaxReports)
+	}
+
+	if result.SupportedCollectionFunctions[2] != MaximumCalculationAlgorithmEnum {
+		t.Errorf("Invalid SupportedCollectionFunctions: %v", result.SupportedCollectionFunctions)
+	}
+
+	if result.metricReportDefinitions != "/redfish/v1/TelemetryService/MetricReportDefinitions" {
+		t.Errorf("Invalid MetricReportDefinitions link: %s", result.metricReportDefinitions)
+	}
+
+	if result.triggers != "/redfish/v1/TelemetryService/Triggers" {
+		t.Errorf("Invalid Triggers link: %s", result.triggers)
+	}
+
+	if result.submitTestMetricReportTarget != "/redfish/v1/TelemetryService/Actions/TelemetryService.SubmitTestMetricReport" {
+		t.Errorf("Invalid SubmitTestMetricReport target: %s", result.submitTestMetricReportTarget)
+	}
+}
+
+// TestTelemetryServiceMetricReports tests getting the metric reports.
+func TestTelemetryServiceMetricReports(t *testing.T) {
+	var result TelemetryService
+	err := json.NewDecoder(strings.NewReader(telemetryServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, `{
+					"Members": [
+						{"@odata.id": "/redfish/v1/TelemetryService/MetricReports/PowerMetrics"}
+					],
+					"Members@odata.count": 1
+				}`, nil),
+				testResponse(http.StatusOK, metricReportBody, nil),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	reports, err := result.MetricReports()
+	if err != nil {
+		t.Fatalf("Error getting metric reports: %s", err)
+	}
+
+	if len(reports) != 1 || reports[0].ID != "PowerMetrics" {
+		t.Errorf("Unexpected metric reports: %v", reports)
+	}
+}
+
+// TestTelemetryServiceSubmitTestMetricReport tests the SubmitTestMetricReport call.
+func TestTelemetryServiceSubmitTestMetricReport(t *testing.T) {
+	var result TelemetryService
+	err := json.NewDecoder(strings.NewReader(telemetryServiceBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.SubmitTestMetricReport("PowerMetrics", []MetricValue{
+		{MetricID: "AverageConsumedWatts", MetricValue: "100"},
+	})
+	if err != nil {
+		t.Errorf("Error making SubmitTestMetricReport call: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+
+	if !strings.Contains(calls[0].Payload, "GeneratedMetricReportValues:[map[MetricId:AverageConsumedWatts MetricValue:100]]") {
+		t.Errorf("Unexpected SubmitTestMetricReport payload: %s", calls[0].Payload)
+	}
+}
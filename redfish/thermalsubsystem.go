@@ -0,0 +1,65 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// ThermalSubsystem shall describe the thermal management subsystem of a
+// chassis. It replaces the deprecated Thermal resource.
+type ThermalSubsystem struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// FanRedundancy shall contain redundancy information for the groups of
+	// fans in this subsystem.
+	FanRedundancy []RedundantGroup
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// fans shall contain a link to a resource collection of type
+	// FanCollection.
+	fans string
+}
+
+// UnmarshalJSON unmarshals a ThermalSubsystem object from the raw JSON.
+func (thermalsubsystem *ThermalSubsystem) UnmarshalJSON(b []byte) error {
+	type temp ThermalSubsystem
+	var t struct {
+		temp
+		Fans common.Link
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	// Extract the links to other entities for later
+	*thermalsubsystem = ThermalSubsystem(t.temp)
+	thermalsubsystem.fans = t.Fans.String()
+
+	return nil
+}
+
+// Fans gets the fans of this subsystem.
+func (thermalsubsystem *ThermalSubsystem) Fans() ([]*FanUnit, error) {
+	return ListReferencedFanUnits(thermalsubsystem.Client, thermalsubsystem.fans)
+}
+
+// GetThermalSubsystem will get a ThermalSubsystem instance from the service.
+func GetThermalSubsystem(c common.Client, uri string) (*ThermalSubsystem, error) {
+	var thermalSubsystem ThermalSubsystem
+	return &thermalSubsystem, thermalSubsystem.Get(c, uri, &thermalSubsystem)
+}
@@ -0,0 +1,59 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var thermalSubsystemBody = `{
+		"@odata.type": "#ThermalSubsystem.v1_0_0.ThermalSubsystem",
+		"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem",
+		"Id": "ThermalSubsystem",
+		"Name": "Thermal Subsystem for Chassis",
+		"FanRedundancy": [
+			{
+				"RedundancyType": "NPlusM",
+				"MaxSupportedInGroup": 2,
+				"MinNeededInGroup": 1,
+				"RedundancyGroup": [
+					{
+						"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans/Bay1"
+					}
+				]
+			}
+		],
+		"Fans": {
+			"@odata.id": "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans"
+		},
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		}
+	}`
+
+// TestThermalSubsystem tests the parsing of ThermalSubsystem objects.
+func TestThermalSubsystem(t *testing.T) {
+	var result ThermalSubsystem
+	err := json.NewDecoder(strings.NewReader(thermalSubsystemBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "ThermalSubsystem" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.FanRedundancy[0].RedundancyType != NPlusMRedundancyType {
+		t.Errorf("Invalid FanRedundancy: %v", result.FanRedundancy)
+	}
+
+	if result.fans != "/redfish/v1/Chassis/1U/ThermalSubsystem/Fans" {
+		t.Errorf("Invalid Fans link: %s", result.fans)
+	}
+}
@@ -0,0 +1,238 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// TriggerActionEnum is the action to perform when a trigger condition is
+// met.
+type TriggerActionEnum string
+
+const (
+	// LogToLogServiceTriggerActionEnum shall log the occurrence of the
+	// condition to the log service.
+	LogToLogServiceTriggerActionEnum TriggerActionEnum = "LogToLogService"
+	// RedfishEventTriggerActionEnum shall send an event to subscribers.
+	RedfishEventTriggerActionEnum TriggerActionEnum = "RedfishEvent"
+	// RedfishMetricReportTriggerActionEnum shall force the metric reports
+	// managed by the MetricReportDefinitions specified by the
+	// MetricReportDefinitions property to be updated.
+	RedfishMetricReportTriggerActionEnum TriggerActionEnum = "RedfishMetricReport"
+)
+
+// DiscreteTriggerConditionEnum is the type of discrete trigger condition.
+type DiscreteTriggerConditionEnum string
+
+const (
+	// SpecifiedDiscreteTriggerConditionEnum A discrete trigger condition is
+	// met when the metric value becomes one of the values that the
+	// DiscreteTriggers property lists.
+	SpecifiedDiscreteTriggerConditionEnum DiscreteTriggerConditionEnum = "Specified"
+	// ChangedDiscreteTriggerConditionEnum A discrete trigger condition is met
+	// whenever the metric value changes.
+	ChangedDiscreteTriggerConditionEnum DiscreteTriggerConditionEnum = "Changed"
+)
+
+// ThresholdActivation is the direction of crossing that activates a
+// threshold.
+type ThresholdActivation string
+
+const (
+	// IncreasingThresholdActivation Value increases above the threshold.
+	IncreasingThresholdActivation ThresholdActivation = "Increasing"
+	// DecreasingThresholdActivation Value decreases below the threshold.
+	DecreasingThresholdActivation ThresholdActivation = "Decreasing"
+	// EitherThresholdActivation Value crosses the threshold in either
+	// direction.
+	EitherThresholdActivation ThresholdActivation = "Either"
+)
+
+// Threshold shall contain the properties for an individual threshold for
+// this sensor.
+type Threshold struct {
+	// Activation shall indicate the direction of crossing of the reading for
+	// this sensor that activates the threshold.
+	Activation ThresholdActivation
+	// DwellTime shall indicate the time interval over which the sensor
+	// reading must have passed through this threshold value before the
+	// threshold is considered to be violated, in ISO 8601 duration format.
+	DwellTime string
+	// Reading shall indicate the reading for this sensor that activates the
+	// threshold.
+	Reading float32
+}
+
+// Thresholds shall contain a set of thresholds for a sensor.
+type Thresholds struct {
+	// LowerCritical shall contain the value at which the reading is below
+	// normal range but not yet fatal.
+	LowerCritical Threshold
+	// LowerWarning shall contain the value at which the reading is below
+	// normal range.
+	LowerWarning Threshold
+	// UpperCritical shall contain the value at which the reading is above
+	// normal range but not yet fatal.
+	UpperCritical Threshold
+	// UpperWarning shall contain the value at which the reading is above
+	// normal range.
+	UpperWarning Threshold
+}
+
+// DiscreteTrigger shall contain the characteristics of the discrete trigger.
+type DiscreteTrigger struct {
+	// DwellTime shall contain the amount of time that a trigger event
+	// persists before the MetricAction is performed, in ISO 8601 duration
+	// format.
+	DwellTime string
+	// Name shall contain a name for the trigger.
+	Name string
+	// Severity shall contain the Severity property to be used in the event
+	// message.
+	Severity common.Health
+	// Value shall contain the value of the discrete metric that constitutes
+	// a trigger event.
+	Value string
+}
+
+// Triggers shall contain a trigger that applies to metrics.
+type Triggers struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// Description provides a description of this resource.
+	Description string
+	// DiscreteTriggerCondition shall contain the conditions when a discrete
+	// metric triggers.
+	DiscreteTriggerCondition DiscreteTriggerConditionEnum
+	// DiscreteTriggers shall contain a list of values to which to compare a
+	// metric reading.
+	DiscreteTriggers []DiscreteTrigger
+	// EventTriggers shall contain an array of MessageIds that specify when a
+	// trigger condition is met based on an event.
+	EventTriggers []string
+	// MetricProperties shall contain a list of URIs with wildcards and
+	// property identifiers for which this trigger is defined.
+	MetricProperties []string
+	// MetricType shall contain the type of trigger.
+	MetricType MetricType
+	// NumericThresholds shall contain the list of thresholds to which to
+	// compare a numeric metric value.
+	NumericThresholds Thresholds
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// TriggerActions shall specify the actions to perform when a trigger
+	// condition is met.
+	TriggerActions []TriggerActionEnum
+	// Wildcards shall contain the wildcards and their substitution values
+	// for the entries in the MetricProperties array property.
+	Wildcards []Wildcard
+	// metricReportDefinitions are the metric report definitions updated when
+	// the trigger condition is met.
+	metricReportDefinitions []string
+}
+
+// UnmarshalJSON unmarshals a Triggers object from the raw JSON.
+func (triggers *Triggers) UnmarshalJSON(b []byte) error {
+	type temp Triggers
+	var t struct {
+		temp
+		Links struct {
+			MetricReportDefinitions common.Links
+		}
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*triggers = Triggers(t.temp)
+
+	// Extract the links to other entities for later
+	triggers.metricReportDefinitions = t.Links.MetricReportDefinitions.ToStrings()
+
+	return nil
+}
+
+// MetricReportDefinitions gets the metric report definitions updated when
+// the trigger condition is met.
+func (triggers *Triggers) MetricReportDefinitions() ([]*MetricReportDefinition, error) {
+	var result []*MetricReportDefinition
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range triggers.metricReportDefinitions {
+		definition, err := GetMetricReportDefinition(triggers.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, definition)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// GetTriggers will get a Triggers instance from the service.
+func GetTriggers(c common.Client, uri string) (*Triggers, error) {
+	var triggers Triggers
+	return &triggers, triggers.Get(c, uri, &triggers)
+}
+
+// ListReferencedTriggers gets the collection of Triggers from
+// a provided reference.
+func ListReferencedTriggers(c common.Client, link string) ([]*Triggers, error) { //nolint:dupl
+	var result []*Triggers
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Triggers
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		triggers, err := GetTriggers(c, link)
+		ch <- GetResult{Item: triggers, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
@@ -0,0 +1,72 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var triggersBody = `{
+		"@odata.id": "/redfish/v1/TelemetryService/Triggers/PlatformPowerCapTriggers",
+		"@odata.type": "#Triggers.v1_3_1.Triggers",
+		"Id": "PlatformPowerCapTriggers",
+		"Name": "Triggers for platform power consumed",
+		"MetricType": "Numeric",
+		"TriggerActions": ["RedfishEvent", "RedfishMetricReport"],
+		"NumericThresholds": {
+			"UpperCritical": {
+				"Reading": 50,
+				"Activation": "Increasing",
+				"DwellTime": "PT0.001S"
+			},
+			"UpperWarning": {
+				"Reading": 48.1,
+				"Activation": "Increasing",
+				"DwellTime": "PT0.004S"
+			}
+		},
+		"MetricProperties": [
+			"/redfish/v1/Chassis/1/Power#/PowerControl/0/PowerConsumedWatts"
+		],
+		"Links": {
+			"MetricReportDefinitions": [
+				{
+					"@odata.id": "/redfish/v1/TelemetryService/MetricReportDefinitions/PowerMetrics"
+				}
+			]
+		}
+	}`
+
+// TestTriggers tests the parsing of Triggers objects.
+func TestTriggers(t *testing.T) {
+	var result Triggers
+	err := json.NewDecoder(strings.NewReader(triggersBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "PlatformPowerCapTriggers" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.TriggerActions[1] != RedfishMetricReportTriggerActionEnum {
+		t.Errorf("Invalid TriggerActions: %v", result.TriggerActions)
+	}
+
+	if result.NumericThresholds.UpperCritical.Reading != 50 {
+		t.Errorf("Invalid UpperCritical reading: %f", result.NumericThresholds.UpperCritical.Reading)
+	}
+
+	if result.NumericThresholds.UpperWarning.Activation != IncreasingThresholdActivation {
+		t.Errorf("Invalid UpperWarning activation: %s", result.NumericThresholds.UpperWarning.Activation)
+	}
+
+	if len(result.metricReportDefinitions) != 1 {
+		t.Errorf("Invalid MetricReportDefinitions links: %v", result.metricReportDefinitions)
+	}
+}
@@ -5,7 +5,11 @@
 package redfish
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 
 	"github.com/bcohee/gofish/common"
 )
@@ -96,3 +100,149 @@ func (updateService *UpdateService) SoftwareInventories() ([]*SoftwareInventory,
 func (updateService *UpdateService) FirmwareInventories() ([]*SoftwareInventory, error) {
 	return ListReferencedSoftwareInventories(updateService.Client, updateService.FirmwareInventory)
 }
+
+// SimpleUpdate shall update installed software components using a software
+// image file located at the ImageURI. Targets optionally lists the
+// components to update, protocol is used when ImageURI does not include one,
+// and username and password are the optional credentials used to access the
+// image. The returned TaskMonitor follows the update.
+func (updateService *UpdateService) SimpleUpdate(imageURI string, targets []string, protocol TransferProtocolType,
+	username, password string) (*TaskMonitor, error) {
+	if updateService.UpdateServiceTarget == "" {
+		return nil, common.NewActionNotSupportedError("SimpleUpdate is not supported by this service")
+	}
+
+	if protocol != "" && len(updateService.TransferProtocol) > 0 {
+		supported := false
+		for _, allowed := range updateService.TransferProtocol {
+			if string(protocol) == allowed {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, fmt.Errorf("transfer protocol '%s' is not supported by this service", protocol)
+		}
+	}
+
+	t := struct {
+		ImageURI         string
+		Targets          []string             `json:",omitempty"`
+		TransferProtocol TransferProtocolType `json:",omitempty"`
+		Username         string               `json:",omitempty"`
+		Password         string               `json:",omitempty"`
+	}{
+		ImageURI:         imageURI,
+		Targets:          targets,
+		TransferProtocol: protocol,
+		Username:         username,
+		Password:         password,
+	}
+
+	resp, err := updateService.PostWithResponse(updateService.UpdateServiceTarget, t)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskMonitor(updateService.Client, resp)
+}
+
+// UpdateParameters contains the parameters sent along with an image pushed
+// to the MultipartHTTPPushURI.
+type UpdateParameters struct {
+	// ForceUpdate shall indicate whether the service should bypass update
+	// policies when applying the image, such as allowing a component to be
+	// downgraded.
+	ForceUpdate bool `json:",omitempty"`
+	// OperationApplyTime shall indicate when the update is applied.
+	OperationApplyTime common.OperationApplyTime `json:"@Redfish.OperationApplyTime,omitempty"`
+	// Targets shall contain zero or more URIs indicating where to apply the
+	// update image. If empty, the service determines where to apply it.
+	Targets []string `json:",omitempty"`
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage `json:",omitempty"`
+}
+
+// namedImage gives a name to image readers sent as a file part.
+type namedImage struct {
+	io.Reader
+	name string
+}
+
+// Name returns the file name of the image.
+func (image *namedImage) Name() string {
+	return image.name
+}
+
+// namedSeekableImage gives a name to seekable image readers, keeping them
+// seekable so the upload can be retried.
+type namedSeekableImage struct {
+	io.ReadSeeker
+	name string
+}
+
+// Name returns the file name of the image.
+func (image *namedSeekableImage) Name() string {
+	return image.name
+}
+
+// MultipartHTTPPush uploads a software image to the MultipartHTTPPushURI.
+// The image is streamed from the reader, so large images do not need to fit
+// in memory. filename is used for the uploaded file part unless the reader
+// already has a name, as os.File does. The returned TaskMonitor follows the
+// update.
+func (updateService *UpdateService) MultipartHTTPPush(filename string, image io.Reader, parameters *UpdateParameters) (*TaskMonitor, error) {
+	if updateService.MultipartHTTPPushURI == "" {
+		return nil, common.NewActionNotSupportedError("multipart HTTP push is not supported by this service")
+	}
+
+	if parameters == nil {
+		parameters = &UpdateParameters{}
+	}
+	updateParameters, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := image.(interface{ Name() string }); !ok {
+		if seeker, ok := image.(io.ReadSeeker); ok {
+			image = &namedSeekableImage{ReadSeeker: seeker, name: filename}
+		} else {
+			image = &namedImage{Reader: image, name: filename}
+		}
+	}
+
+	resp, err := updateService.Client.PostMultipart(updateService.MultipartHTTPPushURI, map[string]io.Reader{
+		"UpdateParameters": bytes.NewReader(updateParameters),
+		"UpdateFile":       image,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskMonitor(updateService.Client, resp)
+}
+
+// rawRequester is implemented by clients able to send a payload as is, such
+// as gofish.APIClient.
+type rawRequester interface {
+	RunRawRequestWithHeaders(method, url string, payloadBuffer io.ReadSeeker, contentType string, customHeaders map[string]string) (*http.Response, error)
+}
+
+// HTTPPush uploads a software image to the deprecated HTTPPushURI, for
+// services that do not support MultipartHTTPPush. The image is streamed from
+// the reader. The returned TaskMonitor follows the update.
+func (updateService *UpdateService) HTTPPush(image io.ReadSeeker) (*TaskMonitor, error) {
+	if updateService.HTTPPushURI == "" {
+		return nil, common.NewActionNotSupportedError("HTTP push is not supported by this service")
+	}
+
+	client, ok := updateService.Client.(rawRequester)
+	if !ok {
+		return nil, fmt.Errorf("client does not support sending raw payloads")
+	}
+
+	resp, err := client.RunRawRequestWithHeaders(http.MethodPost, updateService.HTTPPushURI, image, "application/octet-stream", nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskMonitor(updateService.Client, resp)
+}
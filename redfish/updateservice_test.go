@@ -6,6 +6,7 @@ package redfish
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -66,3 +67,86 @@ func TestUpdateService(t *testing.T) {
 		assertMessage(t, result.UpdateServiceTarget, "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate")
 	})
 }
+
+// TestUpdateServiceSimpleUpdate tests the SimpleUpdate call.
+func TestUpdateServiceSimpleUpdate(t *testing.T) {
+	var result UpdateService
+	err := json.NewDecoder(strings.NewReader(simpleUpdateBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	_, err = result.SimpleUpdate("http://images.example.com/bmc.bin", nil, NFSTransferProtocolType, "", "")
+	if err == nil {
+		t.Error("Unsupported transfer protocol should be rejected")
+	}
+
+	monitor, err := result.SimpleUpdate("http://images.example.com/bmc.bin",
+		[]string{"/redfish/v1/UpdateService/FirmwareInventory/BMC"}, HTTPTransferProtocolType, "user", "pass")
+	if err != nil {
+		t.Fatalf("Error making SimpleUpdate call: %s", err)
+	}
+
+	if !monitor.Done() {
+		t.Error("Update without task should be complete")
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate" {
+		t.Errorf("Unexpected SimpleUpdate URL: %s", calls[0].URL)
+	}
+
+	for _, expected := range []string{
+		"ImageURI:http://images.example.com/bmc.bin",
+		"Targets:[/redfish/v1/UpdateService/FirmwareInventory/BMC]",
+		"TransferProtocol:HTTP",
+		"Username:user",
+	} {
+		if !strings.Contains(calls[0].Payload, expected) {
+			t.Errorf("Expected %s in payload: %s", expected, calls[0].Payload)
+		}
+	}
+}
+
+// TestUpdateServiceMultipartHTTPPush tests uploading an image.
+func TestUpdateServiceMultipartHTTPPush(t *testing.T) {
+	var result UpdateService
+	err := json.NewDecoder(strings.NewReader(simpleUpdateBody)).Decode(&result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodPost: {
+				testResponse(http.StatusAccepted, "", http.Header{"Location": []string{"/redfish/v1/TaskService/TaskMonitors/1"}}),
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	_, err = result.MultipartHTTPPush("bmc.bin", strings.NewReader("image"), nil)
+	if err == nil {
+		t.Error("Push should fail when the service has no MultipartHttpPushUri")
+	}
+
+	result.MultipartHTTPPushURI = "/redfish/v1/UpdateService/upload"
+	monitor, err := result.MultipartHTTPPush("bmc.bin", strings.NewReader("image"), &UpdateParameters{
+		Targets: []string{"/redfish/v1/UpdateService/FirmwareInventory/BMC"},
+	})
+	if err != nil {
+		t.Fatalf("Error pushing image: %s", err)
+	}
+
+	if monitor.Done() || monitor.URI != "/redfish/v1/TaskService/TaskMonitors/1" {
+		t.Errorf("Unexpected task monitor: %#v", monitor)
+	}
+
+	calls := testClient.CapturedCalls()
+	if calls[0].URL != "/redfish/v1/UpdateService/upload" {
+		t.Errorf("Unexpected push URL: %s", calls[0].URL)
+	}
+}
@@ -0,0 +1,456 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// ZoneType is the type of zone.
+type ZoneType string
+
+const (
+	// DefaultZoneType The zone in which all endpoints are added by default
+	// when instantiated.
+	DefaultZoneType ZoneType = "Default"
+	// ZoneOfEndpointsZoneType A zone that contains endpoints.
+	ZoneOfEndpointsZoneType ZoneType = "ZoneOfEndpoints"
+	// ZoneOfZonesZoneType A zone that contains zones.
+	ZoneOfZonesZoneType ZoneType = "ZoneOfZones"
+	// ZoneOfResourceBlocksZoneType A zone that contains resource blocks.
+	ZoneOfResourceBlocksZoneType ZoneType = "ZoneOfResourceBlocks"
+)
+
+// Zone is used to represent a simple zone for a Redfish implementation.
+type Zone struct {
+	common.Entity
+
+	// ODataContext is the odata context.
+	ODataContext string `json:"@odata.context"`
+	// ODataType is the odata type.
+	ODataType string `json:"@odata.type"`
+	// DefaultRoutingEnabled shall indicate whether routing within this zone
+	// is enabled.
+	DefaultRoutingEnabled bool
+	// Description provides a description of this resource.
+	Description string
+	// ExternalAccessibility shall contain an indication of accessibility of
+	// endpoints in this zone to endpoints outside of this zone.
+	ExternalAccessibility string
+	// Identifiers shall contain a list of all known durable names for the
+	// associated zone.
+	Identifiers []common.Identifier
+	// Oem shall contain the OEM extensions.
+	Oem json.RawMessage
+	// Status shall contain any status or health properties of the resource.
+	Status common.Status
+	// ZoneType shall contain the type of zone that this zone represents.
+	ZoneType ZoneType
+	// CollectionCapabilities shall describe the compositions that can be
+	// requested from the resource blocks of this zone.
+	CollectionCapabilities common.CollectionCapabilities `json:"@Redfish.CollectionCapabilities"`
+	// addressPools are the address pools associated with this zone.
+	addressPools []string
+	// containedByZones are the zones that contain this zone.
+	containedByZones []string
+	// containsZones are the zones contained by this zone.
+	containsZones []string
+	// endpoints are the endpoints that this zone contains.
+	endpoints []string
+	// involvedSwitches are the switches in this zone.
+	involvedSwitches []string
+	// resourceBlocks are the resource blocks in this zone.
+	resourceBlocks []string
+	// addEndpointTarget is the URL to send AddEndpoint requests.
+	addEndpointTarget string
+	// removeEndpointTarget is the URL to send RemoveEndpoint requests.
+	removeEndpointTarget string
+}
+
+// UnmarshalJSON unmarshals a Zone object from the raw JSON.
+func (zone *Zone) UnmarshalJSON(b []byte) error {
+	type temp Zone
+	type actions struct {
+		AddEndpoint struct {
+			Target string
+		} `json:"#Zone.AddEndpoint"`
+		RemoveEndpoint struct {
+			Target string
+		} `json:"#Zone.RemoveEndpoint"`
+	}
+	type links struct {
+		AddressPools     common.Links
+		ContainedByZones common.Links
+		ContainsZones    common.Links
+		Endpoints        common.Links
+		InvolvedSwitches common.Links
+		ResourceBlocks   common.Links
+	}
+	var t struct {
+		temp
+		Actions actions
+		Links   links
+	}
+
+	err := json.Unmarshal(b, &t)
+	if err != nil {
+		return err
+	}
+
+	*zone = Zone(t.temp)
+
+	// Extract the links to other entities for later
+	zone.addressPools = t.Links.AddressPools.ToStrings()
+	zone.containedByZones = t.Links.ContainedByZones.ToStrings()
+	zone.containsZones = t.Links.ContainsZones.ToStrings()
+	zone.endpoints = t.Links.Endpoints.ToStrings()
+	zone.involvedSwitches = t.Links.InvolvedSwitches.ToStrings()
+	zone.resourceBlocks = t.Links.ResourceBlocks.ToStrings()
+	zone.addEndpointTarget = t.Actions.AddEndpoint.Target
+	zone.removeEndpointTarget = t.Actions.RemoveEndpoint.Target
+
+	return nil
+}
+
+// EndpointLinks returns the links to the endpoints that this zone contains.
+func (zone *Zone) EndpointLinks() []string {
+	return append([]string(nil), zone.endpoints...)
+}
+
+// AddEndpoint adds an endpoint to this zone. The AddEndpoint action is used
+// if the service supports it, in which case the zone is read again
+// afterwards. Otherwise the endpoint links of the zone are updated.
+func (zone *Zone) AddEndpoint(endpoint string) error {
+	for _, uri := range zone.endpoints {
+		if uri == endpoint {
+			return nil
+		}
+	}
+
+	if zone.addEndpointTarget != "" {
+		return zone.endpointAction(zone.addEndpointTarget, endpoint)
+	}
+
+	return zone.SetEndpoints(append(zone.EndpointLinks(), endpoint))
+}
+
+// RemoveEndpoint removes an endpoint from this zone. The RemoveEndpoint
+// action is used if the service supports it, in which case the zone is read
+// again afterwards. Otherwise the endpoint links of the zone are updated.
+func (zone *Zone) RemoveEndpoint(endpoint string) error {
+	var remaining []string
+	for _, uri := range zone.endpoints {
+		if uri != endpoint {
+			remaining = append(remaining, uri)
+		}
+	}
+	if len(remaining) == len(zone.endpoints) {
+		return fmt.Errorf("endpoint %s is not part of zone %s", endpoint, zone.ID)
+	}
+
+	if zone.removeEndpointTarget != "" {
+		return zone.endpointAction(zone.removeEndpointTarget, endpoint)
+	}
+
+	return zone.SetEndpoints(remaining)
+}
+
+// endpointAction invokes an endpoint action of this zone. Actions are sent
+// without If-Match, and the zone is read again afterwards since the action
+// changed it along with its ETag.
+func (zone *Zone) endpointAction(target, endpoint string) error {
+	resp, err := zone.Client.Post(target, struct {
+		Endpoint odataLink
+	}{Endpoint: odataLink{ODataID: endpoint}})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return zone.Reload(zone)
+}
+
+// SetEndpoints replaces the endpoints of this zone.
+func (zone *Zone) SetEndpoints(endpoints []string) error {
+	t := struct {
+		Links struct {
+			Endpoints []odataLink
+		}
+	}{}
+	t.Links.Endpoints = odataLinks(endpoints)
+
+	err := zone.Patch(zone.ODataID, t)
+	if err == nil {
+		zone.endpoints = append([]string(nil), endpoints...)
+	}
+	return err
+}
+
+// ZoneParameters contains the properties of a new zone.
+type ZoneParameters struct {
+	// Name is the name of the new zone.
+	Name string `json:",omitempty"`
+	// Description is the description of the new zone.
+	Description string `json:",omitempty"`
+	// ZoneType is the type of the new zone.
+	ZoneType ZoneType `json:",omitempty"`
+	// Endpoints are the links to the endpoints the zone contains.
+	Endpoints []string `json:"-"`
+	// AddressPools are the links to the address pools of the zone.
+	AddressPools []string `json:"-"`
+}
+
+// MarshalJSON marshals the zone parameters, placing the endpoint and
+// address pool links under Links.
+func (parameters ZoneParameters) MarshalJSON() ([]byte, error) {
+	type temp ZoneParameters
+	type links struct {
+		AddressPools []odataLink `json:",omitempty"`
+		Endpoints    []odataLink `json:",omitempty"`
+	}
+	t := struct {
+		temp
+		Links *links `json:",omitempty"`
+	}{temp: temp(parameters)}
+
+	if len(parameters.Endpoints) > 0 || len(parameters.AddressPools) > 0 {
+		t.Links = &links{
+			AddressPools: odataLinks(parameters.AddressPools),
+			Endpoints:    odataLinks(parameters.Endpoints),
+		}
+	}
+
+	return json.Marshal(t)
+}
+
+// CreateZone creates a zone in the collection at uri. It returns the link to
+// the new zone.
+func CreateZone(c common.Client, uri string, parameters *ZoneParameters) (string, error) {
+	if strings.TrimSpace(uri) == "" {
+		return "", fmt.Errorf("uri should not be empty")
+	}
+	if parameters == nil {
+		return "", fmt.Errorf("zone parameters must be supplied")
+	}
+
+	resp, err := c.Post(uri, parameters)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// return the zone link from returned location
+	zoneLink := resp.Header.Get("Location")
+	if urlParser, err := url.ParseRequestURI(zoneLink); err == nil {
+		zoneLink = urlParser.RequestURI()
+	}
+
+	return zoneLink, nil
+}
+
+// DeleteZone will delete a Zone.
+func DeleteZone(c common.Client, uri string) error {
+	// validate uri
+	if strings.TrimSpace(uri) == "" {
+		return fmt.Errorf("uri should not be empty")
+	}
+
+	resp, err := c.Delete(uri)
+	if err == nil {
+		defer resp.Body.Close()
+	}
+
+	return err
+}
+
+// AddressPools gets the address pools associated with this zone.
+func (zone *Zone) AddressPools() ([]*AddressPool, error) {
+	var result []*AddressPool
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range zone.addressPools {
+		item, err := GetAddressPool(zone.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ContainedByZones gets the zones that contain this zone.
+func (zone *Zone) ContainedByZones() ([]*Zone, error) {
+	var result []*Zone
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range zone.containedByZones {
+		item, err := GetZone(zone.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ContainsZones gets the zones contained by this zone.
+func (zone *Zone) ContainsZones() ([]*Zone, error) {
+	var result []*Zone
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range zone.containsZones {
+		item, err := GetZone(zone.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// Endpoints gets the endpoints that this zone contains.
+func (zone *Zone) Endpoints() ([]*Endpoint, error) {
+	var result []*Endpoint
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range zone.endpoints {
+		item, err := GetEndpoint(zone.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// InvolvedSwitches gets the switches in this zone.
+func (zone *Zone) InvolvedSwitches() ([]*Switch, error) {
+	var result []*Switch
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range zone.involvedSwitches {
+		item, err := GetSwitch(zone.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// ResourceBlocks gets the resource blocks in this zone.
+func (zone *Zone) ResourceBlocks() ([]*ResourceBlock, error) {
+	var result []*ResourceBlock
+
+	collectionError := common.NewCollectionError()
+	for _, uri := range zone.resourceBlocks {
+		item, err := GetResourceBlock(zone.Client, uri)
+		if err != nil {
+			collectionError.Failures[uri] = err
+		} else {
+			result = append(result, item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// GetZone will get a Zone instance from the service.
+func GetZone(c common.Client, uri string) (*Zone, error) {
+	var zone Zone
+	return &zone, zone.Get(c, uri, &zone)
+}
+
+// ListReferencedZones gets the collection of Zone from
+// a provided reference.
+func ListReferencedZones(c common.Client, link string) ([]*Zone, error) { //nolint:dupl
+	var result []*Zone
+	if link == "" {
+		return result, nil
+	}
+
+	type GetResult struct {
+		Item  *Zone
+		Link  string
+		Error error
+	}
+
+	ch := make(chan GetResult)
+	collectionError := common.NewCollectionError()
+	get := func(link string) {
+		zone, err := GetZone(c, link)
+		ch <- GetResult{Item: zone, Link: link, Error: err}
+	}
+
+	go func() {
+		err := common.CollectList(get, c, link)
+		if err != nil {
+			collectionError.Failures[link] = err
+		}
+		close(ch)
+	}()
+
+	for r := range ch {
+		if r.Error != nil {
+			collectionError.Failures[r.Link] = r.Error
+		} else {
+			result = append(result, r.Item)
+		}
+	}
+
+	if collectionError.Empty() {
+		return result, nil
+	}
+
+	return result, collectionError
+}
+
+// odataLinks references a list of resources in request payloads.
+func odataLinks(uris []string) []odataLink {
+	result := make([]odataLink, 0, len(uris))
+	for _, uri := range uris {
+		result = append(result, odataLink{ODataID: uri})
+	}
+	return result
+}
@@ -0,0 +1,187 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bcohee/gofish/common"
+)
+
+var zoneBody = `{
+		"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Zones/1",
+		"@odata.type": "#Zone.v1_6_1.Zone",
+		"Id": "1",
+		"Name": "Zone 1",
+		"ZoneType": "ZoneOfEndpoints",
+		"Status": {
+			"State": "Enabled",
+			"Health": "OK"
+		},
+		"Links": {
+			"Endpoints": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Endpoints/Host1"
+				},
+				{
+					"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Endpoints/Target1"
+				}
+			],
+			"InvolvedSwitches": [
+				{
+					"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Switches/1"
+				}
+			]
+		}
+	}`
+
+// TestZone tests the parsing of Zone objects.
+func TestZone(t *testing.T) {
+	var result Zone
+	err := json.NewDecoder(strings.NewReader(zoneBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	if result.ID != "1" {
+		t.Errorf("Received invalid ID: %s", result.ID)
+	}
+
+	if result.ZoneType != ZoneOfEndpointsZoneType {
+		t.Errorf("Invalid ZoneType: %s", result.ZoneType)
+	}
+
+	if len(result.EndpointLinks()) != 2 {
+		t.Errorf("Invalid Endpoints links: %v", result.EndpointLinks())
+	}
+
+	if result.involvedSwitches[0] != "/redfish/v1/Fabrics/NVMeoF/Switches/1" {
+		t.Errorf("Invalid InvolvedSwitches links: %v", result.involvedSwitches)
+	}
+}
+
+// TestZoneEndpointMembership tests adding and removing endpoints by
+// updating the zone links.
+func TestZoneEndpointMembership(t *testing.T) {
+	var result Zone
+	err := json.NewDecoder(strings.NewReader(zoneBody)).Decode(&result)
+
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	testClient := &common.TestClient{}
+	result.SetClient(testClient)
+
+	err = result.AddEndpoint("/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2")
+	if err != nil {
+		t.Errorf("Error adding endpoint: %s", err)
+	}
+
+	err = result.AddEndpoint("/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2")
+	if err != nil {
+		t.Errorf("Error adding endpoint again: %s", err)
+	}
+
+	err = result.RemoveEndpoint("/redfish/v1/Fabrics/NVMeoF/Endpoints/Host1")
+	if err != nil {
+		t.Errorf("Error removing endpoint: %s", err)
+	}
+
+	err = result.RemoveEndpoint("/redfish/v1/Fabrics/NVMeoF/Endpoints/Host1")
+	if err == nil {
+		t.Error("Removing an endpoint that is not in the zone should fail")
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", len(calls))
+	}
+
+	if !strings.Contains(calls[0].Payload, "map[@odata.id:/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2]") {
+		t.Errorf("Unexpected add payload: %s", calls[0].Payload)
+	}
+
+	if strings.Contains(calls[1].Payload, "Host1") || !strings.Contains(calls[1].Payload, "Target1") {
+		t.Errorf("Unexpected remove payload: %s", calls[1].Payload)
+	}
+
+	links := result.EndpointLinks()
+	if len(links) != 2 || links[0] != "/redfish/v1/Fabrics/NVMeoF/Endpoints/Target1" {
+		t.Errorf("Unexpected endpoints after update: %v", links)
+	}
+}
+
+// TestZoneEndpointActions tests adding and removing endpoints with the zone
+// actions.
+func TestZoneEndpointActions(t *testing.T) {
+	body := strings.Replace(zoneBody, `"Links": {`, `"Actions": {
+			"#Zone.AddEndpoint": {
+				"target": "/redfish/v1/Fabrics/NVMeoF/Zones/1/Actions/Zone.AddEndpoint"
+			},
+			"#Zone.RemoveEndpoint": {
+				"target": "/redfish/v1/Fabrics/NVMeoF/Zones/1/Actions/Zone.RemoveEndpoint"
+			}
+		},
+		"Links": {`, 1)
+	added := strings.Replace(body, `"Endpoints": [`, `"Endpoints": [
+				{"@odata.id": "/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2"},`, 1)
+
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				testResponse(http.StatusOK, body, http.Header{"Etag": []string{`W/"1"`}}),
+				testResponse(http.StatusOK, added, http.Header{"Etag": []string{`W/"2"`}}),
+				testResponse(http.StatusOK, body, http.Header{"Etag": []string{`W/"3"`}}),
+			},
+		},
+	}
+	result, err := GetZone(testClient, "/redfish/v1/Fabrics/NVMeoF/Zones/1")
+	if err != nil {
+		t.Fatalf("Error getting zone: %s", err)
+	}
+
+	err = result.AddEndpoint("/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2")
+	if err != nil {
+		t.Errorf("Error adding endpoint: %s", err)
+	}
+	if links := result.EndpointLinks(); len(links) != 3 || result.ETag() != `W/"2"` {
+		t.Errorf("Expected the zone to be read again, got: %v %s", links, result.ETag())
+	}
+
+	err = result.RemoveEndpoint("/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2")
+	if err != nil {
+		t.Errorf("Error removing endpoint: %s", err)
+	}
+
+	err = result.SetEndpoints([]string{"/redfish/v1/Fabrics/NVMeoF/Endpoints/Target1"})
+	if err != nil {
+		t.Errorf("Error setting endpoints: %s", err)
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 6 {
+		t.Fatalf("Expected 6 calls, got %d", len(calls))
+	}
+
+	if calls[1].URL != "/redfish/v1/Fabrics/NVMeoF/Zones/1/Actions/Zone.AddEndpoint" ||
+		calls[1].Payload != "map[Endpoint:map[@odata.id:/redfish/v1/Fabrics/NVMeoF/Endpoints/Host2]]" ||
+		calls[1].CustomHeaders["If-Match"] != "" {
+		t.Errorf("Unexpected AddEndpoint call: %v", calls[1])
+	}
+
+	if calls[3].URL != "/redfish/v1/Fabrics/NVMeoF/Zones/1/Actions/Zone.RemoveEndpoint" ||
+		calls[3].CustomHeaders["If-Match"] != "" {
+		t.Errorf("Unexpected RemoveEndpoint call: %v", calls[3])
+	}
+
+	if calls[5].Action != http.MethodPatch || calls[5].CustomHeaders["If-Match"] != `W/"3"` {
+		t.Errorf("Expected the update to use the current ETag, got: %v", calls[5])
+	}
+}
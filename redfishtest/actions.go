@@ -0,0 +1,185 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+import (
+	"net/http"
+	"sync"
+)
+
+const (
+	// TaskService is the URI of the task service added to the resources the
+	// first time an action runs as a task, if they do not define one.
+	TaskService = "/redfish/v1/TaskService"
+	// Tasks is the URI of the task collection added to the resources the
+	// first time an action runs as a task, if they do not define one.
+	Tasks = "/redfish/v1/TaskService/Tasks"
+	// TaskMonitors is the URI under which the task monitors are served.
+	TaskMonitors = "/redfish/v1/TaskService/TaskMonitors"
+)
+
+// ActionHandler carries out an action invoked with the given parameters. It
+// can change the resources of the server to reflect the effects of the
+// action. Returning an error fails the action, and the error message is
+// reported to the client.
+type ActionHandler func(server *Server, parameters map[string]interface{}) error
+
+// action is a registered action handler.
+type action struct {
+	handler ActionHandler
+	// withTask is set if the action runs as a task.
+	withTask bool
+	// polls is the number of polls of the task monitor before the task
+	// completes.
+	polls int
+}
+
+// taskMonitor tracks an action running as a task.
+type taskMonitor struct {
+	mu         sync.Mutex
+	task       string
+	handler    ActionHandler
+	parameters map[string]interface{}
+	polls      int
+	done       bool
+}
+
+// HandleAction registers the handler of the action with the given target,
+// for example /redfish/v1/Systems/1/Actions/ComputerSystem.Reset. The
+// service answers the action with 204 No Content once the handler returns.
+func (s *Server) HandleAction(target string, handler ActionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions[cleanURI(target)] = &action{handler: handler}
+}
+
+// HandleActionWithTask registers the handler of an action running as a
+// task. The service answers the action with 202 Accepted and a task monitor,
+// which reports the task as running for the given number of polls. The
+// handler is called when the task monitor is polled after that, completing
+// the task.
+func (s *Server) HandleActionWithTask(target string, polls int, handler ActionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions[cleanURI(target)] = &action{handler: handler, withTask: true, polls: polls}
+}
+
+// invoke handles a POST to an action.
+func (s *Server) invoke(w http.ResponseWriter, a *action, parameters map[string]interface{}) {
+	if !a.withTask {
+		if err := a.handler(s, parameters); err != nil {
+			writeError(w, http.StatusBadRequest, "GeneralError", err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := s.tasksURI()
+	task := s.addMember(tasks, map[string]interface{}{
+		"@odata.type":     "#Task.v1_4_3.Task",
+		"Name":            "Task",
+		"TaskState":       "Running",
+		"TaskStatus":      "OK",
+		"PercentComplete": 0,
+		"Messages":        []interface{}{},
+	})
+	monitor := TaskMonitors + "/" + s.resources[task]["Id"].(string)
+	s.resources[task]["TaskMonitor"] = monitor
+	s.monitors[monitor] = &taskMonitor{
+		task:       task,
+		handler:    a.handler,
+		parameters: parameters,
+		polls:      a.polls,
+	}
+
+	w.Header().Set("Location", monitor)
+	s.writeResource(w, http.StatusAccepted, task)
+}
+
+// poll handles a GET of a task monitor, completing the task once it was
+// polled enough.
+func (s *Server) poll(w http.ResponseWriter, monitor *taskMonitor) {
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+
+	if !monitor.done && monitor.polls > 0 {
+		monitor.polls--
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.writeResource(w, http.StatusAccepted, monitor.task)
+		return
+	}
+
+	var err error
+	if !monitor.done {
+		err = monitor.handler(s, monitor.parameters)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.resources[monitor.task]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ResourceMissingAtURI", "The task of the task monitor was deleted.")
+		return
+	}
+
+	if !monitor.done {
+		monitor.done = true
+		task["PercentComplete"] = 100
+		if err != nil {
+			task["TaskState"] = "Exception"
+			task["TaskStatus"] = "Critical"
+			task["Messages"] = []interface{}{
+				map[string]interface{}{
+					"MessageId": "Base.1.8.GeneralError",
+					"Message":   err.Error(),
+					"Severity":  "Critical",
+				},
+			}
+		} else {
+			task["TaskState"] = "Completed"
+		}
+		s.versions[monitor.task]++
+	}
+
+	s.writeResource(w, http.StatusOK, monitor.task)
+}
+
+// tasksURI returns the URI of the task collection, adding the task service
+// if the resources do not define one.
+func (s *Server) tasksURI() string {
+	root := s.resources[ServiceRoot]
+	service := cleanURI(odataID(root["Tasks"]))
+	if service == "" {
+		service = TaskService
+		root["Tasks"] = map[string]interface{}{"@odata.id": service}
+	}
+
+	if _, ok := s.resources[service]; !ok {
+		s.resources[service] = map[string]interface{}{
+			"@odata.id":   service,
+			"@odata.type": "#TaskService.v1_1_4.TaskService",
+			"Id":          "TaskService",
+			"Name":        "Task Service",
+			"Tasks":       map[string]interface{}{"@odata.id": Tasks},
+		}
+	}
+
+	tasks := cleanURI(odataID(s.resources[service]["Tasks"]))
+	if tasks == "" {
+		tasks = Tasks
+		s.resources[service]["Tasks"] = map[string]interface{}{"@odata.id": tasks}
+	}
+	if _, ok := s.resources[tasks]; !ok {
+		s.resources[tasks] = newCollection(tasks, "#TaskCollection.TaskCollection", "Task Collection")
+	}
+
+	return tasks
+}
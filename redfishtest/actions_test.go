@@ -0,0 +1,104 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish"
+	"github.com/bcohee/gofish/common"
+	"github.com/bcohee/gofish/redfish"
+)
+
+const resetTarget = "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"
+
+// resetHandler sets the power state of the system for a reset.
+func resetHandler(server *Server, parameters map[string]interface{}) error {
+	system, _ := server.Resource("/redfish/v1/Systems/1")
+	switch parameters["ResetType"] {
+	case "ForceOff":
+		system["PowerState"] = "Off"
+	case "On":
+		system["PowerState"] = "On"
+	default:
+		return errors.New("unsupported reset type")
+	}
+	return server.SetResource("/redfish/v1/Systems/1", system)
+}
+
+// TestServerAction tests invoking an action.
+func TestServerAction(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	server.HandleAction(resetTarget, resetHandler)
+	client := connect(t, server, gofish.ClientConfig{})
+
+	system := getSystem(t, client)
+	if err := system.Reset(redfish.ForceOffResetType); err != nil {
+		t.Fatalf("Error resetting system: %s", err)
+	}
+	if system = getSystem(t, client); system.PowerState != redfish.OffPowerState {
+		t.Errorf("Unexpected power state: %s", system.PowerState)
+	}
+
+	err := system.Reset(redfish.NmiResetType)
+	var redfishError *common.Error
+	if !errors.As(err, &redfishError) || redfishError.Message != "unsupported reset type" {
+		t.Errorf("Expected the error of the handler, got: %v", err)
+	}
+
+	_, err = client.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.AddResourceBlock", map[string]interface{}{})
+	if !errors.Is(err, common.ErrActionNotSupported) {
+		t.Errorf("Expected action not supported error, got: %v", err)
+	}
+}
+
+// TestServerActionWithTask tests following an action running as a task.
+func TestServerActionWithTask(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	server.HandleActionWithTask(resetTarget, 2, resetHandler)
+	client := connect(t, server, gofish.ClientConfig{})
+
+	system := getSystem(t, client)
+	monitor, err := system.ResetWithTask(redfish.ForceOffResetType)
+	if err != nil {
+		t.Fatalf("Error resetting system: %s", err)
+	}
+	if monitor.Done() || monitor.Task() == nil || monitor.Task().TaskState != redfish.RunningTaskState {
+		t.Fatalf("Expected a running task, got: %+v", monitor.Task())
+	}
+	monitor.PollInterval = time.Millisecond
+
+	polls := 0
+	task, err := monitor.Wait(context.Background(), func(task *redfish.Task) { polls++ })
+	if err != nil {
+		t.Fatalf("Error waiting for task: %s", err)
+	}
+	if task.TaskState != redfish.CompletedTaskState || polls != 3 {
+		t.Errorf("Unexpected task after %d polls: %s", polls, task.TaskState)
+	}
+	if system = getSystem(t, client); system.PowerState != redfish.OffPowerState {
+		t.Errorf("Unexpected power state: %s", system.PowerState)
+	}
+
+	tasks, err := redfish.ListReferencedTasks(client, Tasks)
+	if err != nil || len(tasks) != 1 {
+		t.Errorf("Expected 1 task, got %d: %v", len(tasks), err)
+	}
+
+	monitor, err = system.ResetWithTask(redfish.NmiResetType)
+	if err != nil {
+		t.Fatalf("Error resetting system: %s", err)
+	}
+	monitor.PollInterval = time.Millisecond
+	task, err = monitor.Wait(context.Background(), nil)
+	if err == nil || task.TaskState != redfish.ExceptionTaskState {
+		t.Errorf("Expected the task to fail, got: %v", err)
+	}
+}
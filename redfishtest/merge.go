@@ -0,0 +1,61 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+// mergePatch applies a PATCH request to a JSON value. Objects are merged
+// property by property and null removes a property. Arrays of objects are
+// patched element by element: an empty object leaves the element unchanged,
+// null removes it and elements past the end of the array are added. Other
+// arrays are replaced.
+func mergePatch(original, patch interface{}) interface{} {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		o, ok := original.(map[string]interface{})
+		if !ok {
+			o = make(map[string]interface{})
+		}
+		result := copyObject(o)
+		for key, value := range p {
+			if value == nil {
+				delete(result, key)
+				continue
+			}
+			result[key] = mergePatch(result[key], value)
+		}
+		return result
+	case []interface{}:
+		o, ok := original.([]interface{})
+		if !ok || !isObjectArray(o) {
+			return copyValue(p)
+		}
+		var result []interface{}
+		for i, value := range p {
+			switch {
+			case value == nil:
+				continue
+			case i < len(o):
+				result = append(result, mergePatch(o[i], value))
+			default:
+				result = append(result, copyValue(value))
+			}
+		}
+		if result == nil {
+			result = []interface{}{}
+		}
+		return result
+	default:
+		return p
+	}
+}
+
+// isObjectArray checks whether an array holds objects.
+func isObjectArray(array []interface{}) bool {
+	for _, value := range array {
+		if _, ok := value.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return len(array) > 0
+}
@@ -0,0 +1,76 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestMergePatch tests applying PATCH requests to resources.
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+	}{
+		{
+			"property",
+			`{"AssetTag": "", "Name": "System"}`,
+			`{"AssetTag": "Rack 12"}`,
+			`{"AssetTag": "Rack 12", "Name": "System"}`,
+		},
+		{
+			"nested object",
+			`{"Boot": {"BootSourceOverrideEnabled": "Once", "BootSourceOverrideTarget": "None"}}`,
+			`{"Boot": {"BootSourceOverrideTarget": "Pxe"}}`,
+			`{"Boot": {"BootSourceOverrideEnabled": "Once", "BootSourceOverrideTarget": "Pxe"}}`,
+		},
+		{
+			"null property",
+			`{"AssetTag": "Rack 12", "Name": "System"}`,
+			`{"AssetTag": null}`,
+			`{"Name": "System"}`,
+		},
+		{
+			"primitive array",
+			`{"StaticNameServers": ["10.0.0.1", "10.0.0.2"]}`,
+			`{"StaticNameServers": ["10.0.0.3"]}`,
+			`{"StaticNameServers": ["10.0.0.3"]}`,
+		},
+		{
+			"object array",
+			`{"IPv4StaticAddresses": [{"Address": "10.0.0.2", "SubnetMask": "255.0.0.0"}, {"Address": "10.0.1.2"}]}`,
+			`{"IPv4StaticAddresses": [{}, {"Address": "10.0.1.3"}, {"Address": "10.0.2.2"}]}`,
+			`{"IPv4StaticAddresses": [{"Address": "10.0.0.2", "SubnetMask": "255.0.0.0"}, {"Address": "10.0.1.3"}, {"Address": "10.0.2.2"}]}`,
+		},
+		{
+			"object array removal",
+			`{"IPv4StaticAddresses": [{"Address": "10.0.0.2"}, {"Address": "10.0.1.2"}]}`,
+			`{"IPv4StaticAddresses": [null, {}]}`,
+			`{"IPv4StaticAddresses": [{"Address": "10.0.1.2"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		var original, patch, expected interface{}
+		if err := json.Unmarshal([]byte(test.original), &original); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if err := json.Unmarshal([]byte(test.patch), &patch); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if err := json.Unmarshal([]byte(test.expected), &expected); err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+
+		result, _ := json.Marshal(mergePatch(original, patch))
+		want, _ := json.Marshal(expected)
+		if string(result) != string(want) {
+			t.Errorf("%s: expected %s, got %s", test.name, want, result)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// NewServerFromMockup starts a server for the resources of a mockup
+// directory, in the layout of the DMTF Redfish mockups: each resource is an
+// index.json file in the directory matching its URI. The directory either
+// holds the service root itself or a redfish/v1 directory holding it.
+func NewServerFromMockup(dir string) (*Server, error) {
+	resources, err := LoadMockup(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+	return NewServer(resources)
+}
+
+// LoadMockup reads the resources of a mockup, in the layout of the DMTF
+// Redfish mockups, by URI.
+func LoadMockup(mockup fs.FS) (map[string]interface{}, error) {
+	root := "."
+	if info, err := fs.Stat(mockup, "redfish/v1"); err == nil && info.IsDir() {
+		root = "redfish/v1"
+	}
+
+	resources := make(map[string]interface{})
+	err := fs.WalkDir(mockup, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || entry.Name() != "index.json" {
+			return nil
+		}
+
+		b, err := fs.ReadFile(mockup, name)
+		if err != nil {
+			return err
+		}
+		var resource map[string]interface{}
+		if err := json.Unmarshal(b, &resource); err != nil {
+			return fmt.Errorf("mockup resource %s: %w", name, err)
+		}
+
+		dir := path.Dir(name)
+		if root != "." {
+			dir = strings.TrimPrefix(dir, root)
+		}
+		resources[cleanURI(path.Join(ServiceRoot, dir))] = resource
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := resources[ServiceRoot]; !ok {
+		return nil, fmt.Errorf("mockup has no service root")
+	}
+	return resources, nil
+}
@@ -0,0 +1,96 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bcohee/gofish"
+)
+
+// writeMockup writes the resources of a mockup to a directory.
+func writeMockup(t *testing.T, dir string, resources map[string]string) {
+	for name, body := range resources {
+		path := filepath.Join(dir, filepath.FromSlash(name), "index.json")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestNewServerFromMockup tests serving the resources of a mockup directory.
+func TestNewServerFromMockup(t *testing.T) {
+	for _, prefix := range []string{"", "redfish/v1"} {
+		dir := t.TempDir()
+		writeMockup(t, dir, map[string]string{
+			prefix:                `{"@odata.id": "/redfish/v1/", "Id": "RootService", "Systems": {"@odata.id": "/redfish/v1/Systems"}}`,
+			prefix + "/Systems":   `{"@odata.id": "/redfish/v1/Systems", "Members": [{"@odata.id": "/redfish/v1/Systems/1"}], "Members@odata.count": 1}`,
+			prefix + "/Systems/1": `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Name": "Mockup System"}`,
+		})
+		if err := os.WriteFile(filepath.Join(dir, "README"), []byte("mockup"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		server, err := NewServerFromMockup(dir)
+		if err != nil {
+			t.Fatalf("Error starting server: %s", err)
+		}
+
+		client, err := gofish.Connect(gofish.ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+		if err != nil {
+			t.Fatalf("Error connecting: %s", err)
+		}
+		systems, err := client.Service.Systems()
+		if err != nil || len(systems) != 1 || systems[0].Name != "Mockup System" {
+			t.Errorf("Unexpected systems for prefix %q: %v %v", prefix, systems, err)
+		}
+		server.Close()
+	}
+
+	if _, err := NewServerFromMockup(t.TempDir()); err == nil {
+		t.Error("Expected an error for a mockup without service root")
+	}
+}
+
+// TestMockupAddMember tests that members created in a mockup collection do
+// not replace the members it holds.
+func TestMockupAddMember(t *testing.T) {
+	dir := t.TempDir()
+	writeMockup(t, dir, map[string]string{
+		"":           `{"@odata.id": "/redfish/v1/", "Id": "RootService", "Systems": {"@odata.id": "/redfish/v1/Systems"}}`,
+		"/Systems":   `{"@odata.id": "/redfish/v1/Systems", "Members": [{"@odata.id": "/redfish/v1/Systems/1"}, {"@odata.id": "/redfish/v1/Systems/2"}], "Members@odata.count": 2}`,
+		"/Systems/1": `{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Name": "First"}`,
+		"/Systems/2": `{"@odata.id": "/redfish/v1/Systems/2", "Id": "2", "Name": "Second"}`,
+	})
+	server, err := NewServerFromMockup(dir)
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	defer server.Close()
+	client := connect(t, server, gofish.ClientConfig{})
+
+	resp, err := client.Post("/redfish/v1/Systems", map[string]interface{}{"Name": "Third"})
+	if err != nil {
+		t.Fatalf("Error creating system: %s", err)
+	}
+	resp.Body.Close()
+	if location := resp.Header.Get("Location"); location != "/redfish/v1/Systems/3" {
+		t.Errorf("Unexpected location: %s", location)
+	}
+
+	for uri, name := range map[string]string{"/redfish/v1/Systems/1": "First", "/redfish/v1/Systems/2": "Second"} {
+		if resource, _ := server.Resource(uri); resource["Name"] != name {
+			t.Errorf("Expected %s to be kept, got: %v", uri, resource)
+		}
+	}
+	if collection, _ := server.Resource("/redfish/v1/Systems"); collection["Members@odata.count"] != 3 {
+		t.Errorf("Unexpected member count: %v", collection["Members@odata.count"])
+	}
+}
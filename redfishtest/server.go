@@ -0,0 +1,531 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+// Package redfishtest provides an in-process Redfish service to test code
+// using gofish end to end.
+package redfishtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// ServiceRoot is the URI of the service root.
+	ServiceRoot = "/redfish/v1"
+	// SessionService is the URI of the session service added to the
+	// resources if they do not define one.
+	SessionService = "/redfish/v1/SessionService"
+	// Sessions is the URI of the session collection added to the resources
+	// if they do not define one.
+	Sessions = "/redfish/v1/SessionService/Sessions"
+)
+
+// Server is a Redfish service serving a tree of resources over HTTP. The
+// resources are JSON objects by URI, which change as the service handles
+// requests:
+//
+//   - GET returns the resource with its ETag, or 304 Not Modified if it
+//     matches If-None-Match.
+//   - PATCH merges the request into the resource, following the Redfish
+//     rules for arrays.
+//   - POST to a collection creates a member, POST to the session collection
+//     creates a session and POST to an action calls its handler.
+//   - DELETE removes the resource from the tree and from its collection.
+//
+// PATCH and DELETE requests carrying If-Match fail with 412 Precondition
+// Failed if the resource changed.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// resources holds the resources by URI.
+	resources map[string]map[string]interface{}
+	// versions holds the version of the resources, used as their ETag.
+	versions map[string]int
+	// sessions holds the URI of the sessions by token.
+	sessions map[string]string
+	// username and password are the credentials of the service, no
+	// authentication is required if username is empty.
+	username string
+	password string
+	// actions holds the action handlers by target.
+	actions map[string]*action
+	// monitors holds the task monitors by URI.
+	monitors map[string]*taskMonitor
+	// lastID is used to generate the identifiers of new resources.
+	lastID int
+}
+
+// NewServer starts a server for the given resources. The resources are given
+// by URI and are JSON objects, such as maps, structs or json.RawMessage.
+// A service root and a session service are added if missing.
+func NewServer(resources map[string]interface{}) (*Server, error) {
+	server := newServer()
+	for uri, resource := range resources {
+		if err := server.setResource(uri, resource); err != nil {
+			return nil, err
+		}
+	}
+	server.start()
+	return server, nil
+}
+
+// newServer creates a server without resources, which is not started.
+func newServer() *Server {
+	return &Server{
+		resources: make(map[string]map[string]interface{}),
+		versions:  make(map[string]int),
+		sessions:  make(map[string]string),
+		actions:   make(map[string]*action),
+		monitors:  make(map[string]*taskMonitor),
+	}
+}
+
+// start adds the missing service resources and starts serving.
+func (s *Server) start() {
+	root, ok := s.resources[ServiceRoot]
+	if !ok {
+		root = map[string]interface{}{
+			"@odata.id":   ServiceRoot,
+			"@odata.type": "#ServiceRoot.v1_5_0.ServiceRoot",
+			"Id":          "RootService",
+			"Name":        "Root Service",
+		}
+		s.resources[ServiceRoot] = root
+	}
+
+	if _, ok := root["SessionService"]; !ok {
+		root["SessionService"] = map[string]interface{}{"@odata.id": SessionService}
+	}
+	links, _ := root["Links"].(map[string]interface{})
+	if links == nil {
+		links = make(map[string]interface{})
+		root["Links"] = links
+	}
+	if _, ok := links["Sessions"]; !ok {
+		links["Sessions"] = map[string]interface{}{"@odata.id": Sessions}
+	}
+
+	sessions := odataID(links["Sessions"])
+	if _, ok := s.resources[SessionService]; !ok && odataID(root["SessionService"]) == SessionService {
+		s.resources[SessionService] = map[string]interface{}{
+			"@odata.id":   SessionService,
+			"@odata.type": "#SessionService.v1_1_8.SessionService",
+			"Id":          "SessionService",
+			"Name":        "Session Service",
+			"Sessions":    map[string]interface{}{"@odata.id": sessions},
+		}
+	}
+	if _, ok := s.resources[sessions]; !ok {
+		s.resources[sessions] = newCollection(sessions, "#SessionCollection.SessionCollection", "Session Collection")
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+}
+
+// SetCredentials requires clients to authenticate with the given
+// credentials, using a session or basic authentication. Only the service
+// root and the creation of sessions are allowed without authentication.
+func (s *Server) SetCredentials(username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username = username
+	s.password = password
+}
+
+// Resource returns a copy of the resource at the given URI.
+func (s *Server) Resource(uri string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resource, ok := s.resources[cleanURI(uri)]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(resource), true
+}
+
+// SetResource adds or replaces the resource at the given URI, as another
+// client of the service would. Its ETag changes.
+func (s *Server) SetResource(uri string, resource interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.setResource(uri, resource)
+}
+
+// setResource adds or replaces a resource.
+func (s *Server) setResource(uri string, resource interface{}) error {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return fmt.Errorf("resource %s: %w", uri, err)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(b, &object); err != nil || object == nil {
+		return fmt.Errorf("resource %s is not a JSON object", uri)
+	}
+
+	uri = cleanURI(uri)
+	object["@odata.id"] = uri
+	s.resources[uri] = object
+	s.versions[uri]++
+	return nil
+}
+
+// handle serves a request.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	uri := cleanURI(r.URL.Path)
+
+	if uri == "/redfish" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"v1": ServiceRoot + "/"})
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		b, err := io.ReadAll(r.Body)
+		if err == nil && len(b) > 0 {
+			err = json.Unmarshal(b, &body)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "MalformedJSON", "The request body submitted was malformed JSON.")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	if !s.authorized(r, uri) {
+		s.mu.Unlock()
+		w.Header().Set("WWW-Authenticate", `Basic realm="redfishtest"`)
+		writeError(w, http.StatusUnauthorized, "InsufficientPrivilege", "There are insufficient privileges for the account or credentials associated with the current session to perform the requested operation.")
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if a, ok := s.actions[uri]; ok {
+			s.mu.Unlock()
+			s.invoke(w, a, body)
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		if monitor, ok := s.monitors[uri]; ok {
+			s.mu.Unlock()
+			s.poll(w, monitor)
+			return
+		}
+	}
+	defer s.mu.Unlock()
+
+	resource, ok := s.resources[uri]
+	if !ok {
+		if r.Method == http.MethodPost && strings.Contains(uri, "/Actions/") {
+			writeError(w, http.StatusBadRequest, "ActionNotSupported", fmt.Sprintf("The action %s is not supported by the resource.", uri))
+			return
+		}
+		writeError(w, http.StatusNotFound, "ResourceMissingAtURI", fmt.Sprintf("The resource at the URI %s was not found.", uri))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if match := r.Header.Get("If-None-Match"); match != "" && match == s.etag(uri) {
+			w.Header().Set("ETag", s.etag(uri))
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		s.writeResource(w, http.StatusOK, uri)
+	case http.MethodPatch:
+		if !s.checkETag(w, r, uri) {
+			return
+		}
+		if property := readOnlyProperty(body); property != "" {
+			writeError(w, http.StatusBadRequest, "PropertyNotWritable", fmt.Sprintf("The property %s is a read only property and cannot be assigned a value.", property))
+			return
+		}
+		s.resources[uri] = mergePatch(resource, body).(map[string]interface{})
+		s.versions[uri]++
+		s.writeResource(w, http.StatusOK, uri)
+	case http.MethodPost:
+		if _, ok := resource["Members"]; !ok {
+			writeError(w, http.StatusMethodNotAllowed, "OperationNotAllowed", "The HTTP method is not allowed on this resource.")
+			return
+		}
+		if uri == s.sessionsURI() {
+			s.createSession(w, uri, body)
+			return
+		}
+		member := s.addMember(uri, body)
+		w.Header().Set("Location", member)
+		s.writeResource(w, http.StatusCreated, member)
+	case http.MethodDelete:
+		if !s.checkETag(w, r, uri) {
+			return
+		}
+		s.deleteResource(uri)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "OperationNotAllowed", "The HTTP method is not allowed on this resource.")
+	}
+}
+
+// authorized checks the credentials of a request.
+func (s *Server) authorized(r *http.Request, uri string) bool {
+	if s.username == "" {
+		return true
+	}
+	if r.Method == http.MethodGet && (uri == ServiceRoot || uri == "/redfish") {
+		return true
+	}
+	if r.Method == http.MethodPost && uri == s.sessionsURI() {
+		return true
+	}
+
+	if token := r.Header.Get("X-Auth-Token"); token != "" {
+		_, ok := s.sessions[token]
+		return ok
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && username == s.username && password == s.password
+}
+
+// sessionsURI returns the URI of the session collection.
+func (s *Server) sessionsURI() string {
+	if links, ok := s.resources[ServiceRoot]["Links"].(map[string]interface{}); ok {
+		return cleanURI(odataID(links["Sessions"]))
+	}
+	return Sessions
+}
+
+// createSession creates a session for valid credentials.
+func (s *Server) createSession(w http.ResponseWriter, uri string, body map[string]interface{}) {
+	username, _ := body["UserName"].(string)
+	password, _ := body["Password"].(string)
+	if s.username != "" && (username != s.username || password != s.password) {
+		writeError(w, http.StatusUnauthorized, "ResourceAtURIUnauthorized", "While accessing the resource at the URI, the service received an authorization error.")
+		return
+	}
+
+	session := s.addMember(uri, map[string]interface{}{
+		"@odata.type": "#Session.v1_1_0.Session",
+		"Name":        "User Session",
+		"UserName":    username,
+	})
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		writeError(w, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	token := hex.EncodeToString(b)
+	s.sessions[token] = session
+
+	w.Header().Set("X-Auth-Token", token)
+	w.Header().Set("Location", session)
+	s.writeResource(w, http.StatusCreated, session)
+}
+
+// addMember creates a member of a collection, returning its URI.
+func (s *Server) addMember(collection string, body map[string]interface{}) string {
+	id, _ := body["Id"].(string)
+	for id == "" || s.resources[collection+"/"+id] != nil {
+		s.lastID++
+		id = strconv.Itoa(s.lastID)
+	}
+
+	member := copyObject(body)
+	uri := collection + "/" + id
+	member["@odata.id"] = uri
+	member["Id"] = id
+	delete(member, "Password")
+	s.resources[uri] = member
+	s.versions[uri]++
+
+	resource := s.resources[collection]
+	members, _ := resource["Members"].([]interface{})
+	resource["Members"] = append(members, map[string]interface{}{"@odata.id": uri})
+	resource["Members@odata.count"] = len(members) + 1
+	s.versions[collection]++
+
+	return uri
+}
+
+// deleteResource removes a resource, the resources below it, and its
+// membership in collections.
+func (s *Server) deleteResource(uri string) {
+	for key := range s.resources {
+		if key == uri || strings.HasPrefix(key, uri+"/") {
+			delete(s.resources, key)
+			delete(s.versions, key)
+		}
+	}
+	for token, session := range s.sessions {
+		if session == uri {
+			delete(s.sessions, token)
+		}
+	}
+
+	for key, resource := range s.resources {
+		members, ok := resource["Members"].([]interface{})
+		if !ok {
+			continue
+		}
+		var kept []interface{}
+		for _, member := range members {
+			if cleanURI(odataID(member)) != uri {
+				kept = append(kept, member)
+			}
+		}
+		if len(kept) != len(members) {
+			if kept == nil {
+				kept = []interface{}{}
+			}
+			resource["Members"] = kept
+			resource["Members@odata.count"] = len(kept)
+			s.versions[key]++
+		}
+	}
+}
+
+// etag returns the ETag of a resource.
+func (s *Server) etag(uri string) string {
+	return fmt.Sprintf(`W/"%d"`, s.versions[uri])
+}
+
+// checkETag checks the If-Match header of a request, writing the error if
+// the resource changed.
+func (s *Server) checkETag(w http.ResponseWriter, r *http.Request, uri string) bool {
+	match := r.Header.Get("If-Match")
+	if match == "" || match == "*" || match == s.etag(uri) {
+		return true
+	}
+	writeError(w, http.StatusPreconditionFailed, "PreconditionFailed", "The ETag supplied did not match the ETag required to change this resource.")
+	return false
+}
+
+// writeResource writes a resource with its ETag and allowed methods.
+func (s *Server) writeResource(w http.ResponseWriter, status int, uri string) {
+	resource := copyObject(s.resources[uri])
+	resource["@odata.etag"] = s.etag(uri)
+	w.Header().Set("ETag", s.etag(uri))
+	if _, ok := resource["Members"]; ok {
+		w.Header().Set("Allow", "GET, HEAD, POST")
+	} else {
+		w.Header().Set("Allow", "GET, HEAD, PATCH, DELETE")
+	}
+	writeJSON(w, status, resource)
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("OData-Version", "4.0")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// writeError writes a Redfish error response for a message of the Base
+// registry.
+func writeError(w http.ResponseWriter, status int, key, message string) {
+	messageID := "Base.1.8." + key
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    messageID,
+			"message": message,
+			"@Message.ExtendedInfo": []interface{}{
+				map[string]interface{}{
+					"@odata.type": "#Message.v1_1_1.Message",
+					"MessageId":   messageID,
+					"Message":     message,
+					"Severity":    "Critical",
+				},
+			},
+		},
+	})
+}
+
+// readOnlyProperty returns the first annotation found in a PATCH request,
+// since annotations cannot be updated.
+func readOnlyProperty(body map[string]interface{}) string {
+	var names []string
+	for name := range body {
+		if strings.HasPrefix(name, "@odata.") || name == "Id" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// newCollection creates an empty resource collection.
+func newCollection(uri, odataType, name string) map[string]interface{} {
+	return map[string]interface{}{
+		"@odata.id":           uri,
+		"@odata.type":         odataType,
+		"Name":                name,
+		"Members":             []interface{}{},
+		"Members@odata.count": 0,
+	}
+}
+
+// odataID returns the @odata.id of a link.
+func odataID(link interface{}) string {
+	if object, ok := link.(map[string]interface{}); ok {
+		id, _ := object["@odata.id"].(string)
+		return id
+	}
+	return ""
+}
+
+// cleanURI removes the query and trailing slash of a URI.
+func cleanURI(uri string) string {
+	if i := strings.IndexAny(uri, "?#"); i >= 0 {
+		uri = uri[:i]
+	}
+	if len(uri) > 1 {
+		uri = strings.TrimSuffix(uri, "/")
+	}
+	return uri
+}
+
+// copyObject returns a deep copy of a JSON object.
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	return copyValue(object).(map[string]interface{})
+}
+
+// copyValue returns a deep copy of a JSON value.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = copyValue(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = copyValue(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
@@ -0,0 +1,255 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package redfishtest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bcohee/gofish"
+	"github.com/bcohee/gofish/common"
+	"github.com/bcohee/gofish/redfish"
+)
+
+func newTestServer(t *testing.T) *Server {
+	server, err := NewServer(map[string]interface{}{
+		"/redfish/v1": map[string]interface{}{
+			"Id":             "RootService",
+			"Name":           "Root Service",
+			"Systems":        map[string]interface{}{"@odata.id": "/redfish/v1/Systems"},
+			"AccountService": map[string]interface{}{"@odata.id": "/redfish/v1/AccountService"},
+		},
+		"/redfish/v1/Systems": map[string]interface{}{
+			"Name":                "Computer System Collection",
+			"Members":             []interface{}{map[string]interface{}{"@odata.id": "/redfish/v1/Systems/1"}},
+			"Members@odata.count": 1,
+		},
+		"/redfish/v1/Systems/1": map[string]interface{}{
+			"@odata.type": "#ComputerSystem.v1_10_0.ComputerSystem",
+			"Id":          "1",
+			"Name":        "System",
+			"AssetTag":    "",
+			"PowerState":  "On",
+			"Boot": map[string]interface{}{
+				"BootSourceOverrideEnabled": "Disabled",
+				"BootSourceOverrideTarget":  "None",
+			},
+			"Actions": map[string]interface{}{
+				"#ComputerSystem.Reset": map[string]interface{}{
+					"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset",
+				},
+			},
+		},
+		"/redfish/v1/AccountService": map[string]interface{}{
+			"Id":       "AccountService",
+			"Name":     "Account Service",
+			"Accounts": map[string]interface{}{"@odata.id": "/redfish/v1/AccountService/Accounts"},
+		},
+		"/redfish/v1/AccountService/Accounts": map[string]interface{}{
+			"Name":                "Accounts Collection",
+			"Members":             []interface{}{},
+			"Members@odata.count": 0,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error starting server: %s", err)
+	}
+	return server
+}
+
+func connect(t *testing.T, server *Server, config gofish.ClientConfig) *gofish.APIClient {
+	config.Endpoint = server.URL
+	config.HTTPClient = server.Client()
+	client, err := gofish.Connect(config)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	return client
+}
+
+func getSystem(t *testing.T, client *gofish.APIClient) *redfish.ComputerSystem {
+	systems, err := client.Service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+	if len(systems) != 1 {
+		t.Fatalf("Expected 1 system, got %d", len(systems))
+	}
+	return systems[0]
+}
+
+// TestServerSessions tests authenticating with sessions.
+func TestServerSessions(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	server.SetCredentials("admin", "secret")
+
+	_, err := gofish.Connect(gofish.ClientConfig{
+		Endpoint:   server.URL,
+		HTTPClient: server.Client(),
+		Username:   "admin",
+		Password:   "wrong",
+	})
+	if !errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("Expected unauthorized error, got: %v", err)
+	}
+
+	client := connect(t, server, gofish.ClientConfig{Username: "admin", Password: "secret"})
+	session, err := client.GetSession()
+	if err != nil {
+		t.Fatalf("Error getting session: %s", err)
+	}
+
+	if system := getSystem(t, client); system.Name != "System" {
+		t.Errorf("Unexpected system name: %s", system.Name)
+	}
+
+	sessions, err := client.Service.Sessions()
+	if err != nil {
+		t.Fatalf("Error getting sessions: %s", err)
+	}
+	if len(sessions) != 1 || sessions[0].ODataID != session.ID || sessions[0].UserName != "admin" {
+		t.Errorf("Unexpected sessions: %v", sessions)
+	}
+
+	client.Logout()
+	_, err = client.Get("/redfish/v1/Systems")
+	if !errors.Is(err, common.ErrUnauthorized) {
+		t.Errorf("Expected unauthorized error after logging out, got: %v", err)
+	}
+
+	basic := connect(t, server, gofish.ClientConfig{Username: "admin", Password: "secret", BasicAuth: true})
+	getSystem(t, basic)
+}
+
+// TestServerPatch tests that updates change the resources.
+func TestServerPatch(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	client := connect(t, server, gofish.ClientConfig{})
+
+	system := getSystem(t, client)
+	system.AssetTag = "Rack 12"
+	system.Boot.BootSourceOverrideTarget = redfish.PxeBootSourceOverrideTarget
+	if err := system.Update(); err != nil {
+		t.Fatalf("Error updating system: %s", err)
+	}
+
+	system = getSystem(t, client)
+	if system.AssetTag != "Rack 12" {
+		t.Errorf("Unexpected asset tag: %s", system.AssetTag)
+	}
+	if system.Boot.BootSourceOverrideTarget != redfish.PxeBootSourceOverrideTarget ||
+		system.Boot.BootSourceOverrideEnabled != redfish.DisabledBootSourceOverrideEnabled {
+		t.Errorf("Unexpected boot settings: %+v", system.Boot)
+	}
+
+	resource, _ := server.Resource("/redfish/v1/Systems/1")
+	if resource["AssetTag"] != "Rack 12" {
+		t.Errorf("Unexpected asset tag in server: %v", resource["AssetTag"])
+	}
+
+	_, err := client.Patch("/redfish/v1/Systems/1", map[string]interface{}{"@odata.id": "/redfish/v1/Systems/2"})
+	if err == nil {
+		t.Error("Expected an error updating an annotation")
+	}
+
+	_, err = client.Patch("/redfish/v1/Systems/2", map[string]interface{}{"AssetTag": "Rack 12"})
+	if !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+}
+
+// TestServerETags tests rejecting updates of resources changed since they
+// were read.
+func TestServerETags(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	client := connect(t, server, gofish.ClientConfig{CacheResponses: true})
+
+	system := getSystem(t, client)
+	if system.ETag() == "" {
+		t.Fatal("Expected the system to have an ETag")
+	}
+	if cached := getSystem(t, client); cached.ETag() != system.ETag() || cached.Name != system.Name {
+		t.Errorf("Unexpected cached system: %s %s", cached.ETag(), cached.Name)
+	}
+
+	// Another client changes the system
+	resource, _ := server.Resource("/redfish/v1/Systems/1")
+	resource["Name"] = "Renamed"
+	if err := server.SetResource("/redfish/v1/Systems/1", resource); err != nil {
+		t.Fatalf("Error changing resource: %s", err)
+	}
+
+	system.AssetTag = "Rack 12"
+	err := system.Update()
+	if !errors.Is(err, common.ErrPreconditionFailed) {
+		t.Fatalf("Expected precondition failed error, got: %v", err)
+	}
+
+	err = common.UpdateWithRetry(system, func() error {
+		system.AssetTag = "Rack 12"
+		return nil
+	}, 0)
+	if err != nil {
+		t.Fatalf("Error updating system: %s", err)
+	}
+
+	system = getSystem(t, client)
+	if system.Name != "Renamed" || system.AssetTag != "Rack 12" {
+		t.Errorf("Unexpected system after update: %s %s", system.Name, system.AssetTag)
+	}
+}
+
+// TestServerCollections tests creating and deleting collection members.
+func TestServerCollections(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	client := connect(t, server, gofish.ClientConfig{})
+
+	resp, err := client.Post("/redfish/v1/AccountService/Accounts", map[string]interface{}{
+		"UserName": "operator",
+		"Password": "secret",
+		"RoleId":   "Operator",
+	})
+	if err != nil {
+		t.Fatalf("Error creating account: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Unexpected status code: %d", resp.StatusCode)
+	}
+	location := resp.Header.Get("Location")
+
+	account, err := redfish.GetManagerAccount(client, location)
+	if err != nil {
+		t.Fatalf("Error getting account: %s", err)
+	}
+	if account.UserName != "operator" || account.RoleID != "Operator" {
+		t.Errorf("Unexpected account: %s %s", account.UserName, account.RoleID)
+	}
+	if resource, _ := server.Resource(location); resource["Password"] != nil {
+		t.Error("Expected the password not to be stored")
+	}
+
+	accounts, err := redfish.ListReferencedManagerAccounts(client, "/redfish/v1/AccountService/Accounts")
+	if err != nil || len(accounts) != 1 {
+		t.Errorf("Expected 1 account, got %d: %v", len(accounts), err)
+	}
+
+	if _, err := client.Delete(location); err != nil {
+		t.Fatalf("Error deleting account: %s", err)
+	}
+	if _, err := redfish.GetManagerAccount(client, location); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+
+	collection, _ := server.Resource("/redfish/v1/AccountService/Accounts")
+	if collection["Members@odata.count"] != 0 {
+		t.Errorf("Unexpected member count: %v", collection["Members@odata.count"])
+	}
+}
@@ -0,0 +1,166 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// RetryPolicy controls how the APIClient retries requests that failed because
+// of a transient problem, such as a BMC that is rebooting or too busy to
+// answer.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Defaults to one
+	// second.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, including delays
+	// requested by the service through the Retry-After header. Defaults to 30
+	// seconds.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each attempt. Defaults
+	// to 2.
+	Multiplier float64
+	// Jitter is the fraction of the delay, between 0 and 1, that is randomized
+	// to avoid many clients retrying in lockstep.
+	Jitter float64
+	// RetryStatusCodes lists the HTTP status codes considered transient.
+	// Defaults to 429, 502, 503 and 504.
+	RetryStatusCodes []int
+	// RetryNonIdempotent allows POST and PATCH requests to be retried. Only
+	// enable this if the actions being invoked are safe to repeat.
+	RetryNonIdempotent bool
+	// OnRetry is optionally called before waiting for the next attempt.
+	// statusCode is 0 if the attempt failed without a response, in which case
+	// err holds the reason.
+	OnRetry func(attempt int, method, url string, statusCode int, err error, delay time.Duration)
+	// Sleep waits for the given delay, returning early with an error if ctx
+	// is done. It defaults to a timer based wait and can be replaced in tests
+	// to avoid real delays.
+	Sleep func(ctx context.Context, delay time.Duration) error
+}
+
+// DefaultRetryPolicy returns a policy making up to four attempts with
+// exponential backoff and jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// defaultRetryStatusCodes are the status codes retried when the policy does
+// not list any.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// allowsMethod checks whether requests with the given method may be retried.
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost, http.MethodPatch:
+		return p.RetryNonIdempotent
+	}
+	return false
+}
+
+// retryableStatus checks whether the status code is considered transient.
+func (p *RetryPolicy) retryableStatus(statusCode int) bool {
+	codes := p.RetryStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryStatusCodes
+	}
+	for _, code := range codes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry decides whether another attempt should be made after the given
+// attempt (counting from 1) produced resp or err.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, attempt int, method string, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || !p.allowsMethod(method) {
+		return false
+	}
+
+	if err != nil {
+		// Errors caused by the caller giving up are not transient
+		return ctx.Err() == nil
+	}
+
+	return p.retryableStatus(resp.StatusCode)
+}
+
+// Backoff returns the delay to wait after the given attempt (counting from 1)
+// before trying again. A Retry-After header in resp takes precedence over the
+// computed exponential backoff. Both are capped at MaxBackoff.
+func (p *RetryPolicy) Backoff(attempt int, resp *http.Response) time.Duration {
+	maximum := p.MaxBackoff
+	if maximum <= 0 {
+		maximum = 30 * time.Second
+	}
+	if delay, ok := common.RetryAfter(resp); ok {
+		if delay > maximum {
+			return maximum
+		}
+		return delay
+	}
+
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = time.Second
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(maximum) {
+		delay = float64(maximum)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay = delay * (1 - jitter + 2*jitter*rand.Float64()) //nolint:gosec
+	}
+
+	return time.Duration(delay)
+}
+
+// wait pauses before the next attempt.
+func (p *RetryPolicy) wait(ctx context.Context, delay time.Duration) error {
+	if p.Sleep != nil {
+		return p.Sleep(ctx, delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
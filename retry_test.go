@@ -0,0 +1,272 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// retryTestServer returns a server answering the service root and failing
+// the first failures requests to other paths with the given status.
+func retryTestServer(failures int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == common.DefaultServiceRoot {
+			w.Write([]byte(`{"Id": "RootService"}`)) //nolint
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost && string(body) != `{"ResetType":"On"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if atomic.AddInt32(&calls, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"Id": "1"}`)) //nolint
+	}))
+	return ts, &calls
+}
+
+// recordingSleep returns a Sleep hook recording the requested delays.
+func recordingSleep(delays *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, delay time.Duration) error {
+		*delays = append(*delays, delay)
+		return nil
+	}
+}
+
+// TestRetryTransientFailures tests retrying until the service recovers.
+func TestRetryTransientFailures(t *testing.T) {
+	ts, calls := retryTestServer(2, http.StatusServiceUnavailable, "")
+	defer ts.Close()
+
+	var delays []time.Duration
+	var retried []int
+	policy := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     150 * time.Millisecond,
+		Sleep:          recordingSleep(&delays),
+		OnRetry: func(attempt int, method, url string, statusCode int, err error, delay time.Duration) {
+			retried = append(retried, statusCode)
+		},
+	}
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	resp, err := client.Get("/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Request should have succeeded after retries: %s", err)
+	}
+	resp.Body.Close()
+
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+
+	if len(delays) != 2 || delays[0] != 100*time.Millisecond || delays[1] != 150*time.Millisecond {
+		t.Errorf("Unexpected backoff delays: %v", delays)
+	}
+
+	if len(retried) != 2 || retried[0] != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected OnRetry calls: %v", retried)
+	}
+}
+
+// TestRetryAfterHeader tests honouring the delay requested by the service.
+func TestRetryAfterHeader(t *testing.T) {
+	ts, _ := retryTestServer(1, http.StatusServiceUnavailable, "7")
+	defer ts.Close()
+
+	var delays []time.Duration
+	policy := &RetryPolicy{MaxAttempts: 3, Sleep: recordingSleep(&delays)}
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	resp, err := client.Get("/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Request should have succeeded after retries: %s", err)
+	}
+	resp.Body.Close()
+
+	if len(delays) != 1 || delays[0] != 7*time.Second {
+		t.Errorf("Expected Retry-After delay of 7s, got: %v", delays)
+	}
+}
+
+// TestRetryAfterHeaderCapped tests that the delay requested by the service
+// does not exceed MaxBackoff.
+func TestRetryAfterHeaderCapped(t *testing.T) {
+	ts, _ := retryTestServer(1, http.StatusServiceUnavailable, "3600")
+	defer ts.Close()
+
+	var delays []time.Duration
+	policy := &RetryPolicy{MaxAttempts: 3, MaxBackoff: 10 * time.Second, Sleep: recordingSleep(&delays)}
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	resp, err := client.Get("/redfish/v1/Systems/1")
+	if err != nil {
+		t.Fatalf("Request should have succeeded after retries: %s", err)
+	}
+	resp.Body.Close()
+
+	if len(delays) != 1 || delays[0] != 10*time.Second {
+		t.Errorf("Expected Retry-After delay capped at 10s, got: %v", delays)
+	}
+}
+
+// TestRetryExhausted tests returning the last error once attempts run out.
+func TestRetryExhausted(t *testing.T) {
+	ts, calls := retryTestServer(10, http.StatusGatewayTimeout, "")
+	defer ts.Close()
+
+	var delays []time.Duration
+	policy := &RetryPolicy{MaxAttempts: 3, Sleep: recordingSleep(&delays)}
+
+	client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = client.Get("/redfish/v1/Systems/1") //nolint:bodyclose
+	if err == nil {
+		t.Fatal("Request should have failed")
+	}
+
+	if err.(*common.Error).HTTPReturnedStatusCode != http.StatusGatewayTimeout {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+}
+
+// TestRetryNonIdempotent tests POST requests are only retried when allowed.
+func TestRetryNonIdempotent(t *testing.T) {
+	for _, allowed := range []bool{false, true} {
+		ts, calls := retryTestServer(1, http.StatusServiceUnavailable, "")
+
+		var delays []time.Duration
+		policy := &RetryPolicy{
+			MaxAttempts:        3,
+			RetryNonIdempotent: allowed,
+			Sleep:              recordingSleep(&delays),
+		}
+
+		client, err := Connect(ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+		if err != nil {
+			t.Fatalf("Error connecting: %s", err)
+		}
+
+		resp, err := client.Post("/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", map[string]string{"ResetType": "On"})
+		if allowed {
+			if err != nil {
+				t.Errorf("POST should have been retried: %s", err)
+			} else {
+				resp.Body.Close()
+			}
+			if *calls != 2 {
+				t.Errorf("Expected 2 attempts, got %d", *calls)
+			}
+		} else {
+			if err == nil {
+				resp.Body.Close()
+				t.Error("POST should not have been retried")
+			}
+			if *calls != 1 {
+				t.Errorf("Expected 1 attempt, got %d", *calls)
+			}
+		}
+
+		ts.Close()
+	}
+}
+
+// TestRetryBackoff tests the computed backoff delays.
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, delay := range expected {
+		if got := policy.Backoff(i+1, nil); got != delay {
+			t.Errorf("Attempt %d: expected %s, got %s", i+1, delay, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		got := policy.Backoff(1, nil)
+		if got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Errorf("Jittered delay out of range: %s", got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"Wed, 21 Oct 2015 07:28:00 GMT"}}}
+	if got := policy.Backoff(1, resp); got != 0 {
+		t.Errorf("Retry-After date in the past should not wait, got %s", got)
+	}
+}
+
+// TestRetryContextCancelled tests giving up when the client context is done.
+func TestRetryContextCancelled(t *testing.T) {
+	ts, calls := retryTestServer(10, http.StatusServiceUnavailable, "")
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policy := &RetryPolicy{
+		MaxAttempts: 10,
+		Sleep: func(ctx context.Context, delay time.Duration) error {
+			cancel()
+			return ctx.Err()
+		},
+	}
+
+	client, err := ConnectContext(ctx, ClientConfig{Endpoint: ts.URL, HTTPClient: ts.Client(), RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	_, err = client.Get("/redfish/v1/Systems/1") //nolint:bodyclose
+	if err == nil || !strings.Contains(err.Error(), "canceled") {
+		t.Errorf("Expected cancellation error, got: %v", err)
+	}
+
+	if *calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", *calls)
+	}
+}
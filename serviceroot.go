@@ -57,6 +57,23 @@ type ProtocolFeaturesSupported struct {
 	// SelectQuery shall be a boolean indicating whether this service supports
 	// the use of the $select query parameter as described by the specification.
 	SelectQuery bool
+	// TopSkipQuery shall be a boolean indicating whether this service supports
+	// the use of the $top and $skip query parameters as described by the
+	// specification.
+	TopSkipQuery bool
+}
+
+// QuerySupport returns the query parameters supported by the service.
+func (features *ProtocolFeaturesSupported) QuerySupport() common.QuerySupport {
+	return common.QuerySupport{
+		ExpandAll:     features.ExpandQuery.ExpandAll,
+		ExpandNoLinks: features.ExpandQuery.NoLinks,
+		ExpandLevels:  features.ExpandQuery.Levels && features.ExpandQuery.MaxLevels >= 1,
+		Select:        features.SelectQuery,
+		Filter:        features.FilterQuery,
+		TopSkip:       features.TopSkipQuery,
+		Only:          features.OnlyMemberQuery,
+	}
 }
 
 // Service represents the root Redfish service. All values for resources
@@ -228,6 +245,23 @@ func ServiceRoot(c common.Client) (*Service, error) {
 	return &serviceroot, nil
 }
 
+// WithQuery returns a copy of the service whose requests apply the given
+// query options. Options the service does not advertise in its
+// ProtocolFeaturesSupported are ignored. Resources retrieved from the
+// returned service keep using the query options. The Get* and ListReferenced*
+// functions only apply them when given the *common.QueryClient of the
+// service, not another Client wrapping it.
+func (serviceroot *Service) WithQuery(options common.QueryOptions) *Service {
+	client := serviceroot.Client
+	if qc, ok := client.(*common.QueryClient); ok {
+		client = qc.Client
+	}
+
+	result := *serviceroot
+	result.SetClient(common.NewQueryClient(client, options, serviceroot.ProtocolFeaturesSupported.QuerySupport()))
+	return &result
+}
+
 // Chassis gets the chassis instances managed by this service.
 func (serviceroot *Service) Chassis() ([]*redfish.Chassis, error) {
 	return redfish.ListReferencedChassis(serviceroot.Client, serviceroot.chassis)
@@ -327,6 +361,13 @@ func (serviceroot *Service) MessageByLanguage(messageID, language string) (*redf
 	return redfish.GetMessageFromMessageRegistryByLanguage(serviceroot.Client, serviceroot.registries, messageID, language)
 }
 
+// MessageResolver creates a resolver completing the messages of the service
+// with its message registries in the given language. The resolver caches the
+// registries, so it should be reused rather than created for each message.
+func (serviceroot *Service) MessageResolver(language string) *redfish.MessageResolver {
+	return redfish.NewMessageResolver(serviceroot.Client, serviceroot.registries, language)
+}
+
 // Systems get the system instances from the service
 func (serviceroot *Service) Systems() ([]*redfish.ComputerSystem, error) {
 	return redfish.ListReferencedComputerSystems(serviceroot.Client, serviceroot.systems)
@@ -337,6 +378,31 @@ func (serviceroot *Service) CompositionService() (*redfish.CompositionService, e
 	return redfish.GetCompositionService(serviceroot.Client, serviceroot.compositionService)
 }
 
+// CertificateService gets the certificate service instance
+func (serviceroot *Service) CertificateService() (*redfish.CertificateService, error) {
+	return redfish.GetCertificateService(serviceroot.Client, serviceroot.certificateService)
+}
+
+// JobService gets the job service instance
+func (serviceroot *Service) JobService() (*redfish.JobService, error) {
+	return redfish.GetJobService(serviceroot.Client, serviceroot.jobService)
+}
+
+// ResourceBlocks gets the resource blocks of the service
+func (serviceroot *Service) ResourceBlocks() ([]*redfish.ResourceBlock, error) {
+	return redfish.ListReferencedResourceBlocks(serviceroot.Client, serviceroot.resourceBlocks)
+}
+
+// TelemetryService gets the telemetry service instance
+func (serviceroot *Service) TelemetryService() (*redfish.TelemetryService, error) {
+	return redfish.GetTelemetryService(serviceroot.Client, serviceroot.telemetryService)
+}
+
+// Fabrics gets the fabrics of the service
+func (serviceroot *Service) Fabrics() ([]*redfish.Fabric, error) {
+	return redfish.ListReferencedFabrics(serviceroot.Client, serviceroot.fabrics)
+}
+
 // UpdateService gets the update service instance
 func (serviceroot *Service) UpdateService() (*redfish.UpdateService, error) {
 	return redfish.GetUpdateService(serviceroot.Client, serviceroot.updateService)
@@ -6,8 +6,15 @@ package gofish
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
+	"testing/fstest"
+
+	"github.com/bcohee/gofish/common"
+	"github.com/bcohee/gofish/redfish"
 )
 
 var serviceRootBody = strings.NewReader(
@@ -237,3 +244,112 @@ func TestServiceRootOEM(t *testing.T) {
 		t.Errorf("Expect\n%s\n,Obtain\n%s", oemExp, oemObt)
 	}
 }
+
+// TestServiceRootWithQuery tests fetching a collection with its members
+// expanded.
+func TestServiceRootWithQuery(t *testing.T) {
+	var result Service
+	err := json.Unmarshal([]byte(`{
+		"Systems": {"@odata.id": "/redfish/v1/Systems"},
+		"ProtocolFeaturesSupported": {
+			"ExpandQuery": {"ExpandAll": true, "Levels": true, "MaxLevels": 6, "NoLinks": true},
+			"TopSkipQuery": true
+		}
+	}`), &result)
+	if err != nil {
+		t.Errorf("Error decoding JSON: %s", err)
+	}
+
+	systemsBody := `{
+		"Members@odata.count": 2,
+		"Members": [
+			{"@odata.id": "/redfish/v1/Systems/1", "Id": "1", "Name": "System 1"},
+			{"@odata.id": "/redfish/v1/Systems/2", "Id": "2", "Name": "System 2"}
+		]
+	}`
+	testClient := &common.TestClient{
+		CustomReturnForActions: map[string][]interface{}{
+			http.MethodGet: {
+				&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(systemsBody))},
+			},
+		},
+	}
+	result.SetClient(testClient)
+
+	systems, err := result.WithQuery(common.QueryOptions{Expand: true, Top: 2}).Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+
+	if len(systems) != 2 {
+		t.Errorf("Expected 2 systems, got %d", len(systems))
+	}
+
+	calls := testClient.CapturedCalls()
+	if len(calls) != 1 || calls[0].URL != "/redfish/v1/Systems?$expand=.($levels=1)&$top=2" {
+		t.Errorf("Unexpected requests: %#v", calls)
+	}
+
+	if result.Client != testClient {
+		t.Error("Original service should keep its client")
+	}
+}
+
+// TestServiceRootFromFiles tests using the library offline on a mockup.
+func TestServiceRootFromFiles(t *testing.T) {
+	client, err := common.NewFileClientFS(fstest.MapFS{
+		"redfish/v1/index.json": {Data: []byte(`{
+			"@odata.id": "/redfish/v1/",
+			"Id": "RootService",
+			"Systems": {"@odata.id": "/redfish/v1/Systems"}
+		}`)},
+		"redfish/v1/Systems/index.json": {Data: []byte(`{
+			"@odata.id": "/redfish/v1/Systems",
+			"Members": [{"@odata.id": "/redfish/v1/Systems/1"}],
+			"Members@odata.count": 1
+		}`)},
+		"redfish/v1/Systems/1/index.json": {Data: []byte(`{
+			"@odata.id": "/redfish/v1/Systems/1",
+			"@odata.etag": "W/\"1\"",
+			"Id": "1",
+			"Name": "Captured System",
+			"AssetTag": "Rack 12",
+			"Actions": {
+				"#ComputerSystem.Reset": {"target": "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset"}
+			}
+		}`)},
+	})
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+
+	service, err := ServiceRoot(client)
+	if err != nil {
+		t.Fatalf("Error getting service root: %s", err)
+	}
+
+	systems, err := service.Systems()
+	if err != nil {
+		t.Fatalf("Error getting systems: %s", err)
+	}
+	if len(systems) != 1 || systems[0].Name != "Captured System" || systems[0].AssetTag != "Rack 12" {
+		t.Fatalf("Unexpected systems: %v", systems)
+	}
+
+	system := systems[0]
+	if system.ETag() != `W/"1"` {
+		t.Errorf("Unexpected ETag: %s", system.ETag())
+	}
+
+	system.AssetTag = "Rack 13"
+	if err := system.Update(); !errors.Is(err, common.ErrReadOnly) {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+	if err := system.Reset(redfish.OnResetType); !errors.Is(err, common.ErrReadOnly) {
+		t.Errorf("Expected read only error, got: %v", err)
+	}
+
+	if _, err := redfish.GetComputerSystem(client, "/redfish/v1/Systems/2"); !errors.Is(err, common.ErrNotFound) {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+}
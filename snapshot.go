@@ -0,0 +1,312 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bcohee/gofish/common"
+)
+
+// Snapshot holds the resources of a service at a point in time, to compare
+// them with another snapshot.
+type Snapshot struct {
+	// Resources holds the resources by URI.
+	Resources map[string]map[string]interface{}
+	// Failed holds the sorted URIs of the resources that could not be
+	// fetched. Their presence, and that of the resources below them, is
+	// unknown.
+	Failed []string
+}
+
+// NewSnapshot crawls the resources of a service into a snapshot. The options
+// are those of Crawl, except for Dir which is ignored. Resources that cannot
+// be fetched are left out of the snapshot and recorded in its Failed list,
+// and their errors are returned as a common.CollectionError along with it.
+func NewSnapshot(c common.Client, options CrawlOptions) (*Snapshot, error) { //nolint:gocritic
+	snapshot := &Snapshot{Resources: make(map[string]map[string]interface{})}
+
+	var mu sync.Mutex
+	crawler := newCrawler(c, options)
+	crawler.store = func(uri string, body map[string]interface{}, header http.Header) error {
+		mu.Lock()
+		defer mu.Unlock()
+		snapshot.Resources[uri] = body
+		return nil
+	}
+	crawler.run()
+
+	if crawler.collectionError.Empty() {
+		return snapshot, nil
+	}
+	for uri := range crawler.collectionError.Failures {
+		snapshot.Failed = append(snapshot.Failed, uri)
+	}
+	sort.Strings(snapshot.Failed)
+	return snapshot, crawler.collectionError
+}
+
+// LoadSnapshot reads a snapshot from a directory in the layout of the DMTF
+// Redfish mockups, such as one written by Crawl.
+func LoadSnapshot(dir string, options CrawlOptions) (*Snapshot, error) { //nolint:gocritic
+	c, err := common.NewFileClient(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(c, options)
+}
+
+// DefaultVolatileProperties lists the patterns of the properties changing on
+// their own, such as readings and timestamps, ignored by Diff by default.
+var DefaultVolatileProperties = []string{
+	"Reading*",
+	"*ConsumedWatts",
+	"*DateTime*",
+	"*Time",
+	"*Timestamp",
+	"PowerOnHours",
+	"UptimeSeconds",
+}
+
+// DiffOptions controls how Diff compares snapshots.
+type DiffOptions struct {
+	// IgnoreProperties lists patterns of properties not to compare, with the
+	// path.Match syntax. A pattern matches a property name, for example
+	// "Reading*", or a property path, for example "Status.State". Defaults to
+	// DefaultVolatileProperties.
+	IgnoreProperties []string
+}
+
+// SnapshotDiff describes how resources changed between two snapshots.
+type SnapshotDiff struct {
+	// Added holds the URIs of the resources found only in the new snapshot.
+	Added []string `json:"added,omitempty"`
+	// Removed holds the URIs of the resources found only in the old snapshot.
+	Removed []string `json:"removed,omitempty"`
+	// Changed holds the resources found in both snapshots with different
+	// properties.
+	Changed []ResourceChange `json:"changed,omitempty"`
+	// Failed holds the URIs of the resources that could not be fetched for
+	// either snapshot. Resources at or below them are not reported as added
+	// or removed, since their presence on the other side is unknown.
+	Failed []string `json:"failed,omitempty"`
+}
+
+// ResourceChange describes how the properties of a resource changed.
+type ResourceChange struct {
+	// ODataID is the URI of the resource.
+	ODataID string `json:"@odata.id"`
+	// Properties holds the changed properties, sorted by path.
+	Properties []PropertyChange `json:"properties"`
+}
+
+// PropertyChange describes a changed property.
+type PropertyChange struct {
+	// Path is the path of the property in the resource, for example
+	// "Status.Health", "Attributes.BootMode" or "Links.Drives[2]".
+	Path string `json:"path"`
+	// Old is the value in the old snapshot, nil if it was missing.
+	Old interface{} `json:"old"`
+	// New is the value in the new snapshot, nil if it is missing.
+	New interface{} `json:"new"`
+}
+
+// Empty reports whether the snapshots were the same.
+func (diff *SnapshotDiff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// Diff compares two snapshots of a service by resource URI. Members added to
+// or removed from collections are reported as added or removed resources
+// rather than as changes of the collections. Resources that failed to be
+// fetched for either snapshot are reported as failed instead.
+func Diff(old, current *Snapshot, options DiffOptions) *SnapshotDiff {
+	ignored := options.IgnoreProperties
+	if ignored == nil {
+		ignored = DefaultVolatileProperties
+	}
+
+	diff := &SnapshotDiff{Failed: mergeFailed(old.Failed, current.Failed)}
+	for uri := range old.Resources {
+		if _, ok := current.Resources[uri]; !ok && !diff.failed(uri) {
+			diff.Removed = append(diff.Removed, uri)
+		}
+	}
+	for uri, resource := range current.Resources {
+		oldResource, ok := old.Resources[uri]
+		if !ok {
+			if !diff.failed(uri) {
+				diff.Added = append(diff.Added, uri)
+			}
+			continue
+		}
+
+		differ := &snapshotDiffer{ignored: ignored}
+		differ.compareObjects("", oldResource, resource)
+		if len(differ.changes) > 0 {
+			sort.Slice(differ.changes, func(i, j int) bool {
+				return differ.changes[i].Path < differ.changes[j].Path
+			})
+			diff.Changed = append(diff.Changed, ResourceChange{ODataID: uri, Properties: differ.changes})
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].ODataID < diff.Changed[j].ODataID
+	})
+	return diff
+}
+
+// mergeFailed returns the sorted union of the failed URIs of two snapshots.
+func mergeFailed(old, current []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, uri := range append(append([]string{}, old...), current...) {
+		if !seen[uri] {
+			seen[uri] = true
+			result = append(result, uri)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// failed reports whether a resource is, or is below, one that failed to be
+// fetched.
+func (diff *SnapshotDiff) failed(uri string) bool {
+	for _, failed := range diff.Failed {
+		if uri == failed || strings.HasPrefix(uri, strings.TrimSuffix(failed, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedDiffProperties lists the properties of resources never compared:
+// the ETag, and the members of collections since their changes are reported
+// as added or removed resources.
+var skippedDiffProperties = map[string]bool{
+	"@odata.etag":            true,
+	"Members":                true,
+	"Members@odata.count":    true,
+	"Members@odata.nextLink": true,
+}
+
+// snapshotDiffer collects the property changes of a resource.
+type snapshotDiffer struct {
+	ignored []string
+	changes []PropertyChange
+}
+
+// isIgnored checks whether a property is left out of the comparison.
+func (differ *snapshotDiffer) isIgnored(name, propertyPath string) bool {
+	for _, pattern := range differ.ignored {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, propertyPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// compareObjects compares the properties of two JSON objects.
+func (differ *snapshotDiffer) compareObjects(prefix string, old, current map[string]interface{}) {
+	names := make(map[string]bool)
+	for name := range old {
+		names[name] = true
+	}
+	for name := range current {
+		names[name] = true
+	}
+
+	for name := range names {
+		if prefix == "" && skippedDiffProperties[name] {
+			continue
+		}
+		propertyPath := name
+		if prefix != "" {
+			propertyPath = prefix + "." + name
+		}
+		if differ.isIgnored(name, propertyPath) {
+			continue
+		}
+		differ.compare(propertyPath, old[name], current[name])
+	}
+}
+
+// compare compares two JSON values.
+func (differ *snapshotDiffer) compare(propertyPath string, old, current interface{}) {
+	oldObject, oldIsObject := old.(map[string]interface{})
+	newObject, newIsObject := current.(map[string]interface{})
+	if (oldIsObject || old == nil) && (newIsObject || current == nil) && (oldIsObject || newIsObject) {
+		// Objects are compared property by property, even when missing on
+		// one side
+		differ.compareObjects(propertyPath, oldObject, newObject)
+		return
+	}
+
+	oldArray, oldIsArray := old.([]interface{})
+	newArray, newIsArray := current.([]interface{})
+	if oldIsArray && newIsArray && len(oldArray) == len(newArray) {
+		for i := range oldArray {
+			differ.compare(fmt.Sprintf("%s[%d]", propertyPath, i), oldArray[i], newArray[i])
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(old, current) {
+		differ.changes = append(differ.changes, PropertyChange{Path: propertyPath, Old: old, New: current})
+	}
+}
+
+// WriteText writes the differences in a human readable form: added resources
+// are prefixed with "+", removed ones with "-" and changed ones with "~",
+// followed by their changed properties. Resources that could not be fetched
+// are prefixed with "?".
+func (diff *SnapshotDiff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, uri := range diff.Added {
+		fmt.Fprintf(&b, "+ %s\n", uri)
+	}
+	for _, uri := range diff.Removed {
+		fmt.Fprintf(&b, "- %s\n", uri)
+	}
+	for _, change := range diff.Changed {
+		fmt.Fprintf(&b, "~ %s\n", change.ODataID)
+		for _, property := range change.Properties {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", property.Path, formatDiffValue(property.Old), formatDiffValue(property.New))
+		}
+	}
+	for _, uri := range diff.Failed {
+		fmt.Fprintf(&b, "? %s\n", uri)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatDiffValue formats a JSON value on a single line.
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "(none)"
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
@@ -0,0 +1,135 @@
+//
+// SPDX-License-Identifier: BSD-3-Clause
+//
+
+package gofish
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSnapshotDiff tests comparing a crawl written to disk with the live
+// service.
+func TestSnapshotDiff(t *testing.T) {
+	server := newCrawlServer(t)
+	defer server.Close()
+	client, err := Connect(ClientConfig{Endpoint: server.URL, HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	dir := t.TempDir()
+	if _, err := Crawl(client, CrawlOptions{Dir: dir}); err == nil {
+		t.Fatal("Expected the missing chassis to fail")
+	}
+	old, err := LoadSnapshot(dir, CrawlOptions{})
+	if err == nil {
+		t.Fatal("Expected the missing chassis to fail")
+	}
+
+	system, _ := server.Resource("/redfish/v1/Systems/1")
+	system["SerialNumber"] = "XYZ789"
+	system["LastResetTime"] = "2026-10-18T08:00:00Z"
+	system["Status"] = map[string]interface{}{"Health": "Warning"}
+	if err := server.SetResource("/redfish/v1/Systems/1", system); err != nil {
+		t.Fatalf("Error changing system: %s", err)
+	}
+	if _, err := client.Delete("/redfish/v1/Systems/1/EthernetInterfaces/1"); err != nil {
+		t.Fatalf("Error deleting interface: %s", err)
+	}
+	resp, err := client.Post("/redfish/v1/Managers", map[string]interface{}{"Name": "Manager"})
+	if err != nil {
+		t.Fatalf("Error adding manager: %s", err)
+	}
+	resp.Body.Close()
+	manager := resp.Header.Get("Location")
+
+	current, err := NewSnapshot(client, CrawlOptions{})
+	if err == nil {
+		t.Fatal("Expected the missing chassis to fail")
+	}
+
+	diff := Diff(old, current, DiffOptions{})
+	if !reflect.DeepEqual(diff.Added, []string{manager}) {
+		t.Errorf("Unexpected added resources: %v", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []string{"/redfish/v1/Systems/1/EthernetInterfaces/1"}) {
+		t.Errorf("Unexpected removed resources: %v", diff.Removed)
+	}
+	if !reflect.DeepEqual(diff.Failed, []string{"/redfish/v1/Chassis/1"}) {
+		t.Errorf("Unexpected failed resources: %v", diff.Failed)
+	}
+	expected := []ResourceChange{{
+		ODataID: "/redfish/v1/Systems/1",
+		Properties: []PropertyChange{
+			{Path: "SerialNumber", Old: "ABC123", New: "XYZ789"},
+			{Path: "Status.Health", Old: nil, New: "Warning"},
+		},
+	}}
+	if !reflect.DeepEqual(diff.Changed, expected) {
+		t.Errorf("Unexpected changes: %+v", diff.Changed)
+	}
+
+	var text strings.Builder
+	if err := diff.WriteText(&text); err != nil {
+		t.Fatalf("Error writing diff: %s", err)
+	}
+	expectedText := "+ " + manager + "\n" +
+		"- /redfish/v1/Systems/1/EthernetInterfaces/1\n" +
+		"~ /redfish/v1/Systems/1\n" +
+		"    SerialNumber: \"ABC123\" -> \"XYZ789\"\n" +
+		"    Status.Health: (none) -> \"Warning\"\n" +
+		"? /redfish/v1/Chassis/1\n"
+	if text.String() != expectedText {
+		t.Errorf("Unexpected text:\n%s", text.String())
+	}
+
+	b, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatalf("Error encoding diff: %s", err)
+	}
+	if !strings.Contains(string(b), `{"path":"Status.Health","old":null,"new":"Warning"}`) {
+		t.Errorf("Unexpected JSON: %s", b)
+	}
+
+	if diff := Diff(current, current, DiffOptions{}); !diff.Empty() {
+		t.Errorf("Expected no differences, got: %+v", diff)
+	}
+	diff = Diff(old, current, DiffOptions{IgnoreProperties: append([]string{"SerialNumber", "Status"}, DefaultVolatileProperties...)})
+	if len(diff.Changed) != 0 {
+		t.Errorf("Expected the properties to be ignored, got: %+v", diff.Changed)
+	}
+}
+
+// TestSnapshotDiffFailed tests that resources which could not be fetched are
+// not reported as added or removed.
+func TestSnapshotDiffFailed(t *testing.T) {
+	old := &Snapshot{Resources: map[string]map[string]interface{}{
+		"/redfish/v1/Systems":                        {"Name": "Systems"},
+		"/redfish/v1/Systems/1":                      {"Name": "System"},
+		"/redfish/v1/Systems/1/EthernetInterfaces/1": {"Name": "NIC"},
+		"/redfish/v1/Managers/1":                     {"Name": "Manager"},
+	}}
+	current := &Snapshot{
+		Resources: map[string]map[string]interface{}{
+			"/redfish/v1/Systems": {"Name": "Systems"},
+		},
+		Failed: []string{"/redfish/v1/Systems/1"},
+	}
+
+	diff := Diff(old, current, DiffOptions{})
+	if !reflect.DeepEqual(diff.Removed, []string{"/redfish/v1/Managers/1"}) {
+		t.Errorf("Unexpected removed resources: %v", diff.Removed)
+	}
+	if !reflect.DeepEqual(diff.Failed, []string{"/redfish/v1/Systems/1"}) {
+		t.Errorf("Unexpected failed resources: %v", diff.Failed)
+	}
+
+	diff = Diff(current, old, DiffOptions{})
+	if !reflect.DeepEqual(diff.Added, []string{"/redfish/v1/Managers/1"}) {
+		t.Errorf("Unexpected added resources: %v", diff.Added)
+	}
+}
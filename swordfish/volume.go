@@ -6,7 +6,6 @@ package swordfish
 
 import (
 	"encoding/json"
-	"fmt"
 	"reflect"
 
 	"github.com/bcohee/gofish/common"
@@ -705,7 +704,7 @@ func (volume *Volume) StoragePools() ([]*StoragePool, error) {
 func (volume *Volume) AssignReplicaTarget(replicaType ReplicaType, updateMode ReplicaUpdateMode, targetVolumeODataID string) error {
 	// This action wasn't added until later revisions
 	if volume.assignReplicaTargetTarget == "" {
-		return fmt.Errorf("AssignReplicaTarget action is not supported by this system")
+		return common.NewActionNotSupportedError("AssignReplicaTarget action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -727,7 +726,7 @@ func (volume *Volume) AssignReplicaTarget(replicaType ReplicaType, updateMode Re
 // data to ensure it matches calculated values.
 func (volume *Volume) CheckConsistency() error {
 	if volume.checkConsistencyTarget == "" {
-		return fmt.Errorf("CheckConsistency action is not supported by this system")
+		return common.NewActionNotSupportedError("CheckConsistency action is not supported by this system")
 	}
 
 	return volume.Post(volume.checkConsistencyTarget, nil)
@@ -736,7 +735,7 @@ func (volume *Volume) CheckConsistency() error {
 // Initialize is used to prepare the contents of the volume for use by the system.
 func (volume *Volume) Initialize(initType InitializeType) error {
 	if volume.initializeTarget == "" {
-		return fmt.Errorf("initialize action is not supported by this system")
+		return common.NewActionNotSupportedError("initialize action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -748,13 +747,32 @@ func (volume *Volume) Initialize(initType InitializeType) error {
 	return volume.Post(volume.initializeTarget, t)
 }
 
+// InitializeWithTask is used to prepare the contents of the volume for use
+// by the system and returns a TaskMonitor to follow the initialization when
+// the service completes it asynchronously.
+func (volume *Volume) InitializeWithTask(initType InitializeType) (*redfish.TaskMonitor, error) {
+	if volume.initializeTarget == "" {
+		return nil, common.NewActionNotSupportedError("initialize action is not supported by this system")
+	}
+
+	t := struct {
+		InitializeType InitializeType
+	}{InitializeType: initType}
+
+	resp, err := volume.PostWithResponse(volume.initializeTarget, t)
+	if err != nil {
+		return nil, err
+	}
+	return redfish.NewTaskMonitor(volume.Client, resp)
+}
+
 // RemoveReplicaRelationship is used to disable data synchronization between a
 // source and target volume, remove the replication relationship, and optionally
 // delete the target volume.
 func (volume *Volume) RemoveReplicaRelationship(deleteTarget bool, targetVolumeODataID string) error {
 	// This action wasn't added until later revisions
 	if volume.removeReplicaRelationshipTarget == "" {
-		return fmt.Errorf("RemoveReplicaRelationship action is not supported by this system")
+		return common.NewActionNotSupportedError("RemoveReplicaRelationship action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -776,7 +794,7 @@ func (volume *Volume) RemoveReplicaRelationship(deleteTarget bool, targetVolumeO
 func (volume *Volume) ResumeReplication(targetVolumeODataID string) error {
 	// This action wasn't added until later revisions
 	if volume.resumeReplicationTarget == "" {
-		return fmt.Errorf("ResumeReplication action is not supported by this system")
+		return common.NewActionNotSupportedError("ResumeReplication action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -793,7 +811,7 @@ func (volume *Volume) ResumeReplication(targetVolumeODataID string) error {
 func (volume *Volume) ReverseReplicationRelationship(targetVolumeODataID string) error {
 	// This action wasn't added until later revisions
 	if volume.reverseReplicationRelationshipTarget == "" {
-		return fmt.Errorf("ReverseReplicationRelationship action is not supported by this system")
+		return common.NewActionNotSupportedError("ReverseReplicationRelationship action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -810,7 +828,7 @@ func (volume *Volume) ReverseReplicationRelationship(targetVolumeODataID string)
 func (volume *Volume) SplitReplication(targetVolumeODataID string) error {
 	// This action wasn't added until later revisions
 	if volume.splitReplicationTarget == "" {
-		return fmt.Errorf("SplitReplication action is not supported by this system")
+		return common.NewActionNotSupportedError("SplitReplication action is not supported by this system")
 	}
 
 	// Define this action's parameters
@@ -828,7 +846,7 @@ func (volume *Volume) SplitReplication(targetVolumeODataID string) error {
 func (volume *Volume) SuspendReplication(targetVolumeODataID string) error {
 	// This action wasn't added until later revisions
 	if volume.suspendReplicationTarget == "" {
-		return fmt.Errorf("SuspendReplication action is not supported by this system")
+		return common.NewActionNotSupportedError("SuspendReplication action is not supported by this system")
 	}
 
 	// Define this action's parameters